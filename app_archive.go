@@ -0,0 +1,114 @@
+package main
+
+import (
+	"daedalus/pkg/daedalus"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// ArchiveList moves a list to .archive/ instead of deleting it outright, recording its card
+// count and byte total so it can be reviewed (see ListArchived) or restored (see
+// UnarchiveList) later.
+func (a *App) ArchiveList(name string) error {
+	if a.board == nil {
+		return fmt.Errorf("board not loaded")
+	}
+	a.pauseWatcher()
+
+	cards, ok := a.board.Lists[name]
+	if !ok {
+		return fmt.Errorf("list not found: %s", name)
+	}
+
+	if _, err := a.archiveListLocked(name, cards); err != nil {
+		return err
+	}
+	slog.Info("list archived", "name", name, "cards", len(cards))
+	return nil
+}
+
+// archiveListLocked does the archive-and-update-in-memory-state work shared by ArchiveList
+// and DeleteList's default (non-permanent) path. The caller must have already verified the
+// list exists in a.board.Lists.
+func (a *App) archiveListLocked(name string, cards []daedalus.KanbanCard) (daedalus.ArchivedList, error) {
+	var totalBytes int64
+	for _, card := range cards {
+		totalBytes += daedalus.GetFileSizeFs(a.board.Fs, card.FilePath)
+	}
+
+	entry, err := daedalus.ArchiveListFs(a.board.Fs, a.board.RootPath, name, a.board.Config, len(cards), totalBytes)
+	if err != nil {
+		slog.Error("failed to archive list", "name", name, "error", err)
+		return daedalus.ArchivedList{}, fmt.Errorf("archiving list: %w", err)
+	}
+
+	a.board.TotalFileBytes -= totalBytes
+	delete(a.board.Lists, name)
+	return entry, nil
+}
+
+// UnarchiveList restores a previously archived list (identified by its ArchivedList.Dir, see
+// ListArchived) back onto the board under its original name.
+func (a *App) UnarchiveList(archiveDir string) error {
+	if a.board == nil {
+		return fmt.Errorf("board not loaded")
+	}
+	a.pauseWatcher()
+
+	originalName := ""
+	for _, entry := range a.board.Config.Archived {
+		if entry.Dir == archiveDir {
+			originalName = entry.OriginalName
+			break
+		}
+	}
+	if originalName == "" {
+		return fmt.Errorf("archived list %q not found", archiveDir)
+	}
+
+	cards, maxID, bytes, err := daedalus.UnarchiveListFs(a.board.Fs, a.board.RootPath, archiveDir, a.board.Config)
+	if err != nil {
+		slog.Error("failed to unarchive list", "dir", archiveDir, "error", err)
+		return fmt.Errorf("unarchiving list: %w", err)
+	}
+
+	a.board.Lists[originalName] = cards
+	a.board.TotalFileBytes += bytes
+	if maxID > a.board.MaxID {
+		a.board.MaxID = maxID
+	}
+
+	slog.Info("list unarchived", "name", originalName, "cards", len(cards))
+	return nil
+}
+
+// ListArchived returns every archived list on the current board, newest first.
+func (a *App) ListArchived() ([]daedalus.ArchivedList, error) {
+	if a.board == nil {
+		return nil, fmt.Errorf("board not loaded")
+	}
+	archived := make([]daedalus.ArchivedList, len(a.board.Config.Archived))
+	copy(archived, a.board.Config.Archived)
+	for i, j := 0, len(archived)-1; i < j; i, j = i+1, j-1 {
+		archived[i], archived[j] = archived[j], archived[i]
+	}
+	return archived, nil
+}
+
+// PurgeArchived permanently deletes archived lists older than olderThanDays days, freeing
+// their disk space. Pass 0 to purge every archived list regardless of age.
+func (a *App) PurgeArchived(olderThanDays int) (int64, error) {
+	if a.board == nil {
+		return 0, fmt.Errorf("board not loaded")
+	}
+	a.pauseWatcher()
+
+	freed, err := daedalus.PurgeArchivedFs(a.board.Fs, a.board.RootPath, a.board.Config, time.Duration(olderThanDays)*24*time.Hour)
+	if err != nil {
+		slog.Error("failed to purge archived lists", "error", err)
+		return freed, fmt.Errorf("purging archived lists: %w", err)
+	}
+	slog.Info("archived lists purged", "olderThanDays", olderThanDays, "bytesFreed", freed)
+	return freed, nil
+}
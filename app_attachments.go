@@ -0,0 +1,49 @@
+package main
+
+import (
+	"daedalus/pkg/daedalus"
+	"fmt"
+	"log/slog"
+)
+
+// AttachCardFile attaches the file at srcPath (anywhere readable on the board's filesystem) to
+// the card at filePath, storing its content in the board's deduplicated blob store and appending
+// the resulting daedalus.Attachment to the card's metadata.
+func (a *App) AttachCardFile(filePath string, srcPath string) (daedalus.Attachment, error) {
+	if a.board == nil {
+		return daedalus.Attachment{}, fmt.Errorf("board not loaded")
+	}
+	a.pauseWatcher()
+
+	absPath, err := a.validatePath(filePath)
+	if err != nil {
+		return daedalus.Attachment{}, err
+	}
+
+	attachment, err := daedalus.AttachCardFileFs(a.board.Fs, absPath, srcPath)
+	if err != nil {
+		return daedalus.Attachment{}, err
+	}
+
+	if listKey, idx, found := a.findCardByPath(absPath); found {
+		a.board.Lists[listKey][idx].Metadata.Attachments = append(a.board.Lists[listKey][idx].Metadata.Attachments, attachment)
+	}
+	a.enqueueSync(daedalus.SyncOpWrite, absPath)
+	slog.Info("card file attached", "path", absPath, "name", attachment.Name, "sha256", attachment.Sha256)
+	return attachment, nil
+}
+
+// GCAttachments removes every blob in the board's attachment store no longer referenced by any
+// card, for the frontend to offer as a "reclaim space" action after deleting cards with large
+// attachments.
+func (a *App) GCAttachments() (int, error) {
+	if a.board == nil {
+		return 0, fmt.Errorf("board not loaded")
+	}
+	removed, err := daedalus.GCAttachmentsFs(a.board.Fs, a.board.RootPath)
+	if err != nil {
+		return 0, err
+	}
+	slog.Info("attachment blobs garbage collected", "removed", removed)
+	return removed, nil
+}
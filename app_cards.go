@@ -4,15 +4,18 @@ import (
 	"daedalus/pkg/daedalus"
 	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/spf13/afero"
 )
 
-// GetCardContent returns the full markdown body of a card file
+// GetCardContent returns the full markdown body of a card file. If the body no longer matches
+// its stored content hash (edited outside Daedalus since the last save), the in-memory card is
+// flagged Dirty -- it was already read in full here, so this is the cheapest place to notice.
 func (a *App) GetCardContent(filePath string) (string, error) {
 	if a.board == nil {
 		return "", fmt.Errorf("board not loaded")
@@ -22,7 +25,22 @@ func (a *App) GetCardContent(filePath string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return daedalus.ReadCardContent(absPath)
+	body, err := daedalus.ReadCardContentFs(a.ctx, a.board.Fs, absPath)
+	if err != nil {
+		return "", err
+	}
+
+	if listKey, idx, found := a.findCardByPath(absPath); found {
+		meta := a.board.Lists[listKey][idx].Metadata
+		dirty := meta.ContentSHA256 != "" && daedalus.ContentHash(body) != meta.ContentSHA256
+		if dirty != a.board.Lists[listKey][idx].Dirty {
+			if dirty {
+				slog.Warn("card content hash mismatch -- edited outside Daedalus", "id", meta.ID, "list", listKey)
+			}
+			a.board.Lists[listKey][idx].Dirty = dirty
+		}
+	}
+	return body, nil
 }
 
 // SaveCard writes updated metadata and body to a card file, updates in-memory state, and returns the updated card
@@ -37,20 +55,53 @@ func (a *App) SaveCard(filePath string, metadata daedalus.CardMetadata, body str
 		return nil, err
 	}
 
+	// Check the card's version vector against the last one this device saw (see
+	// daedalus.DetectConflict) before touching anything -- a board synced via Dropbox/Syncthing
+	// can have picked up a concurrent edit from another device since this one last loaded it.
+	versionState, err := daedalus.LoadVersionState(a.board.Fs, a.board.RootPath)
+	if err != nil {
+		slog.Warn("failed to load card version state", "path", absPath, "error", err)
+		versionState = &daedalus.VersionState{Versions: make(map[string][]daedalus.VersionEntry)}
+	}
+	onDiskVersion, lastSeenVersion, conflict, err := daedalus.DetectConflict(a.board.Fs, a.board.RootPath, versionState, absPath)
+	if err != nil {
+		slog.Warn("failed to check card version for conflicts", "path", absPath, "error", err)
+	} else if conflict {
+		slog.Warn("save refused: concurrent edit detected", "path", absPath)
+		a.cachePendingConflict(absPath, metadata, body)
+		return nil, &daedalus.ConflictError{Path: absPath, Local: lastSeenVersion, Remote: onDiskVersion}
+	}
+
 	now := time.Now()
 	metadata.Updated = &now
 	if metadata.Created == nil {
 		metadata.Created = &now
 	}
+	metadata.ContentSHA256 = daedalus.ContentHash(body)
+	metadata.Version = daedalus.BumpVersion(onDiskVersion, a.deviceID())
 
-	oldSize := getFileSize(absPath)
+	oldSize := daedalus.GetFileSizeFs(a.board.Fs, absPath)
 
-	if err := daedalus.WriteCardFile(absPath, metadata, body); err != nil {
+	if err := daedalus.AppendWALFs(a.board.Fs, a.board.RootPath, daedalus.WALOpWrite, absPath, &metadata, body); err != nil {
+		slog.Warn("failed to append wal record for card save", "path", absPath, "error", err)
+	}
+	if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, absPath, metadata, body); err != nil {
 		slog.Error("failed to write card", "id", metadata.ID, "file", absPath, "error", err)
 		return nil, fmt.Errorf("writing card file: %w", err)
 	}
+	if err := daedalus.FinishWALFs(a.board.Fs, a.board.RootPath, absPath); err != nil {
+		slog.Warn("failed to finish wal record for card save", "path", absPath, "error", err)
+	}
+	if written, err := afero.ReadFile(a.board.Fs, absPath); err == nil {
+		if err := daedalus.WriteCardBackupFs(a.board.Fs, a.board.RootPath, metadata.ID, written); err != nil {
+			slog.Warn("failed to write card backup snapshot", "id", metadata.ID, "error", err)
+		}
+	}
+	daedalus.RecordSeenVersion(a.board.Fs, a.board.RootPath, versionState, absPath, metadata.Version)
+	delete(a.pendingConflicts, absPath)
 
-	a.board.TotalFileBytes += getFileSize(absPath) - oldSize
+	a.board.TotalFileBytes += daedalus.GetFileSizeFs(a.board.Fs, absPath) - oldSize
+	a.enqueueSync(daedalus.SyncOpWrite, absPath)
 
 	updatedCard := daedalus.KanbanCard{
 		FilePath:    absPath,
@@ -73,6 +124,8 @@ func (a *App) SaveCard(filePath string, metadata daedalus.CardMetadata, body str
 // updates in-memory state, and returns the new KanbanCard.
 // Position "bottom" appends, a numeric string inserts at that 0-based index,
 // and anything else (including "top") prepends.
+// CreateCard skips the version-conflict check MoveCard/SaveCard/DeleteCard do: the file doesn't
+// exist on disk yet, so there's no prior version vector it could possibly diverge from.
 func (a *App) CreateCard(listDirName string, title string, body string, position string) (*daedalus.KanbanCard, error) {
 	if a.board == nil {
 		return nil, fmt.Errorf("board not loaded")
@@ -107,14 +160,27 @@ func (a *App) CreateCard(listDirName string, title string, body string, position
 
 	// Construct full file body matching SaveCard pattern
 	fullBody := fmt.Sprintf("# %s\n\n%s", title, body)
+	meta.ContentSHA256 = daedalus.ContentHash(fullBody)
 
 	filePath := filepath.Join(a.board.RootPath, listDirName, fmt.Sprintf("%d.md", newID))
-	if err := daedalus.WriteCardFile(filePath, meta, fullBody); err != nil {
+	if err := daedalus.AppendWALFs(a.board.Fs, a.board.RootPath, daedalus.WALOpWrite, filePath, &meta, fullBody); err != nil {
+		slog.Warn("failed to append wal record for new card", "path", filePath, "error", err)
+	}
+	if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, filePath, meta, fullBody); err != nil {
 		slog.Error("failed to write new card", "id", newID, "list", listDirName, "error", err)
 		return nil, fmt.Errorf("writing new card: %w", err)
 	}
+	if err := daedalus.FinishWALFs(a.board.Fs, a.board.RootPath, filePath); err != nil {
+		slog.Warn("failed to finish wal record for new card", "path", filePath, "error", err)
+	}
+	if written, err := afero.ReadFile(a.board.Fs, filePath); err == nil {
+		if err := daedalus.WriteCardBackupFs(a.board.Fs, a.board.RootPath, newID, written); err != nil {
+			slog.Warn("failed to write card backup snapshot", "id", newID, "error", err)
+		}
+	}
 
-	a.board.TotalFileBytes += getFileSize(filePath)
+	a.board.TotalFileBytes += daedalus.GetFileSizeFs(a.board.Fs, filePath)
+	a.enqueueSync(daedalus.SyncOpWrite, filePath)
 
 	card := daedalus.KanbanCard{
 		FilePath:    filePath,
@@ -147,14 +213,37 @@ func (a *App) DeleteCard(filePath string) error {
 		return err
 	}
 
-	removedBytes := getFileSize(absPath)
+	// Refuse to delete a card that picked up a concurrent edit this device hasn't seen yet --
+	// deleting it would silently discard that edit with no way to recover it.
+	versionState, err := daedalus.LoadVersionState(a.board.Fs, a.board.RootPath)
+	if err != nil {
+		slog.Warn("failed to load card version state", "path", absPath, "error", err)
+		versionState = &daedalus.VersionState{Versions: make(map[string][]daedalus.VersionEntry)}
+	}
+	onDiskVersion, lastSeenVersion, conflict, err := daedalus.DetectConflict(a.board.Fs, a.board.RootPath, versionState, absPath)
+	if err != nil {
+		slog.Warn("failed to check card version for conflicts", "path", absPath, "error", err)
+	} else if conflict {
+		slog.Warn("delete refused: concurrent edit detected", "path", absPath)
+		return &daedalus.ConflictError{Path: absPath, Local: lastSeenVersion, Remote: onDiskVersion}
+	}
+
+	removedBytes := daedalus.GetFileSizeFs(a.board.Fs, absPath)
 
-	if err := os.Remove(absPath); err != nil {
+	if err := daedalus.AppendWALFs(a.board.Fs, a.board.RootPath, daedalus.WALOpDelete, absPath, nil, ""); err != nil {
+		slog.Warn("failed to append wal record for card delete", "path", absPath, "error", err)
+	}
+	if err := a.board.Fs.Remove(absPath); err != nil {
 		slog.Error("failed to remove card file", "path", absPath, "error", err)
 		return fmt.Errorf("removing card file: %w", err)
 	}
+	if err := daedalus.FinishWALFs(a.board.Fs, a.board.RootPath, absPath); err != nil {
+		slog.Warn("failed to finish wal record for card delete", "path", absPath, "error", err)
+	}
+	delete(a.pendingConflicts, absPath)
 
 	a.board.TotalFileBytes -= removedBytes
+	a.enqueueSync(daedalus.SyncOpDelete, absPath)
 
 	// Remove card from in-memory lists
 	for listName, cards := range a.board.Lists {
@@ -177,6 +266,8 @@ func (a *App) MoveCard(filePath string, targetListDirName string, newListOrder f
 	if a.board == nil {
 		return nil, fmt.Errorf("board not loaded")
 	}
+	start := time.Now()
+	defer func() { moveLatencyHistogram.Observe(time.Since(start).Seconds()) }()
 	a.pauseWatcher()
 
 	absPath, err := a.validatePath(filePath)
@@ -210,38 +301,62 @@ func (a *App) MoveCard(filePath string, targetListDirName string, newListOrder f
 	card := a.board.Lists[sourceListKey][sourceIdx]
 
 	// Read card body from disk
-	body, err := daedalus.ReadCardContent(absPath)
+	body, err := daedalus.ReadCardContentFs(a.ctx, a.board.Fs, absPath)
 	if err != nil {
 		slog.Error("failed to read card content for move", "path", absPath, "error", err)
 		return nil, fmt.Errorf("reading card content: %w", err)
 	}
 
+	// Check the card's version vector against the last one this device saw (see
+	// daedalus.DetectConflict) before touching anything -- a board synced via Dropbox/Syncthing
+	// can have picked up a concurrent edit from another device since this one last loaded it.
+	versionState, err := daedalus.LoadVersionState(a.board.Fs, a.board.RootPath)
+	if err != nil {
+		slog.Warn("failed to load card version state", "path", absPath, "error", err)
+		versionState = &daedalus.VersionState{Versions: make(map[string][]daedalus.VersionEntry)}
+	}
+	onDiskVersion, lastSeenVersion, conflict, err := daedalus.DetectConflict(a.board.Fs, a.board.RootPath, versionState, absPath)
+	if err != nil {
+		slog.Warn("failed to check card version for conflicts", "path", absPath, "error", err)
+	} else if conflict {
+		slog.Warn("move refused: concurrent edit detected", "path", absPath)
+		a.cachePendingConflict(absPath, card.Metadata, body)
+		return nil, &daedalus.ConflictError{Path: absPath, Local: lastSeenVersion, Remote: onDiskVersion}
+	}
+
 	// Update metadata
 	now := time.Now()
 	card.Metadata.Updated = &now
 	card.Metadata.ListOrder = newListOrder
+	card.Metadata.Version = daedalus.BumpVersion(onDiskVersion, a.deviceID())
 
 	// Determine new file path
 	filename := filepath.Base(absPath)
 	newPath := filepath.Join(a.board.RootPath, targetListDirName, filename)
 
 	crossList := sourceListKey != targetListDirName
-
 	if crossList {
-		// Move file to new directory
-		if err := os.Rename(absPath, newPath); err != nil {
-			slog.Error("failed to move card file", "from", absPath, "to", newPath, "error", err)
-			return nil, fmt.Errorf("moving card file: %w", err)
-		}
 		card.FilePath = newPath
 		card.ListName = targetListDirName
 	}
 
-	// Write updated frontmatter
-	if err := daedalus.WriteCardFile(card.FilePath, card.Metadata, body); err != nil {
-		slog.Error("failed to write card after move", "path", card.FilePath, "error", err)
-		return nil, fmt.Errorf("writing card file: %w", err)
+	// Stage the directory move (if any) and the updated frontmatter as one Tx, so a crash
+	// between the rename and the frontmatter write leaves a journal ReplayTxFs can finish on
+	// the next LoadBoard, rather than a card whose path and contents disagree.
+	tx := daedalus.NewTx(a.board.Fs, a.board.RootPath)
+	if crossList {
+		if err := tx.Rename(a.ctx, absPath, newPath); err != nil {
+			slog.Error("failed to stage card move", "from", absPath, "to", newPath, "error", err)
+			return nil, fmt.Errorf("moving card file: %w", err)
+		}
+	}
+	tx.WriteFile(card.FilePath, card.Metadata, body)
+	if err := tx.Commit(a.ctx); err != nil {
+		slog.Error("failed to commit card move", "path", card.FilePath, "error", err)
+		return nil, fmt.Errorf("committing card move: %w", err)
 	}
+	daedalus.RecordSeenVersion(a.board.Fs, a.board.RootPath, versionState, card.FilePath, card.Metadata.Version)
+	delete(a.pendingConflicts, absPath)
 
 	// Update in-memory state: remove from source
 	srcCards := a.board.Lists[sourceListKey]
@@ -299,7 +414,7 @@ func (a *App) MoveAllCards(sourceDir, targetDir string) error {
 	now := time.Now()
 
 	for i, card := range srcCards {
-		body, err := daedalus.ReadCardContent(card.FilePath)
+		body, err := daedalus.ReadCardContentFs(a.ctx, a.board.Fs, card.FilePath)
 		if err != nil {
 			slog.Error("failed to read card content for move-all", "path", card.FilePath, "error", err)
 			return fmt.Errorf("reading card %d: %w", card.Metadata.ID, err)
@@ -311,7 +426,7 @@ func (a *App) MoveAllCards(sourceDir, targetDir string) error {
 		filename := filepath.Base(card.FilePath)
 		newPath := filepath.Join(a.board.RootPath, targetDir, filename)
 
-		if err := os.Rename(card.FilePath, newPath); err != nil {
+		if err := a.board.Fs.Rename(card.FilePath, newPath); err != nil {
 			slog.Error("failed to move card file", "from", card.FilePath, "to", newPath, "error", err)
 			return fmt.Errorf("moving card %d: %w", card.Metadata.ID, err)
 		}
@@ -319,7 +434,7 @@ func (a *App) MoveAllCards(sourceDir, targetDir string) error {
 		card.FilePath = newPath
 		card.ListName = targetDir
 
-		if err := daedalus.WriteCardFile(card.FilePath, card.Metadata, body); err != nil {
+		if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, card.FilePath, card.Metadata, body); err != nil {
 			slog.Error("failed to write card after move-all", "path", card.FilePath, "error", err)
 			return fmt.Errorf("writing card %d: %w", card.Metadata.ID, err)
 		}
@@ -355,8 +470,8 @@ func (a *App) DeleteAllCards(listDir string) error {
 
 	var totalBytes int64
 	for _, card := range cards {
-		totalBytes += getFileSize(card.FilePath)
-		if err := os.Remove(card.FilePath); err != nil {
+		totalBytes += daedalus.GetFileSizeFs(a.board.Fs, card.FilePath)
+		if err := a.board.Fs.Remove(card.FilePath); err != nil {
 			slog.Error("failed to remove card file", "path", card.FilePath, "error", err)
 			return fmt.Errorf("removing card %d: %w", card.Metadata.ID, err)
 		}
@@ -369,6 +484,111 @@ func (a *App) DeleteAllCards(listDir string) error {
 	return nil
 }
 
+// ReviewCard scores a spaced-repetition review of the card at filePath with grade (0-5 on the
+// SM-2 quality scale: below 3 is a lapse, 3 and up advances the streak), persists the updated
+// schedule via daedalus.RecordReview, and updates in-memory board state.
+func (a *App) ReviewCard(filePath string, grade int) (*daedalus.KanbanCard, error) {
+	if a.board == nil {
+		return nil, fmt.Errorf("board not loaded")
+	}
+	a.pauseWatcher()
+
+	absPath, err := a.validatePath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	listKey, idx, found := a.findCardByPath(absPath)
+	if !found {
+		return nil, fmt.Errorf("card not found: %s", filePath)
+	}
+
+	oldSize := daedalus.GetFileSizeFs(a.board.Fs, absPath)
+	card := a.board.Lists[listKey][idx]
+	if err := daedalus.RecordReviewFs(a.board.Fs, &card, grade, time.Now()); err != nil {
+		return nil, fmt.Errorf("recording review: %w", err)
+	}
+
+	a.board.TotalFileBytes += daedalus.GetFileSizeFs(a.board.Fs, absPath) - oldSize
+	a.enqueueSync(daedalus.SyncOpWrite, absPath)
+	a.board.Lists[listKey][idx] = card
+
+	slog.Info("card reviewed", "id", card.Metadata.ID, "list", listKey, "grade", grade, "streak", card.Metadata.Review.Streak)
+	return &card, nil
+}
+
+// ListDueCards returns every card across the board whose spaced-repetition review is due at or
+// before now, for a frontend study-mode view.
+func (a *App) ListDueCards(now time.Time) ([]daedalus.KanbanCard, error) {
+	if a.board == nil {
+		return nil, fmt.Errorf("board not loaded")
+	}
+	return daedalus.DueCards(a.board, now), nil
+}
+
+// ListDueCardsInList is ListDueCards scoped to a single list, for a frontend study-mode view
+// that reviews one deck (list) at a time instead of the whole board.
+func (a *App) ListDueCardsInList(listDir string, before time.Time) ([]daedalus.KanbanCard, error) {
+	if a.board == nil {
+		return nil, fmt.Errorf("board not loaded")
+	}
+	if _, ok := a.board.Lists[listDir]; !ok {
+		return nil, fmt.Errorf("list %q not found", listDir)
+	}
+	return daedalus.DueCardsInList(a.board, listDir, before), nil
+}
+
+// ReconcileCard resolves a card flagged Dirty by a content-hash mismatch (its body was edited
+// outside Daedalus). If keepDisk is true, the current on-disk content is accepted as canonical:
+// its hash is recomputed and persisted, clearing Dirty without touching the body. Otherwise the
+// last backup snapshot written by SaveCard/CreateCard is restored, discarding the out-of-band
+// edit.
+func (a *App) ReconcileCard(filePath string, keepDisk bool) (*daedalus.KanbanCard, error) {
+	if a.board == nil {
+		return nil, fmt.Errorf("board not loaded")
+	}
+	a.pauseWatcher()
+
+	absPath, err := a.validatePath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	listKey, idx, found := a.findCardByPath(absPath)
+	if !found {
+		return nil, fmt.Errorf("card not found: %s", filePath)
+	}
+	card := a.board.Lists[listKey][idx]
+
+	if keepDisk {
+		body, err := daedalus.ReadCardContentFs(a.ctx, a.board.Fs, absPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading card content: %w", err)
+		}
+		now := time.Now()
+		card.Metadata.ContentSHA256 = daedalus.ContentHash(body)
+		card.Metadata.Updated = &now
+		if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, absPath, card.Metadata, body); err != nil {
+			return nil, fmt.Errorf("writing card file: %w", err)
+		}
+		card.PreviewText = truncatePreview(body)
+	} else {
+		data, err := daedalus.RestoreCardBackupFs(a.board.Fs, a.board.RootPath, absPath, card.Metadata.ID)
+		if err != nil {
+			return nil, fmt.Errorf("restoring card backup: %w", err)
+		}
+		meta, body, err := daedalus.ParseCardBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing restored card: %w", err)
+		}
+		card.Metadata = meta
+		card.PreviewText = truncatePreview(body)
+	}
+
+	card.Dirty = false
+	a.board.Lists[listKey][idx] = card
+	slog.Info("card reconciled", "id", card.Metadata.ID, "list", listKey, "keepDisk", keepDisk)
+	return &card, nil
+}
+
 // findCardByPath searches all board lists for a card with the given file path.
 // Returns the list key, index within that list, and whether the card was found.
 func (a *App) findCardByPath(absPath string) (string, int, bool) {
@@ -7,33 +7,6 @@ import (
 	"time"
 )
 
-// SaveListConfig updates the config for a single list and persists to board.yaml.
-func (a *App) SaveListConfig(dirName string, title string, limit int) error {
-	if a.board == nil {
-		return fmt.Errorf("board not loaded")
-	}
-	a.pauseWatcher()
-
-	idx := daedalus.FindListEntry(a.board.Config.Lists, dirName)
-	if idx >= 0 {
-		a.board.Config.Lists[idx].Title = title
-		a.board.Config.Lists[idx].Limit = limit
-	} else {
-		a.board.Config.Lists = append(a.board.Config.Lists, daedalus.ListEntry{
-			Dir:   dirName,
-			Title: title,
-			Limit: limit,
-		})
-	}
-
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
-		slog.Error("failed to save list config", "dir", dirName, "error", err)
-		return err
-	}
-	slog.Info("list config saved", "dir", dirName, "title", title, "limit", limit)
-	return nil
-}
-
 // SaveLabelsExpanded persists the label collapsed/expanded state to board.yaml.
 func (a *App) SaveLabelsExpanded(expanded bool) error {
 	if a.board == nil {
@@ -42,10 +15,11 @@ func (a *App) SaveLabelsExpanded(expanded bool) error {
 	a.pauseWatcher()
 	a.board.Config.LabelsExpanded = &expanded
 
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save labels expanded", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Debug("labels expanded state saved", "expanded", expanded)
 	return nil
 }
@@ -58,10 +32,11 @@ func (a *App) SaveShowYearProgress(show bool) error {
 	a.pauseWatcher()
 	a.board.Config.ShowYearProgress = &show
 
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save year progress", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Debug("year progress state saved", "show", show)
 	return nil
 }
@@ -74,20 +49,27 @@ func (a *App) SaveLabelColors(colors map[string]string) error {
 	a.pauseWatcher()
 	a.board.Config.LabelColors = colors
 
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save label colors", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Debug("label colors saved", "count", len(colors))
 	return nil
 }
 
 // updateCardsWithLabel finds every card containing the given label, applies transformFn to modify
 // the card's labels, writes the updated card to disk, and returns the count of affected cards.
-func (a *App) updateCardsWithLabel(label string, transformFn func(labels []string, idx int) []string) (int, error) {
+// Every list and card it's about to change is snapshotted into txn first, so the caller can call
+// txn.Abort to undo a partial run if it returns an error partway through.
+func (a *App) updateCardsWithLabel(txn *daedalus.Txn, label string, transformFn func(labels []string, idx int) []string) (int, error) {
 	affected := 0
 	for listKey, cards := range a.board.Lists {
+		listSnapshotted := false
 		for i, card := range cards {
+			if err := a.ctx.Err(); err != nil {
+				return affected, fmt.Errorf("label update cancelled: %w", err)
+			}
 			idx := -1
 			for j, l := range card.Metadata.Labels {
 				if l == label {
@@ -98,16 +80,23 @@ func (a *App) updateCardsWithLabel(label string, transformFn func(labels []strin
 			if idx == -1 {
 				continue
 			}
+			if !listSnapshotted {
+				txn.SnapshotList(listKey)
+				listSnapshotted = true
+			}
+			if err := txn.SnapshotCard(card.FilePath); err != nil {
+				return affected, err
+			}
 
 			card.Metadata.Labels = transformFn(card.Metadata.Labels, idx)
 			now := time.Now()
 			card.Metadata.Updated = &now
 
-			body, err := daedalus.ReadCardContent(card.FilePath)
+			body, err := daedalus.ReadCardContentFs(a.ctx, a.board.Fs, card.FilePath)
 			if err != nil {
 				return affected, fmt.Errorf("reading card %s: %w", card.FilePath, err)
 			}
-			if err := daedalus.WriteCardFile(card.FilePath, card.Metadata, body); err != nil {
+			if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, card.FilePath, card.Metadata, body); err != nil {
 				return affected, fmt.Errorf("writing card %s: %w", card.FilePath, err)
 			}
 
@@ -119,7 +108,9 @@ func (a *App) updateCardsWithLabel(label string, transformFn func(labels []strin
 }
 
 // RemoveLabel strips a label from every card that has it, writing each affected card to disk,
-// and removes any custom color for that label from board.yaml.
+// and removes any custom color for that label from board.yaml. If any card write or the config
+// save fails partway through, every change made so far is rolled back so the board is left
+// exactly as it was before the call.
 func (a *App) RemoveLabel(label string) error {
 	if a.board == nil {
 		return fmt.Errorf("board not loaded")
@@ -127,21 +118,30 @@ func (a *App) RemoveLabel(label string) error {
 	a.pauseWatcher()
 	slog.Info("removing label from all cards", "label", label)
 
-	affected, err := a.updateCardsWithLabel(label, func(labels []string, idx int) []string {
+	txn := daedalus.NewTxn(a.board)
+	affected, err := a.updateCardsWithLabel(txn, label, func(labels []string, idx int) []string {
 		return append(labels[:idx], labels[idx+1:]...)
 	})
 	if err != nil {
-		slog.Error("failed during label removal", "label", label, "error", err)
+		slog.Error("failed during label removal, rolling back", "label", label, "error", err)
+		if rbErr := txn.Abort(a.ctx); rbErr != nil {
+			slog.Error("label removal rollback failed", "label", label, "error", rbErr)
+		}
 		return err
 	}
 
 	// Remove custom color if set
 	if a.board.Config.LabelColors != nil {
+		txn.SnapshotLabelColors()
 		delete(a.board.Config.LabelColors, label)
-		if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
-			slog.Error("failed to save config after label removal", "label", label, "error", err)
+		if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
+			slog.Error("failed to save config after label removal, rolling back", "label", label, "error", err)
+			if rbErr := txn.Abort(a.ctx); rbErr != nil {
+				slog.Error("label removal rollback failed", "label", label, "error", rbErr)
+			}
 			return fmt.Errorf("saving board config: %w", err)
 		}
+		a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	}
 
 	slog.Info("label removed", "label", label, "cardsAffected", affected)
@@ -149,7 +149,9 @@ func (a *App) RemoveLabel(label string) error {
 }
 
 // RenameLabel replaces oldName with newName in every card's labels, writing each affected card
-// to disk, and migrates any custom color from the old name to the new name in board.yaml.
+// to disk, and migrates any custom color from the old name to the new name in board.yaml. If any
+// card write or the config save fails partway through, every change made so far is rolled back so
+// the board is left exactly as it was before the call.
 func (a *App) RenameLabel(oldName string, newName string) error {
 	if a.board == nil {
 		return fmt.Errorf("board not loaded")
@@ -161,25 +163,34 @@ func (a *App) RenameLabel(oldName string, newName string) error {
 	}
 	slog.Info("renaming label", "old", oldName, "new", newName)
 
-	affected, err := a.updateCardsWithLabel(oldName, func(labels []string, idx int) []string {
+	txn := daedalus.NewTxn(a.board)
+	affected, err := a.updateCardsWithLabel(txn, oldName, func(labels []string, idx int) []string {
 		labels[idx] = newName
 		return labels
 	})
 	if err != nil {
-		slog.Error("failed during label rename", "old", oldName, "new", newName, "error", err)
+		slog.Error("failed during label rename, rolling back", "old", oldName, "new", newName, "error", err)
+		if rbErr := txn.Abort(a.ctx); rbErr != nil {
+			slog.Error("label rename rollback failed", "old", oldName, "new", newName, "error", rbErr)
+		}
 		return err
 	}
 
 	// Migrate custom color if set
 	if a.board.Config.LabelColors != nil {
 		if color, ok := a.board.Config.LabelColors[oldName]; ok {
+			txn.SnapshotLabelColors()
 			delete(a.board.Config.LabelColors, oldName)
 			a.board.Config.LabelColors[newName] = color
 
-			if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
-				slog.Error("failed to save config after label rename", "error", err)
+			if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
+				slog.Error("failed to save config after label rename, rolling back", "error", err)
+				if rbErr := txn.Abort(a.ctx); rbErr != nil {
+					slog.Error("label rename rollback failed", "old", oldName, "new", newName, "error", rbErr)
+				}
 				return fmt.Errorf("saving board config: %w", err)
 			}
+			a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 		}
 	}
 
@@ -194,10 +205,11 @@ func (a *App) SaveDarkMode(dark bool) error {
 	}
 	a.pauseWatcher()
 	a.board.Config.DarkMode = &dark
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save dark mode", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Debug("dark mode saved", "dark", dark)
 	return nil
 }
@@ -210,10 +222,11 @@ func (a *App) SaveMinimalView(minimal bool) error {
 	a.pauseWatcher()
 
 	a.board.Config.MinimalView = &minimal
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save minimal view", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Debug("minimal view saved", "minimal", minimal)
 	return nil
 }
@@ -225,10 +238,11 @@ func (a *App) SaveZoom(level float64) error {
 	}
 	a.pauseWatcher()
 	a.board.Config.Zoom = &level
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save zoom level", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Debug("zoom level saved", "level", level)
 	return nil
 }
@@ -241,49 +255,11 @@ func (a *App) SaveBoardTitle(title string) error {
 	a.pauseWatcher()
 	a.board.Config.Title = title
 
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save board title", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Info("board title saved", "title", title)
 	return nil
 }
-
-// SaveListOrder reorders the config Lists array to match the given order and persists to board.yaml.
-func (a *App) SaveListOrder(order []string) error {
-	if a.board == nil {
-		return fmt.Errorf("board not loaded")
-	}
-	a.pauseWatcher()
-
-	// Build a map of dir -> entry for quick lookup
-	entryMap := make(map[string]daedalus.ListEntry)
-	for _, entry := range a.board.Config.Lists {
-		entryMap[entry.Dir] = entry
-	}
-
-	// Reassemble in new order
-	var reordered []daedalus.ListEntry
-	used := make(map[string]bool)
-	for _, dir := range order {
-		if entry, ok := entryMap[dir]; ok {
-			reordered = append(reordered, entry)
-			used[dir] = true
-		}
-	}
-
-	// Append any stragglers not in the order array
-	for _, entry := range a.board.Config.Lists {
-		if !used[entry.Dir] {
-			reordered = append(reordered, entry)
-		}
-	}
-
-	a.board.Config.Lists = reordered
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
-		slog.Error("failed to save list order", "error", err)
-		return err
-	}
-	slog.Info("list order saved", "count", len(reordered))
-	return nil
-}
@@ -1,11 +1,35 @@
 package main
 
 import (
+	"context"
 	"daedalus/pkg/daedalus"
+	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/spf13/afero"
 )
 
+// failOnNthCreate wraps an afero.Fs and fails the Nth call that opens a file with O_CREATE set,
+// simulating a card write failing partway through a batch so a transaction's rollback can be
+// exercised deterministically.
+type failOnNthCreate struct {
+	afero.Fs
+	n     int
+	count int
+}
+
+func (f *failOnNthCreate) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&os.O_CREATE != 0 {
+		f.count++
+		if f.count == f.n {
+			return nil, fmt.Errorf("simulated write failure on create #%d", f.count)
+		}
+	}
+	return f.Fs.OpenFile(name, flag, perm)
+}
+
 // SaveListConfig should update the in-memory config and persist to board.yaml.
 func TestSaveListConfig_Success(t *testing.T) {
 	app, root := setupTestBoard(t)
@@ -15,7 +39,7 @@ func TestSaveListConfig_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	idx := daedalus.FindListEntry(app.board.Config.Lists, "test")
+	idx := daedalus.FindListEntry(context.Background(), app.board.Config.Lists, "test")
 	if idx < 0 {
 		t.Fatal("expected config entry for test")
 	}
@@ -29,7 +53,7 @@ func TestSaveListConfig_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error loading saved config: %v", err)
 	}
-	savedIdx := daedalus.FindListEntry(config.Lists, "test")
+	savedIdx := daedalus.FindListEntry(context.Background(), config.Lists, "test")
 	if savedIdx < 0 {
 		t.Fatal("expected saved config entry for test")
 	}
@@ -93,7 +117,7 @@ func TestSaveHalfCollapsedLists_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error loading config: %v", err)
 	}
-	idx := daedalus.FindListEntry(config.Lists, "test")
+	idx := daedalus.FindListEntry(context.Background(), config.Lists, "test")
 	if idx < 0 {
 		t.Fatal("expected config entry for test")
 	}
@@ -110,7 +134,7 @@ func TestSaveHalfCollapsedLists_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error loading config: %v", err)
 	}
-	idx = daedalus.FindListEntry(config.Lists, "test")
+	idx = daedalus.FindListEntry(context.Background(), config.Lists, "test")
 	if idx < 0 {
 		t.Fatal("expected config entry for test after clear")
 	}
@@ -173,7 +197,7 @@ func TestSaveLockedLists_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error loading config: %v", err)
 	}
-	idx := daedalus.FindListEntry(config.Lists, "open")
+	idx := daedalus.FindListEntry(context.Background(), config.Lists, "open")
 	if idx < 0 {
 		t.Fatal("expected config entry for open")
 	}
@@ -181,7 +205,7 @@ func TestSaveLockedLists_Success(t *testing.T) {
 		t.Error("expected open to be locked")
 	}
 
-	doneIdx := daedalus.FindListEntry(config.Lists, "done")
+	doneIdx := daedalus.FindListEntry(context.Background(), config.Lists, "done")
 	if doneIdx >= 0 && config.Lists[doneIdx].Locked {
 		t.Error("expected done to NOT be locked")
 	}
@@ -195,7 +219,7 @@ func TestSaveLockedLists_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error loading config: %v", err)
 	}
-	idx = daedalus.FindListEntry(config.Lists, "open")
+	idx = daedalus.FindListEntry(context.Background(), config.Lists, "open")
 	if idx < 0 {
 		t.Fatal("expected config entry for open after clear")
 	}
@@ -303,3 +327,97 @@ func TestSavePinnedLists_BoardNotLoaded(t *testing.T) {
 		t.Errorf("unexpected error message: %v", err)
 	}
 }
+
+// setupLabeledBoard creates a single list of 3 cards all tagged "bug", and returns the loaded
+// app alongside a snapshot of every card's original bytes and board.yaml's original bytes, for
+// asserting a rolled-back transaction restored disk exactly.
+func setupLabeledBoard(t *testing.T) (app *App, root string, origCards map[string][]byte, origConfig []byte) {
+	t.Helper()
+	root = t.TempDir()
+	listDir := filepath.Join(root, "todo")
+	mustMkdir(t, listDir)
+	mustWrite(t, filepath.Join(listDir, "1.md"), []byte("---\ntitle: \"Card One\"\nid: 1\nlist_order: 1\nlabels:\n  - bug\n---\nBody one.\n"))
+	mustWrite(t, filepath.Join(listDir, "2.md"), []byte("---\ntitle: \"Card Two\"\nid: 2\nlist_order: 2\nlabels:\n  - bug\n---\nBody two.\n"))
+	mustWrite(t, filepath.Join(listDir, "3.md"), []byte("---\ntitle: \"Card Three\"\nid: 3\nlist_order: 3\nlabels:\n  - bug\n---\nBody three.\n"))
+
+	app = NewApp()
+	if resp := app.LoadBoard(root); resp == nil {
+		t.Fatal("LoadBoard returned nil")
+	}
+
+	origCards = make(map[string][]byte)
+	for _, card := range app.board.Lists["todo"] {
+		data, err := os.ReadFile(card.FilePath)
+		if err != nil {
+			t.Fatalf("reading original card %s: %v", card.FilePath, err)
+		}
+		origCards[card.FilePath] = data
+	}
+	origConfig, err := os.ReadFile(filepath.Join(root, "board.yaml"))
+	if err != nil {
+		t.Fatalf("reading original board.yaml: %v", err)
+	}
+	return app, root, origCards, origConfig
+}
+
+// assertBoardRestored checks that every card file and board.yaml on disk match the snapshots
+// taken before the failed operation ran.
+func assertBoardRestored(t *testing.T, root string, origCards map[string][]byte, origConfig []byte) {
+	t.Helper()
+	for path, want := range origCards {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading restored card %s: %v", path, err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("card %s not restored:\ngot:  %s\nwant: %s", path, got, want)
+		}
+	}
+	gotConfig, err := os.ReadFile(filepath.Join(root, "board.yaml"))
+	if err != nil {
+		t.Fatalf("reading restored board.yaml: %v", err)
+	}
+	if string(gotConfig) != string(origConfig) {
+		t.Errorf("board.yaml not restored:\ngot:  %s\nwant: %s", gotConfig, origConfig)
+	}
+}
+
+// RemoveLabel should roll back every card write and leave board.yaml untouched if a write fails
+// partway through the batch.
+func TestRemoveLabel_RollbackOnPartialFailure(t *testing.T) {
+	app, root, origCards, origConfig := setupLabeledBoard(t)
+
+	app.board.Fs = &failOnNthCreate{Fs: app.board.Fs, n: 2}
+
+	if err := app.RemoveLabel("bug"); err == nil {
+		t.Fatal("expected error from simulated write failure")
+	}
+
+	assertBoardRestored(t, root, origCards, origConfig)
+
+	for _, card := range app.board.Lists["todo"] {
+		if len(card.Metadata.Labels) != 1 || card.Metadata.Labels[0] != "bug" {
+			t.Errorf("card %d: in-memory labels not restored, got %v", card.Metadata.ID, card.Metadata.Labels)
+		}
+	}
+}
+
+// RenameLabel should roll back every card write and leave board.yaml untouched if a write fails
+// partway through the batch.
+func TestRenameLabel_RollbackOnPartialFailure(t *testing.T) {
+	app, root, origCards, origConfig := setupLabeledBoard(t)
+
+	app.board.Fs = &failOnNthCreate{Fs: app.board.Fs, n: 2}
+
+	if err := app.RenameLabel("bug", "defect"); err == nil {
+		t.Fatal("expected error from simulated write failure")
+	}
+
+	assertBoardRestored(t, root, origCards, origConfig)
+
+	for _, card := range app.board.Lists["todo"] {
+		if len(card.Metadata.Labels) != 1 || card.Metadata.Labels[0] != "bug" {
+			t.Errorf("card %d: in-memory labels not restored, got %v", card.Metadata.ID, card.Metadata.Labels)
+		}
+	}
+}
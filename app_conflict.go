@@ -0,0 +1,137 @@
+package main
+
+import (
+	"daedalus/pkg/daedalus"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Conflict resolution strategies accepted by ResolveConflict, mirroring the choices Syncthing
+// offers a user over a conflicting file: keep this device's edit, keep the one that's on disk,
+// or keep both (writing the discarded side out as a conflict-copy sibling).
+const (
+	KeepLocal  = "keep-local"
+	KeepRemote = "keep-remote"
+	KeepBoth   = "keep-both"
+)
+
+// ResolveConflict resolves a card that MoveCard refused with a *daedalus.ConflictError, per
+// strategy:
+//
+//   - KeepLocal: the in-memory card (this device's view, including any unsaved edit it was
+//     trying to make) overwrites the file, its version vector merged with the on-disk one so it
+//     no longer looks concurrent going forward.
+//   - KeepRemote: the on-disk content is accepted as canonical; the in-memory card is refreshed
+//     to match it.
+//   - KeepBoth: the on-disk content stays at path untouched, and the in-memory (local) version is
+//     preserved alongside it as a "<id>.conflict-<deviceID>.md" sibling file, analogous to
+//     Syncthing's *.sync-conflict-* files.
+//
+// In all three cases the board's persisted version state is updated so the next MoveCard sees
+// the resolution as the new last-known-good vector instead of flagging it concurrent again.
+func (a *App) ResolveConflict(filePath string, strategy string) (*daedalus.KanbanCard, error) {
+	if a.board == nil {
+		return nil, fmt.Errorf("board not loaded")
+	}
+	a.pauseWatcher()
+
+	absPath, err := a.validatePath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	listKey, idx, found := a.findCardByPath(absPath)
+	if !found {
+		return nil, fmt.Errorf("card not found: %s", filePath)
+	}
+	localCard := a.board.Lists[listKey][idx]
+
+	remoteMeta, remoteBody, err := daedalus.ReadCardFileFs(a.board.Fs, absPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading on-disk card: %w", err)
+	}
+
+	versionState, err := daedalus.LoadVersionState(a.board.Fs, a.board.RootPath)
+	if err != nil {
+		slog.Warn("failed to load card version state", "path", absPath, "error", err)
+		versionState = &daedalus.VersionState{Versions: make(map[string][]daedalus.VersionEntry)}
+	}
+	merged := daedalus.MergeVersions(localCard.Metadata.Version, remoteMeta.Version)
+
+	// localMetadata/localBody is the actual local edit that was refused -- cached by
+	// MoveCard/SaveCard/DeleteCard at the point they raised the ConflictError (see
+	// App.cachePendingConflict). If it's missing (e.g. the app restarted between the conflict
+	// and this call), there's no real local copy to restore; fall back to the in-memory card's
+	// last-known metadata and warn that KeepLocal/KeepBoth can't do better than that here.
+	localMetadata := localCard.Metadata
+	var localBody string
+	if pending, ok := a.pendingConflicts[absPath]; ok {
+		localMetadata = pending.metadata
+		localBody = pending.body
+	} else if strategy == KeepLocal || strategy == KeepBoth {
+		slog.Warn("no cached local edit for conflict resolution, falling back to on-disk content", "path", absPath, "strategy", strategy)
+		localBody, err = daedalus.ReadCardContentFs(a.ctx, a.board.Fs, absPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading card content: %w", err)
+		}
+	}
+
+	var resolved daedalus.KanbanCard
+	switch strategy {
+	case KeepLocal:
+		now := time.Now()
+		localMetadata.Updated = &now
+		localMetadata.ContentSHA256 = daedalus.ContentHash(localBody)
+		localMetadata.Version = daedalus.BumpVersion(merged, a.deviceID())
+		if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, absPath, localMetadata, localBody); err != nil {
+			return nil, fmt.Errorf("writing resolved card: %w", err)
+		}
+		resolved = localCard
+		resolved.Metadata = localMetadata
+		resolved.PreviewText = truncatePreview(localBody)
+
+	case KeepRemote:
+		remoteMeta.Version = merged
+		if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, absPath, remoteMeta, remoteBody); err != nil {
+			return nil, fmt.Errorf("writing resolved card: %w", err)
+		}
+		resolved = localCard
+		resolved.Metadata = remoteMeta
+		resolved.PreviewText = truncatePreview(remoteBody)
+
+	case KeepBoth:
+		conflictPath := conflictSiblingPath(absPath, a.deviceID())
+		if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, conflictPath, localMetadata, localBody); err != nil {
+			return nil, fmt.Errorf("writing conflict copy: %w", err)
+		}
+		remoteMeta.Version = merged
+		if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, absPath, remoteMeta, remoteBody); err != nil {
+			return nil, fmt.Errorf("writing resolved card: %w", err)
+		}
+		resolved = localCard
+		resolved.Metadata = remoteMeta
+		resolved.PreviewText = truncatePreview(remoteBody)
+		slog.Info("conflict copy written", "path", conflictPath)
+
+	default:
+		return nil, fmt.Errorf("unknown conflict resolution strategy: %s", strategy)
+	}
+
+	daedalus.RecordSeenVersion(a.board.Fs, a.board.RootPath, versionState, absPath, resolved.Metadata.Version)
+	delete(a.pendingConflicts, absPath)
+	a.board.Lists[listKey][idx] = resolved
+	slog.Info("conflict resolved", "path", absPath, "strategy", strategy)
+	return &resolved, nil
+}
+
+// conflictSiblingPath builds the "<id>.conflict-<deviceID><ext>" sibling path KeepBoth writes
+// the displaced local copy to, next to the canonical file at path.
+func conflictSiblingPath(path string, deviceID string) string {
+	dir := filepath.Dir(path)
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	name := strings.TrimSuffix(base, ext)
+	return filepath.Join(dir, fmt.Sprintf("%s.conflict-%s%s", name, deviceID, ext))
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/spf13/afero"
 )
 
 // Export structs -- used only for JSON marshaling.
@@ -59,7 +61,7 @@ func (a *App) ExportJSON(path string) error {
 			Title: entry.Title,
 		}
 		for _, card := range cards {
-			body, err := daedalus.ReadCardContent(card.FilePath)
+			body, err := daedalus.ReadCardContentFs(a.ctx, a.board.Fs, card.FilePath)
 			if err != nil {
 				slog.Warn("export: failed to read card body", "path", card.FilePath, "error", err)
 				body = ""
@@ -118,7 +120,7 @@ func (a *App) ExportZip(path string) error {
 	root := a.board.RootPath
 
 	// Add board.yaml.
-	if err := addFileToZip(zw, filepath.Join(root, "board.yaml"), "board.yaml"); err != nil {
+	if err := addFileToZip(a.board.Fs, zw, filepath.Join(root, "board.yaml"), "board.yaml"); err != nil {
 		return fmt.Errorf("adding board.yaml: %w", err)
 	}
 
@@ -127,7 +129,7 @@ func (a *App) ExportZip(path string) error {
 		cards := a.board.Lists[entry.Dir]
 		for _, card := range cards {
 			relPath := entry.Dir + "/" + filepath.Base(card.FilePath)
-			if err := addFileToZip(zw, card.FilePath, relPath); err != nil {
+			if err := addFileToZip(a.board.Fs, zw, card.FilePath, relPath); err != nil {
 				slog.Warn("export: failed to add card to zip", "path", card.FilePath, "error", err)
 			}
 		}
@@ -140,7 +142,7 @@ func (a *App) ExportZip(path string) error {
 		for _, name := range iconNames {
 			srcPath := filepath.Join(iconsDir, name)
 			relPath := "_assets/icons/" + name
-			if err := addFileToZip(zw, srcPath, relPath); err != nil {
+			if err := addFileToZip(a.board.Fs, zw, srcPath, relPath); err != nil {
 				slog.Warn("export: failed to add icon to zip", "name", name, "error", err)
 			}
 		}
@@ -150,9 +152,30 @@ func (a *App) ExportZip(path string) error {
 	return nil
 }
 
-// addFileToZip reads a file from disk and writes it into a zip archive at the given path.
-func addFileToZip(zw *zip.Writer, srcPath string, zipPath string) error {
-	data, err := os.ReadFile(srcPath)
+// ExportCAR writes a content-addressed export of the board to the directory at path: card
+// bodies and icons are deduplicated into blobs/ by SHA-256 digest and referenced from
+// manifest.json, with manifest.json's own digest written to root.txt. See VerifyExport to check
+// an export for tampering or corruption, and daedalus.ExportCAR for the on-disk layout.
+func (a *App) ExportCAR(path string) error {
+	if a.board == nil {
+		return fmt.Errorf("board not loaded")
+	}
+	if err := daedalus.ExportCAR(a.board, a.iconsDir(), path); err != nil {
+		return fmt.Errorf("exporting CAR: %w", err)
+	}
+	slog.Info("board exported as content-addressed archive", "path", path)
+	return nil
+}
+
+// VerifyExport re-hashes every blob and the manifest in a content-addressed export written by
+// ExportCAR, returning an error describing the first mismatch or missing file it finds.
+func (a *App) VerifyExport(path string) error {
+	return daedalus.VerifyExport(path)
+}
+
+// addFileToZip reads a file via fs and writes it into a zip archive at the given path.
+func addFileToZip(fs afero.Fs, zw *zip.Writer, srcPath string, zipPath string) error {
+	data, err := afero.ReadFile(fs, srcPath)
 	if err != nil {
 		return err
 	}
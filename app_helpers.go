@@ -1,14 +1,53 @@
 package main
 
 import (
+	"context"
 	"daedalus/pkg/daedalus"
 	"fmt"
 	"log/slog"
 	"path/filepath"
-	"runtime"
 	"strings"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+// watcherPauseDuration suppresses board.yaml hot-reload callbacks for this long after a write
+// this App made itself, so it doesn't treat its own save as an external edit.
+const watcherPauseDuration = 5 * time.Second
+
+// pauseWatcher suppresses board.yaml hot-reload callbacks while a write this App just made
+// propagates to disk, so the app doesn't react to its own change as if it came from outside.
+func (a *App) pauseWatcher() {
+	if a.configWatcher != nil {
+		a.configWatcher.Pause(watcherPauseDuration)
+	}
+}
+
+// startBoardWatch stops any daedalus.WatchBoard subscription left over from a previously loaded
+// board and starts a fresh one for absRoot, forwarding each daedalus.BoardEvent to the frontend
+// as a "board:event" Wails event, the same way DownloadIcon forwards its progress. This is a
+// separate subscription from configWatcher (board.yaml only) and the sync oplog watcher (this
+// device's own writes): it's the one that notices a card edited by another device or editor.
+func (a *App) startBoardWatch(absRoot string) {
+	if a.boardWatchCancel != nil {
+		a.boardWatchCancel()
+	}
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.boardWatchCancel = cancel
+
+	events, err := daedalus.WatchBoard(ctx, absRoot)
+	if err != nil {
+		slog.Warn("failed to start board watch", "path", absRoot, "error", err)
+		return
+	}
+	go func() {
+		for event := range events {
+			wailsruntime.EventsEmit(a.ctx, "board:event", event)
+		}
+	}()
+}
+
 // requireBoard returns the loaded board state or an error if no board is loaded.
 func (a *App) requireBoard() (*daedalus.BoardState, error) {
 	if a.board == nil {
@@ -37,30 +76,18 @@ func validateIconName(name string) error {
 }
 
 // validatePath resolves a file path to absolute and verifies it is within the board root.
+// The traversal check itself now lives in Storage.Resolve; this keeps returning an absolute
+// path rather than a FileDesc so its many existing callers don't all need rewriting at once.
 func (a *App) validatePath(filePath string) (string, error) {
-	absPath, err := filepath.Abs(filePath)
-	if err != nil {
-		slog.Warn("path resolution failed", "path", filePath, "error", err)
-		return "", fmt.Errorf("invalid path")
+	if a.storage == nil {
+		return "", fmt.Errorf("board not loaded")
 	}
-	absRoot, err := filepath.Abs(a.board.RootPath)
+	desc, err := a.storage.Resolve(filePath)
 	if err != nil {
-		slog.Error("board root path resolution failed", "root", a.board.RootPath, "error", err)
-		return "", fmt.Errorf("invalid root path")
-	}
-	prefix := absRoot + string(filepath.Separator)
-	// Windows and macOS use case-insensitive filesystems.
-	hasPrefix := false
-	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
-		hasPrefix = strings.HasPrefix(strings.ToLower(absPath), strings.ToLower(prefix))
-	} else {
-		hasPrefix = strings.HasPrefix(absPath, prefix)
-	}
-	if !hasPrefix {
-		slog.Warn("path traversal rejected", "path", absPath, "root", absRoot)
-		return "", fmt.Errorf("path outside board directory")
+		slog.Warn("path traversal rejected", "path", filePath, "error", err)
+		return "", err
 	}
-	return absPath, nil
+	return filepath.Join(a.board.RootPath, desc.Name), nil
 }
 
 // OpenFileExternal opens a file in the system default application.
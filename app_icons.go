@@ -10,6 +10,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/spf13/afero"
 )
 
 // iconsDir returns the path to the board's icons directory.
@@ -25,7 +27,7 @@ func (a *App) ListIcons() ([]string, error) {
 	}
 
 	dir := a.iconsDir()
-	entries, err := os.ReadDir(dir)
+	entries, err := afero.ReadDir(a.board.Fs, dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return []string{}, nil
@@ -66,7 +68,7 @@ func (a *App) GetIconContent(name string) (string, error) {
 		return "", err
 	}
 
-	data, err := os.ReadFile(absPath)
+	data, err := afero.ReadFile(a.board.Fs, absPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return "", fmt.Errorf("icon not found: %s", name)
@@ -100,7 +102,7 @@ func (a *App) SaveCustomIcon(name string, content string) error {
 	}
 
 	dir := a.iconsDir()
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := a.board.Fs.MkdirAll(dir, 0755); err != nil {
 		slog.Error("failed to create icons directory", "path", dir, "error", err)
 		return fmt.Errorf("creating icons directory: %w", err)
 	}
@@ -114,10 +116,10 @@ func (a *App) SaveCustomIcon(name string, content string) error {
 	ext := strings.ToLower(filepath.Ext(name))
 	switch ext {
 	case ".svg":
-		if !strings.Contains(content, "<svg") {
-			return fmt.Errorf("invalid SVG content")
+		if err := daedalus.ValidateIconData([]byte(content), ext); err != nil {
+			return err
 		}
-		if err := os.WriteFile(absPath, []byte(content), 0644); err != nil {
+		if err := afero.WriteFile(a.board.Fs, absPath, []byte(content), 0644); err != nil {
 			slog.Error("failed to write SVG icon", "name", name, "error", err)
 			return fmt.Errorf("writing icon: %w", err)
 		}
@@ -126,7 +128,10 @@ func (a *App) SaveCustomIcon(name string, content string) error {
 		if err != nil {
 			return fmt.Errorf("invalid base64 content: %w", err)
 		}
-		if err := os.WriteFile(absPath, data, 0644); err != nil {
+		if err := daedalus.ValidateIconData(data, ext); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(a.board.Fs, absPath, data, 0644); err != nil {
 			slog.Error("failed to write PNG icon", "name", name, "error", err)
 			return fmt.Errorf("writing icon: %w", err)
 		}
@@ -158,7 +163,7 @@ func (a *App) DeleteIcon(name string) error {
 		return err
 	}
 
-	if err := os.Remove(absPath); err != nil {
+	if err := a.board.Fs.Remove(absPath); err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("icon not found: %s", name)
 		}
@@ -178,12 +183,12 @@ func (a *App) DeleteIcon(name string) error {
 			now := time.Now()
 			card.Metadata.Updated = &now
 
-			body, err := daedalus.ReadCardContent(card.FilePath)
+			body, err := daedalus.ReadCardContentFs(a.ctx, a.board.Fs, card.FilePath)
 			if err != nil {
 				slog.Error("failed to read card for icon cleanup", "path", card.FilePath, "error", err)
 				continue
 			}
-			if err := daedalus.WriteCardFile(card.FilePath, card.Metadata, body); err != nil {
+			if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, card.FilePath, card.Metadata, body); err != nil {
 				slog.Error("failed to write card for icon cleanup", "path", card.FilePath, "error", err)
 				continue
 			}
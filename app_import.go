@@ -0,0 +1,39 @@
+package main
+
+import (
+	"daedalus/pkg/daedalus"
+	"fmt"
+	"log/slog"
+)
+
+// ImportJSON restores a board from a JSON export produced by ExportJSON, merging it into
+// the currently loaded board and reloading afterward.
+func (a *App) ImportJSON(path string) error {
+	if a.board == nil {
+		return fmt.Errorf("board not loaded")
+	}
+
+	if err := daedalus.ImportJSON(path, a.board.RootPath, true); err != nil {
+		return fmt.Errorf("importing JSON: %w", err)
+	}
+
+	slog.Info("board imported from JSON", "path", path)
+	a.LoadBoard(a.board.RootPath)
+	return nil
+}
+
+// ImportZip restores a board from a ZIP export produced by ExportZip, merging it into
+// the currently loaded board and reloading afterward.
+func (a *App) ImportZip(path string) error {
+	if a.board == nil {
+		return fmt.Errorf("board not loaded")
+	}
+
+	if err := daedalus.ImportZip(path, a.board.RootPath, true); err != nil {
+		return fmt.Errorf("importing ZIP: %w", err)
+	}
+
+	slog.Info("board imported from zip", "path", path)
+	a.LoadBoard(a.board.RootPath)
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"daedalus/pkg/daedalus"
 	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
 	"strings"
 )
@@ -16,8 +15,8 @@ func (a *App) CreateList(name string) error {
 	}
 	a.pauseWatcher()
 
-	// Validate and clean list name.
-	name, err := daedalus.ValidateListName(name)
+	// Validate and clean list name, applying the board's slug: config if it set one.
+	name, err := daedalus.ValidateListNameWithSlug(name, a.board.Config.Slug)
 	if err != nil {
 		return err
 	}
@@ -27,9 +26,9 @@ func (a *App) CreateList(name string) error {
 		return fmt.Errorf("list already exists: %s", name)
 	}
 
-	// Create directory on disk
+	// Create directory through the board's storage backend (local disk, S3, SFTP, ...).
 	dirPath := filepath.Join(a.board.RootPath, name)
-	if err := os.MkdirAll(dirPath, 0755); err != nil {
+	if err := a.board.Fs.MkdirAll(dirPath, 0755); err != nil {
 		slog.Error("failed to create list directory", "name", name, "path", dirPath, "error", err)
 		return fmt.Errorf("creating list directory: %w", err)
 	}
@@ -38,17 +37,20 @@ func (a *App) CreateList(name string) error {
 	a.board.Lists[name] = []daedalus.KanbanCard{}
 	a.board.Config.Lists = append(a.board.Config.Lists, daedalus.ListEntry{Dir: name})
 
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save config after list creation", "name", name, "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 
 	slog.Info("list created", "name", name)
 	return nil
 }
 
-// DeleteList removes an entire list directory and cleans up all config references.
-func (a *App) DeleteList(listDirName string) error {
+// DeleteList removes a list. By default the list is archived (see ArchiveList) rather than
+// destroyed, so an accidental click doesn't lose cards permanently; pass permanent=true to
+// skip the archive and remove it outright.
+func (a *App) DeleteList(listDirName string, permanent bool) error {
 	if a.board == nil {
 		return fmt.Errorf("board not loaded")
 	}
@@ -67,17 +69,28 @@ func (a *App) DeleteList(listDirName string) error {
 		return fmt.Errorf("list not found: %s", listDirName)
 	}
 
-	slog.Info("deleting list", "name", listDirName, "cards", len(cards))
+	if !permanent {
+		if _, err := a.archiveListLocked(listDirName, cards); err != nil {
+			return err
+		}
+		slog.Info("list archived instead of deleted", "name", listDirName, "cards", len(cards))
+		return nil
+	}
+
+	slog.Info("permanently deleting list", "name", listDirName, "cards", len(cards))
 
 	// Sum file bytes for metrics update
 	var totalBytes int64
 	for _, card := range cards {
-		totalBytes += daedalus.GetFileSize(card.FilePath)
+		totalBytes += daedalus.GetFileSizeFs(a.board.Fs, card.FilePath)
 	}
 
-	// Remove directory from disk
+	// Remove the directory through a one-shot Tx, so a crash mid-delete leaves a journal
+	// ReplayTxFs can finish on the next LoadBoard instead of a half-removed list directory.
 	dirPath := filepath.Join(a.board.RootPath, listDirName)
-	if err := os.RemoveAll(dirPath); err != nil {
+	tx := daedalus.NewTx(a.board.Fs, a.board.RootPath)
+	tx.Remove(dirPath)
+	if err := tx.Commit(a.ctx); err != nil {
 		slog.Error("failed to remove list directory", "name", listDirName, "path", dirPath, "error", err)
 		return fmt.Errorf("removing list directory: %w", err)
 	}
@@ -89,20 +102,24 @@ func (a *App) DeleteList(listDirName string) error {
 	delete(a.board.Lists, listDirName)
 
 	// Remove from config Lists array
-	idx := daedalus.FindListEntry(a.board.Config.Lists, listDirName)
+	idx := daedalus.FindListEntry(a.ctx, a.board.Config.Lists, listDirName)
 	if idx >= 0 {
 		a.board.Config.Lists = append(a.board.Config.Lists[:idx], a.board.Config.Lists[idx+1:]...)
 	}
 
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save config after list deletion", "name", listDirName, "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Info("list deleted", "name", listDirName, "cardsRemoved", len(cards), "bytesFreed", totalBytes)
 	return nil
 }
 
-// saveListBoolFlags builds a set from dirs, applies setFn to each list config entry, and persists to board.yaml.
+// saveListBoolFlags builds a set from dirs, applies setFn to each list config entry, and
+// persists the change. On a sharded board (see daedalus.IsShardedFs) only the lists setFn
+// actually changed are rewritten, as individual .list.yaml files; a monolithic board still
+// rewrites the whole board.yaml, since that's the only place the flag lives there.
 func (a *App) saveListBoolFlags(dirs []string, setFn func(*daedalus.ListEntry, bool)) error {
 	if a.board == nil {
 		return fmt.Errorf("board not loaded")
@@ -113,10 +130,32 @@ func (a *App) saveListBoolFlags(dirs []string, setFn func(*daedalus.ListEntry, b
 	for _, dir := range dirs {
 		set[dir] = true
 	}
+
+	sharded := daedalus.IsShardedFs(a.board.Fs, a.board.RootPath)
+	var touched []daedalus.ListEntry
 	for i := range a.board.Config.Lists {
-		setFn(&a.board.Config.Lists[i], set[a.board.Config.Lists[i].Dir])
+		entry := &a.board.Config.Lists[i]
+		before := *entry
+		setFn(entry, set[entry.Dir])
+		if sharded && *entry != before {
+			touched = append(touched, *entry)
+		}
 	}
-	return daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config)
+
+	if sharded {
+		for _, entry := range touched {
+			if err := daedalus.SaveShardedListEntryFs(a.board.Fs, a.board.RootPath, entry); err != nil {
+				return fmt.Errorf("saving sharded list %q: %w", entry.Dir, err)
+			}
+			a.enqueueSync(daedalus.SyncOpWrite, filepath.Join(entry.Dir, ".list.yaml"))
+		}
+		return nil
+	}
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
+		return err
+	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
+	return nil
 }
 
 // SaveCollapsedLists sets the Collapsed flag on matching entries and persists to board.yaml.
@@ -166,10 +205,11 @@ func (a *App) SavePinnedLists(left []string, right []string) error {
 		}
 	}
 
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save pinned lists", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Debug("pinned lists saved", "left", len(left), "right", len(right))
 	return nil
 }
@@ -191,7 +231,7 @@ func (a *App) SaveListConfig(dirName string, title string, limit int, color stri
 	}
 	a.pauseWatcher()
 
-	idx := daedalus.FindListEntry(a.board.Config.Lists, dirName)
+	idx := daedalus.FindListEntry(a.ctx, a.board.Config.Lists, dirName)
 	if idx >= 0 {
 		a.board.Config.Lists[idx].Title = title
 		a.board.Config.Lists[idx].Limit = limit
@@ -207,10 +247,11 @@ func (a *App) SaveListConfig(dirName string, title string, limit int, color stri
 		})
 	}
 
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save list config", "dir", dirName, "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Info("list config saved", "dir", dirName, "title", title, "limit", limit)
 	return nil
 }
@@ -246,10 +287,11 @@ func (a *App) SaveListOrder(order []string) error {
 	}
 
 	a.board.Config.Lists = reordered
-	if err := daedalus.SaveBoardConfig(a.board.RootPath, a.board.Config); err != nil {
+	if err := daedalus.SaveBoardConfigFs(a.ctx, a.board.Fs, a.board.RootPath, a.board.Config); err != nil {
 		slog.Error("failed to save list order", "error", err)
 		return err
 	}
+	a.enqueueSync(daedalus.SyncOpWrite, "board.yaml")
 	slog.Info("list order saved", "count", len(reordered))
 	return nil
 }
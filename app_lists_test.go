@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"daedalus/pkg/daedalus"
 	"os"
 	"path/filepath"
@@ -126,7 +127,7 @@ func TestCreateList_Success(t *testing.T) {
 	}
 
 	// Config should have the new entry
-	idx := daedalus.FindListEntry(app.board.Config.Lists, "backlog")
+	idx := daedalus.FindListEntry(context.Background(), app.board.Config.Lists, "backlog")
 	if idx < 0 {
 		t.Fatal("expected config entry for backlog")
 	}
@@ -136,7 +137,7 @@ func TestCreateList_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error loading saved config: %v", err)
 	}
-	savedIdx := daedalus.FindListEntry(config.Lists, "backlog")
+	savedIdx := daedalus.FindListEntry(context.Background(), config.Lists, "backlog")
 	if savedIdx < 0 {
 		t.Fatal("expected persisted config entry for backlog")
 	}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"daedalus/pkg/daedalus"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// moveLatencyHistogram tracks MoveCard/MoveCards wall-clock duration in seconds, instrumented
+// directly in the move path so it reflects real cross-list renames and storage-backend latency,
+// not just in-memory reordering. Exposed as daedalus_card_move_duration_seconds.
+var moveLatencyHistogram = daedalus.NewHistogram([]float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5})
+
+// metricsShutdownTimeout bounds how long StopMetricsServer waits for in-flight requests to finish.
+const metricsShutdownTimeout = 5 * time.Second
+
+// StartMetricsServer starts an HTTP server on addr serving GET /metrics in Prometheus text
+// format, so board health (heap, GC, goroutines, per-list card counts, move latency, icon
+// download outcomes) can be graphed in Grafana without the desktop frontend being open. addr
+// must resolve to a loopback address -- this is a local debugging surface, not one meant to be
+// reachable over a network. Only one server can run at a time; call StopMetricsServer first to
+// change addr.
+func (a *App) StartMetricsServer(addr string) error {
+	if a.metricsServer != nil {
+		return fmt.Errorf("metrics server already running")
+	}
+	if err := requireLoopbackAddr(addr); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", a.handleMetrics)
+	server := &http.Server{Addr: addr, Handler: mux}
+	a.metricsServer = server
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("metrics server stopped unexpectedly", "addr", addr, "error", err)
+		}
+	}()
+	slog.Info("metrics server started", "addr", addr)
+	return nil
+}
+
+// StopMetricsServer shuts down the running metrics server. It is a no-op if none is running.
+func (a *App) StopMetricsServer() error {
+	if a.metricsServer == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer cancel()
+
+	err := a.metricsServer.Shutdown(ctx)
+	a.metricsServer = nil
+	if err != nil {
+		slog.Error("metrics server shutdown failed", "error", err)
+		return err
+	}
+	slog.Info("metrics server stopped")
+	return nil
+}
+
+// requireLoopbackAddr rejects any addr whose host doesn't resolve to a loopback address, so the
+// metrics endpoint (unauthenticated, unlike WebDAV/the JSON API) can't be exposed off-box by
+// accident.
+func requireLoopbackAddr(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid metrics address %q: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("metrics server must bind to loopback, got host %q", host)
+	}
+	return nil
+}
+
+// handleMetrics renders the board's current metrics as Prometheus text format.
+func (a *App) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(a.renderPrometheusMetrics()))
+}
+
+// renderPrometheusMetrics builds the Prometheus text-format body for GET /metrics: the same
+// runtime data GetMetrics returns to the frontend, plus per-list card gauges, the card-move
+// latency histogram, and icon download counters that have no Wails-facing equivalent.
+func (a *App) renderPrometheusMetrics() string {
+	m := a.GetMetrics()
+
+	var buf strings.Builder
+	writeGauge(&buf, "daedalus_heap_alloc_mb", "Heap memory allocated, in MB.", m.HeapAlloc)
+	writeGauge(&buf, "daedalus_sys_mb", "Memory obtained from the OS, in MB.", m.Sys)
+	writeGauge(&buf, "daedalus_gc_runs_total", "Number of completed GC cycles.", float64(m.NumGC))
+	writeGauge(&buf, "daedalus_goroutines", "Number of running goroutines.", float64(m.Goroutines))
+	writeGauge(&buf, "daedalus_cards_total", "Number of cards across all lists in the loaded board.", float64(m.NumCards))
+	writeGauge(&buf, "daedalus_lists_total", "Number of lists in the loaded board.", float64(m.NumLists))
+	writeGauge(&buf, "daedalus_max_id", "High-water mark for card IDs.", float64(m.MaxID))
+	writeGauge(&buf, "daedalus_file_size_mb", "Total size of all card files, in MB.", m.FileSizeMB)
+	writeGauge(&buf, "daedalus_process_rss_mb", "Resident set size of the daedalus process, in MB.", m.ProcessRSS)
+	writeGauge(&buf, "daedalus_process_cpu_percent", "CPU usage of the daedalus process, as a percent.", m.ProcessCPU)
+
+	buf.WriteString("# HELP daedalus_list_cards Number of cards in a single list.\n")
+	buf.WriteString("# TYPE daedalus_list_cards gauge\n")
+	if a.board != nil {
+		for list, cards := range a.board.Lists {
+			fmt.Fprintf(&buf, "daedalus_list_cards{list=%q} %d\n", list, len(cards))
+		}
+	}
+
+	writeHistogram(&buf, "daedalus_card_move_duration_seconds", "Card move latency, in seconds.", moveLatencyHistogram)
+
+	buf.WriteString("# HELP daedalus_icon_downloads_total Icon download attempts by outcome.\n")
+	buf.WriteString("# TYPE daedalus_icon_downloads_total counter\n")
+	fmt.Fprintf(&buf, "daedalus_icon_downloads_total{result=\"attempted\"} %d\n", daedalus.IconDownloadAttempts.Load())
+	fmt.Fprintf(&buf, "daedalus_icon_downloads_total{result=\"success\"} %d\n", daedalus.IconDownloadSuccesses.Load())
+	fmt.Fprintf(&buf, "daedalus_icon_downloads_total{result=\"failure\"} %d\n", daedalus.IconDownloadFailures.Load())
+
+	return buf.String()
+}
+
+// writeGauge appends a single Prometheus gauge metric, with its HELP/TYPE preamble, to buf.
+func writeGauge(buf *strings.Builder, name, help string, value float64) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", name, help, name, name, value)
+}
+
+// writeHistogram appends h's buckets, sum, and count as a Prometheus histogram metric, with its
+// HELP/TYPE preamble, to buf.
+func writeHistogram(buf *strings.Builder, name, help string, h *daedalus.Histogram) {
+	buckets, counts, sum, count := h.Snapshot()
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	for i, bound := range buckets {
+		fmt.Fprintf(buf, "%s_bucket{le=\"%g\"} %d\n", name, bound, counts[i])
+	}
+	fmt.Fprintf(buf, "%s_bucket{le=\"+Inf\"} %d\n", name, count)
+	fmt.Fprintf(buf, "%s_sum %g\n", name, sum)
+	fmt.Fprintf(buf, "%s_count %d\n", name, count)
+}
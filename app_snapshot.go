@@ -0,0 +1,79 @@
+package main
+
+import (
+	"daedalus/pkg/daedalus"
+	"fmt"
+	"log/slog"
+)
+
+// CreateSnapshot writes a point-in-time zip snapshot of the current board under
+// _snapshots/ and returns its metadata. Afterward, it rolls old snapshots off per
+// board.yaml's backup.keep, falling back to the app-level defaultSnapshotKeep
+// (see AppConfig) when the board doesn't set its own.
+func (a *App) CreateSnapshot(label string) (daedalus.SnapshotInfo, error) {
+	if a.board == nil {
+		return daedalus.SnapshotInfo{}, fmt.Errorf("board not loaded")
+	}
+
+	info, err := daedalus.CreateSnapshot(a.board.RootPath, a.board, a.iconsDir(), label)
+	if err != nil {
+		if _, ok := err.(*daedalus.ExportError); !ok {
+			return info, fmt.Errorf("creating snapshot: %w", err)
+		}
+		slog.Warn("snapshot created with partial export", "id", info.ID, "error", err)
+		return info, err
+	}
+
+	slog.Info("snapshot created", "id", info.ID, "sizeBytes", info.SizeBytes)
+	if keep := a.rollingSnapshotKeep(); keep > 0 {
+		if err := daedalus.PruneSnapshots(a.board.RootPath, keep); err != nil {
+			slog.Warn("failed to prune rolling snapshots", "error", err)
+		}
+	}
+	return info, nil
+}
+
+// rollingSnapshotKeep returns how many snapshots to keep after a manual CreateSnapshot call:
+// the board's own backup.keep if it set one, else the app-level default, else 0 (unbounded).
+func (a *App) rollingSnapshotKeep() int {
+	if a.board.Config.Backup != nil && a.board.Config.Backup.Keep > 0 {
+		return a.board.Config.Backup.Keep
+	}
+	if a.appConfig != nil {
+		return a.appConfig.DefaultSnapshotKeep
+	}
+	return 0
+}
+
+// ListSnapshots returns every snapshot of the current board, sorted newest-first.
+func (a *App) ListSnapshots() ([]daedalus.SnapshotInfo, error) {
+	if a.board == nil {
+		return nil, fmt.Errorf("board not loaded")
+	}
+	return daedalus.ListSnapshots(a.board.RootPath)
+}
+
+// RestoreSnapshot swaps the current board for the contents of the given snapshot and
+// reloads it.
+func (a *App) RestoreSnapshot(id string) error {
+	if a.board == nil {
+		return fmt.Errorf("board not loaded")
+	}
+	rootPath := a.board.RootPath
+
+	if err := daedalus.RestoreSnapshot(rootPath, id); err != nil {
+		return fmt.Errorf("restoring snapshot: %w", err)
+	}
+
+	slog.Info("snapshot restored", "id", id)
+	a.LoadBoard(rootPath)
+	return nil
+}
+
+// PruneSnapshots deletes all but the keep most recent snapshots of the current board.
+func (a *App) PruneSnapshots(keep int) error {
+	if a.board == nil {
+		return fmt.Errorf("board not loaded")
+	}
+	return daedalus.PruneSnapshots(a.board.RootPath, keep)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"daedalus/pkg/daedalus"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// MoveOp is one entry in a MoveCards batch: move the card at FilePath into TargetList at
+// ListOrder. Same-list reorders and cross-list moves are both expressed the same way MoveCard
+// takes them.
+type MoveOp struct {
+	FilePath   string  `json:"filePath"`
+	TargetList string  `json:"targetList"`
+	ListOrder  float64 `json:"listOrder"`
+}
+
+// moveCardPlan is the validated, staged state for one MoveOp, computed before anything on disk
+// changes so MoveCards can commit -- or roll back -- every op without re-deriving it mid-batch.
+type moveCardPlan struct {
+	op            MoveOp
+	absPath       string
+	newPath       string
+	crossList     bool
+	sourceListKey string
+	origBytes     []byte
+	origSize      int64
+	card          daedalus.KanbanCard
+	stagedPath    string
+}
+
+// MoveCards applies a batch of moves atomically: every op is validated up front (source card
+// exists, neither the source nor target list is locked, target list exists), the updated
+// frontmatter for every op is staged into a temp directory, and only then are the staged files
+// committed onto the board with os.Rename in the order given. If any commit step fails, every
+// already-committed op is rolled back -- its staged file removed and its original file restored
+// from the pre-call snapshot -- so a partial failure leaves the on-disk board and a.board.Lists
+// exactly where they started, instead of splitting a card across directories or corrupting
+// ListOrder. This is the batch counterpart to MoveCard, for drag-drop of multi-selects and
+// scripted rearrangements where a one-op-at-a-time loop would leave the board half-moved on
+// a failure partway through.
+func (a *App) MoveCards(ops []MoveOp) ([]daedalus.KanbanCard, error) {
+	if a.board == nil {
+		return nil, fmt.Errorf("board not loaded")
+	}
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	start := time.Now()
+	defer func() { moveLatencyHistogram.Observe(time.Since(start).Seconds()) }()
+	a.pauseWatcher()
+
+	plans := make([]*moveCardPlan, 0, len(ops))
+	for _, op := range ops {
+		plan, err := a.planMoveCard(op)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+
+	tempDir, err := afero.TempDir(a.board.Fs, a.board.RootPath, ".daedalus-move-*")
+	if err != nil {
+		return nil, fmt.Errorf("staging move batch: %w", err)
+	}
+	defer a.board.Fs.RemoveAll(tempDir)
+
+	for i, plan := range plans {
+		body, err := readCardBodyFromBytes(plan.origBytes)
+		if err != nil {
+			return nil, fmt.Errorf("reading card %d for move: %w", plan.card.Metadata.ID, err)
+		}
+		plan.stagedPath = filepath.Join(tempDir, fmt.Sprintf("%d-%s", i, filepath.Base(plan.newPath)))
+		if err := daedalus.WriteCardFileFs(a.ctx, a.board.Fs, plan.stagedPath, plan.card.Metadata, body); err != nil {
+			return nil, fmt.Errorf("staging card %d for move: %w", plan.card.Metadata.ID, err)
+		}
+	}
+
+	committed := make([]*moveCardPlan, 0, len(plans))
+	for _, plan := range plans {
+		if err := commitMovePlan(a.board.Fs, plan); err != nil {
+			slog.Error("move batch commit failed, rolling back", "id", plan.card.Metadata.ID, "error", err)
+			for j := len(committed) - 1; j >= 0; j-- {
+				rollbackMovePlan(a.board.Fs, committed[j])
+			}
+			return nil, fmt.Errorf("committing move for card %d: %w", plan.card.Metadata.ID, err)
+		}
+		committed = append(committed, plan)
+	}
+
+	moved := make([]daedalus.KanbanCard, 0, len(plans))
+	for _, plan := range plans {
+		srcCards := a.board.Lists[plan.sourceListKey]
+		for i, c := range srcCards {
+			if c.FilePath == plan.absPath {
+				a.board.Lists[plan.sourceListKey] = append(srcCards[:i], srcCards[i+1:]...)
+				break
+			}
+		}
+		a.board.Lists[plan.op.TargetList] = insertSorted(a.board.Lists[plan.op.TargetList], plan.card)
+		a.board.TotalFileBytes += daedalus.GetFileSizeFs(a.board.Fs, plan.card.FilePath) - plan.origSize
+		a.enqueueSync(daedalus.SyncOpWrite, plan.card.FilePath)
+		if plan.crossList {
+			a.enqueueSync(daedalus.SyncOpDelete, plan.absPath)
+		}
+		moved = append(moved, plan.card)
+	}
+
+	slog.Info("moved cards in batch", "count", len(moved))
+	return moved, nil
+}
+
+// planMoveCard validates a single MoveOp against current board state and returns the plan
+// MoveCards will stage and commit. It makes no filesystem changes.
+func (a *App) planMoveCard(op MoveOp) (*moveCardPlan, error) {
+	absPath, err := a.validatePath(op.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := a.board.Lists[op.TargetList]; !ok {
+		return nil, fmt.Errorf("target list not found: %s", op.TargetList)
+	}
+
+	sourceListKey, idx, found := a.findCardByPath(absPath)
+	if !found {
+		return nil, fmt.Errorf("card not found in any list: %s", op.FilePath)
+	}
+	if isListLocked(a.board.Config, sourceListKey) {
+		return nil, fmt.Errorf("source list is locked: %s", sourceListKey)
+	}
+	if isListLocked(a.board.Config, op.TargetList) {
+		return nil, fmt.Errorf("target list is locked: %s", op.TargetList)
+	}
+
+	origBytes, err := afero.ReadFile(a.board.Fs, absPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading card %s: %w", op.FilePath, err)
+	}
+
+	card := a.board.Lists[sourceListKey][idx]
+	now := time.Now()
+	card.Metadata.Updated = &now
+	card.Metadata.ListOrder = op.ListOrder
+
+	newPath := filepath.Join(a.board.RootPath, op.TargetList, filepath.Base(absPath))
+	crossList := sourceListKey != op.TargetList
+	if crossList {
+		card.FilePath = newPath
+		card.ListName = op.TargetList
+	}
+
+	return &moveCardPlan{
+		op:            op,
+		absPath:       absPath,
+		newPath:       newPath,
+		crossList:     crossList,
+		sourceListKey: sourceListKey,
+		origBytes:     origBytes,
+		origSize:      int64(len(origBytes)),
+		card:          card,
+	}, nil
+}
+
+// commitMovePlan renames a plan's staged file onto its final path, removing the now-stale
+// source file for a cross-list move. A same-list reorder renames the staged file directly over
+// the original path, which is a single atomic replace.
+func commitMovePlan(fs afero.Fs, plan *moveCardPlan) error {
+	if err := fs.Rename(plan.stagedPath, plan.newPath); err != nil {
+		return fmt.Errorf("renaming staged card into place: %w", err)
+	}
+	if plan.crossList {
+		if err := fs.Remove(plan.absPath); err != nil {
+			// The new file already landed; undo that much so the rollback below has a clean
+			// single-file state to restore.
+			fs.Remove(plan.newPath)
+			return fmt.Errorf("removing old card path: %w", err)
+		}
+	}
+	return nil
+}
+
+// rollbackMovePlan undoes a previously committed moveCardPlan, restoring the original file from
+// its pre-move snapshot and removing the file that landed at the new path.
+func rollbackMovePlan(fs afero.Fs, plan *moveCardPlan) {
+	if plan.crossList {
+		if err := fs.Remove(plan.newPath); err != nil && !os.IsNotExist(err) {
+			slog.Error("failed to remove new path during move rollback", "path", plan.newPath, "error", err)
+		}
+	}
+	if err := afero.WriteFile(fs, plan.absPath, plan.origBytes, 0644); err != nil {
+		slog.Error("failed to restore original card during move rollback", "path", plan.absPath, "error", err)
+	}
+}
+
+// readCardBodyFromBytes parses raw card file bytes and returns just the markdown body, for
+// staging a card under its existing body with new frontmatter.
+func readCardBodyFromBytes(data []byte) (string, error) {
+	_, body, err := daedalus.ParseCardBytes(data)
+	if err != nil {
+		return "", err
+	}
+	return body, nil
+}
+
+// WithinTransaction snapshots the board's in-memory list state (and the MaxID/TotalFileBytes
+// counters), runs fn, and restores the snapshot if fn returns an error. It lets other mutating
+// methods (create, rename, delete) opt into the same all-or-nothing bookkeeping MoveCards uses
+// internally, so a UI can group several calls under one undo entry without each method
+// re-implementing its own rollback. fn remains responsible for any filesystem writes it makes;
+// WithinTransaction only guarantees the in-memory board ends up matching disk, or back where it
+// started, never caught in between.
+func (a *App) WithinTransaction(fn func() error) error {
+	if a.board == nil {
+		return fmt.Errorf("board not loaded")
+	}
+
+	listsSnapshot := make(map[string][]daedalus.KanbanCard, len(a.board.Lists))
+	for list, cards := range a.board.Lists {
+		listsSnapshot[list] = append([]daedalus.KanbanCard(nil), cards...)
+	}
+	maxID := a.board.MaxID
+	totalBytes := a.board.TotalFileBytes
+
+	if err := fn(); err != nil {
+		a.board.Lists = listsSnapshot
+		a.board.MaxID = maxID
+		a.board.TotalFileBytes = totalBytes
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"daedalus/pkg/daedalus"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/net/webdav"
+)
+
+// webdavShutdownTimeout bounds how long StopWebDAV waits for in-flight requests to finish.
+const webdavShutdownTimeout = 5 * time.Second
+
+// StartWebDAV serves the currently loaded board over WebDAV at addr, gated on HTTP basic auth
+// with user/pass, so it can be mounted in Finder/Explorer/rclone and edited with any external
+// editor while the app is open. Writes are routed through a daedalus.BoardFileSystem, so a card
+// edited over WebDAV shows up in the app's UI without a rescan. A write into a locked list is
+// rejected with 403 before it reaches the board, via withWebDAVLockCheck. Only one server can run
+// at a time; call StopWebDAV first to change addr or credentials.
+func (a *App) StartWebDAV(addr string, user string, pass string) error {
+	board, err := a.requireBoard()
+	if err != nil {
+		return err
+	}
+	if a.webdavServer != nil {
+		return fmt.Errorf("webdav server already running")
+	}
+	if user == "" || pass == "" {
+		return fmt.Errorf("webdav requires a username and password")
+	}
+
+	handler := &webdav.Handler{
+		FileSystem: daedalus.NewBoardFileSystem(board),
+		LockSystem: webdav.NewMemLS(),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				slog.Warn("webdav request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+			}
+		},
+	}
+	server := &http.Server{
+		Addr:    addr,
+		Handler: withWebDAVBasicAuth(user, pass, withWebDAVLockCheck(board, handler)),
+	}
+	a.webdavServer = server
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("webdav server stopped unexpectedly", "addr", addr, "error", err)
+		}
+	}()
+	slog.Info("webdav server started", "addr", addr)
+	return nil
+}
+
+// StopWebDAV shuts down the running WebDAV server. It is a no-op if none is running.
+func (a *App) StopWebDAV() error {
+	if a.webdavServer == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webdavShutdownTimeout)
+	defer cancel()
+
+	err := a.webdavServer.Shutdown(ctx)
+	a.webdavServer = nil
+	if err != nil {
+		slog.Error("webdav server shutdown failed", "error", err)
+		return err
+	}
+	slog.Info("webdav server stopped")
+	return nil
+}
+
+// ServeWebDAV serves the currently loaded board over WebDAV on an already-bound listener,
+// blocking until ctx is cancelled or the listener itself fails. It's the headless counterpart
+// to StartWebDAV/StopWebDAV: a caller that already owns a listener (e.g. daedalus serve binding
+// it alongside the HTTP/JSON API, or a test binding "127.0.0.1:0") drives the lifecycle with ctx
+// instead of a separate Stop call. Credentials are read from board.yaml's webdav: section, same
+// as the CLI's serve-webdav command (StartWebDAV instead takes them as arguments).
+func (a *App) ServeWebDAV(ctx context.Context, listener net.Listener) error {
+	board, err := a.requireBoard()
+	if err != nil {
+		return err
+	}
+	if board.Config.WebDAV == nil || board.Config.WebDAV.Username == "" || board.Config.WebDAV.Password == "" {
+		return fmt.Errorf("board.yaml has no webdav section; add webdav: {username, password} first")
+	}
+
+	handler := &webdav.Handler{
+		FileSystem: daedalus.NewBoardFileSystem(board),
+		LockSystem: webdav.NewMemLS(),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				slog.Warn("webdav request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+			}
+		},
+	}
+	server := &http.Server{
+		Handler: withWebDAVBasicAuth(board.Config.WebDAV.Username, board.Config.WebDAV.Password, withWebDAVLockCheck(board, handler)),
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), webdavShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			slog.Error("webdav server shutdown failed", "error", err)
+			return err
+		}
+		return ctx.Err()
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}
+}
+
+// withWebDAVBasicAuth requires HTTP basic auth matching user/pass before delegating to next.
+func withWebDAVBasicAuth(user, pass string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok || u != user || p != pass {
+			w.Header().Set("WWW-Authenticate", `Basic realm="daedalus"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// webdavLockedWriteMethods are the WebDAV request methods that mutate an existing list
+// directory's contents and so must be rejected up front if that list is locked. MKCOL is
+// excluded since it only ever creates a new, as-yet-unlocked list.
+var webdavLockedWriteMethods = map[string]bool{
+	"PUT":       true,
+	"DELETE":    true,
+	"MOVE":      true,
+	"COPY":      true,
+	"PROPPATCH": true,
+}
+
+// withWebDAVLockCheck returns 403 for a write method targeting a locked list (and, for MOVE/COPY,
+// a locked destination list) before the request reaches next. This matters because
+// golang.org/x/net/webdav.Handler reports every daedalus.BoardFileSystem error -- locked list or
+// not -- as 404, which would otherwise hide the real reason a write was rejected.
+func withWebDAVLockCheck(board *daedalus.BoardState, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !webdavLockedWriteMethods[r.Method] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if daedalus.IsListLocked(board.Config, webdavListDir(r.URL.Path)) {
+			http.Error(w, "list is locked", http.StatusForbidden)
+			return
+		}
+		if dest := r.Header.Get("Destination"); dest != "" {
+			if destURL, err := url.Parse(dest); err == nil && daedalus.IsListLocked(board.Config, webdavListDir(destURL.Path)) {
+				http.Error(w, "destination list is locked", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// webdavListDir extracts the top-level list directory from a WebDAV request path, or "" if name
+// isn't rooted under one (e.g. the board root itself).
+func webdavListDir(name string) string {
+	clean := strings.Trim(path.Clean("/"+name), "/")
+	if clean == "" {
+		return ""
+	}
+	return strings.SplitN(clean, "/", 2)[0]
+}
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/spf13/afero"
 
 	"daedalus/pkg/daedalus"
+	"daedalus/pkg/daedalus/webdav"
 )
 
 func jsonOut(v any) error {
@@ -20,8 +26,8 @@ func jsonOut(v any) error {
 	return nil
 }
 
-func cmdBoard(boardPath string) error {
-	state, err := daedalus.ScanBoard(boardPath)
+func cmdBoard(store daedalus.Store) error {
+	state, err := store.Scan(context.Background())
 	if err != nil {
 		return err
 	}
@@ -39,8 +45,8 @@ func cmdBoard(boardPath string) error {
 	})
 }
 
-func cmdLists(boardPath string) error {
-	state, err := daedalus.ScanBoard(boardPath)
+func cmdLists(store daedalus.Store) error {
+	state, err := store.Scan(context.Background())
 	if err != nil {
 		return err
 	}
@@ -68,13 +74,18 @@ func cmdLists(boardPath string) error {
 	return jsonOut(result)
 }
 
-func cmdCards(boardPath string, args []string) error {
+func cmdCards(boardPath string, store daedalus.Store, args []string, selectorExpr string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: cards <list-name>")
+		return fmt.Errorf("usage: cards <list-name> [--selector <expr>]")
 	}
 	listName := args[0]
 
-	state, err := daedalus.ScanBoard(boardPath)
+	selector, err := daedalus.ParseLabelSelector(selectorExpr)
+	if err != nil {
+		return err
+	}
+
+	state, err := store.Scan(context.Background())
 	if err != nil {
 		return err
 	}
@@ -89,35 +100,49 @@ func cmdCards(boardPath string, args []string) error {
 		Title     string   `json:"title"`
 		Labels    []string `json:"labels"`
 		ListOrder float64  `json:"listOrder"`
+		Signature string   `json:"signature"`
 	}
 
 	result := []cardInfo{}
 	for _, c := range cards {
+		if !selector.Matches(c.Metadata.Labels) {
+			continue
+		}
 		labels := c.Metadata.Labels
 		if labels == nil {
 			labels = []string{}
 		}
+		signature, err := daedalus.VerifyCard(boardPath, c.FilePath)
+		if err != nil {
+			return fmt.Errorf("verifying card signature: %w", err)
+		}
 		result = append(result, cardInfo{
 			ID:        c.Metadata.ID,
 			Title:     c.Metadata.Title,
 			Labels:    labels,
 			ListOrder: c.Metadata.ListOrder,
+			Signature: signature,
 		})
 	}
 
 	return jsonOut(result)
 }
 
-func cmdCardGet(boardPath string, args []string) error {
+func cmdCardGet(boardPath string, store daedalus.Store, args []string, selectorExpr string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: card-get <card-id>")
+		return fmt.Errorf("usage: card-get <card-id> [--selector <expr>]")
 	}
 	cardID, err := strconv.Atoi(args[0])
 	if err != nil {
 		return fmt.Errorf("invalid card ID %q: %w", args[0], err)
 	}
 
-	state, err := daedalus.ScanBoard(boardPath)
+	selector, err := daedalus.ParseLabelSelector(selectorExpr)
+	if err != nil {
+		return err
+	}
+
+	state, err := store.Scan(context.Background())
 	if err != nil {
 		return err
 	}
@@ -125,16 +150,24 @@ func cmdCardGet(boardPath string, args []string) error {
 	for _, cards := range state.Lists {
 		for _, c := range cards {
 			if c.Metadata.ID == cardID {
-				body, err := daedalus.ReadCardContent(c.FilePath)
+				if !selector.Matches(c.Metadata.Labels) {
+					return fmt.Errorf("card with ID %d does not match selector %q", cardID, selectorExpr)
+				}
+				body, err := store.ReadCard(context.Background(), c)
 				if err != nil {
 					return fmt.Errorf("reading card content: %w", err)
 				}
+				signature, err := daedalus.VerifyCard(boardPath, c.FilePath)
+				if err != nil {
+					return fmt.Errorf("verifying card signature: %w", err)
+				}
 				return jsonOut(map[string]any{
-					"id":       c.Metadata.ID,
-					"title":    c.Metadata.Title,
-					"list":     c.ListName,
-					"metadata": c.Metadata,
-					"body":     body,
+					"id":        c.Metadata.ID,
+					"title":     c.Metadata.Title,
+					"list":      c.ListName,
+					"metadata":  c.Metadata,
+					"body":      body,
+					"signature": signature,
 				})
 			}
 		}
@@ -143,14 +176,102 @@ func cmdCardGet(boardPath string, args []string) error {
 	return fmt.Errorf("card with ID %d not found", cardID)
 }
 
-func cmdCardCreate(boardPath string, args []string) error {
+// cmdCardMove moves an existing card into destList at position ("top", "bottom", or a 0-based
+// index; defaults to "bottom"), updating its list_order and renaming its file if it changes
+// lists.
+func cmdCardMove(store daedalus.Store, args []string, position string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: card-move <card-id> <list-name> [--position top|bottom|<index>]")
+	}
+	cardID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid card ID %q: %w", args[0], err)
+	}
+	destList := args[1]
+	if position == "" {
+		position = "bottom"
+	}
+
+	state, err := store.Scan(context.Background())
+	if err != nil {
+		return err
+	}
+	destCards, ok := state.Lists[destList]
+	if !ok {
+		return fmt.Errorf("list %q not found", destList)
+	}
+
+	for _, cards := range state.Lists {
+		for _, c := range cards {
+			if c.Metadata.ID != cardID {
+				continue
+			}
+			meta, filePath, err := store.MoveCard(destList, c, destCards, position)
+			if err != nil {
+				return err
+			}
+			return jsonOut(map[string]any{
+				"id":    meta.ID,
+				"list":  destList,
+				"path":  filePath,
+				"order": meta.ListOrder,
+			})
+		}
+	}
+
+	return fmt.Errorf("card with ID %d not found", cardID)
+}
+
+// cmdCardUpdate edits an existing card's title and/or labels in place. titleSet distinguishes
+// "no --title flag given" from "--title \"\"" (clear the title), since an empty title string is
+// ambiguous otherwise.
+func cmdCardUpdate(store daedalus.Store, args []string, title string, titleSet bool, addLabels, removeLabels []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: card-update <card-id> [--title ...] [--add-label ...] [--remove-label ...]")
+	}
+	cardID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid card ID %q: %w", args[0], err)
+	}
+
+	state, err := store.Scan(context.Background())
+	if err != nil {
+		return err
+	}
+
+	update := daedalus.CardUpdate{AddLabels: addLabels, RemoveLabels: removeLabels}
+	if titleSet {
+		update.Title = &title
+	}
+
+	for _, cards := range state.Lists {
+		for _, c := range cards {
+			if c.Metadata.ID != cardID {
+				continue
+			}
+			meta, err := daedalus.UpdateCardOnDisk(c, update)
+			if err != nil {
+				return err
+			}
+			return jsonOut(map[string]any{
+				"id":     meta.ID,
+				"title":  meta.Title,
+				"labels": meta.Labels,
+			})
+		}
+	}
+
+	return fmt.Errorf("card with ID %d not found", cardID)
+}
+
+func cmdCardCreate(store daedalus.Store, args []string) error {
 	if len(args) < 2 {
 		return fmt.Errorf("usage: card-create <list-name> <title>")
 	}
 	listName := args[0]
 	title := args[1]
 
-	state, err := daedalus.ScanBoard(boardPath)
+	state, err := store.Scan(context.Background())
 	if err != nil {
 		return err
 	}
@@ -160,7 +281,7 @@ func cmdCardCreate(boardPath string, args []string) error {
 		return fmt.Errorf("list %q not found", listName)
 	}
 
-	meta, filePath, _, err := daedalus.CreateCardOnDisk(boardPath, listName, title, "", "bottom", cards, state.MaxID)
+	meta, filePath, _, err := store.CreateCard(listName, title, "", "bottom", cards, state.MaxID)
 	if err != nil {
 		return err
 	}
@@ -173,7 +294,7 @@ func cmdCardCreate(boardPath string, args []string) error {
 	})
 }
 
-func cmdCardDelete(boardPath string, args []string) error {
+func cmdCardDelete(boardPath string, store daedalus.Store, args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("usage: card-delete <card-id>")
 	}
@@ -182,7 +303,7 @@ func cmdCardDelete(boardPath string, args []string) error {
 		return fmt.Errorf("invalid card ID %q: %w", args[0], err)
 	}
 
-	state, err := daedalus.ScanBoard(boardPath)
+	state, err := store.Scan(context.Background())
 	if err != nil {
 		return err
 	}
@@ -190,7 +311,10 @@ func cmdCardDelete(boardPath string, args []string) error {
 	for _, cards := range state.Lists {
 		for _, c := range cards {
 			if c.Metadata.ID == cardID {
-				return os.Remove(c.FilePath)
+				if err := daedalus.AutoSnapshot(boardPath, "pre-delete-card"); err != nil {
+					return fmt.Errorf("auto-snapshot before card delete: %w", err)
+				}
+				return store.DeleteCard(c)
 			}
 		}
 	}
@@ -198,7 +322,152 @@ func cmdCardDelete(boardPath string, args []string) error {
 	return fmt.Errorf("card with ID %d not found", cardID)
 }
 
-func cmdListCreate(boardPath string, args []string) error {
+// cmdReview dispatches the "review queue|grade" subcommands for the SM-2 spaced-repetition
+// scheduler (see pkg/daedalus/review.go), for running a study session without the desktop UI.
+func cmdReview(boardPath string, store daedalus.Store, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: review queue|grade <card-id> <quality>")
+	}
+
+	switch args[0] {
+	case "queue":
+		due, err := daedalus.ScanReviewQueue(boardPath)
+		if err != nil {
+			return err
+		}
+		return jsonOut(due)
+	case "grade":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: review grade <card-id> <quality>")
+		}
+		cardID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid card ID %q: %w", args[1], err)
+		}
+		quality, err := strconv.Atoi(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid quality %q: %w", args[2], err)
+		}
+
+		state, err := store.Scan(context.Background())
+		if err != nil {
+			return err
+		}
+		for _, cards := range state.Lists {
+			for _, c := range cards {
+				if c.Metadata.ID == cardID {
+					if err := daedalus.GradeReview(c.FilePath, quality); err != nil {
+						return err
+					}
+					return jsonOut(map[string]any{"id": cardID, "quality": quality})
+				}
+			}
+		}
+		return fmt.Errorf("card with ID %d not found", cardID)
+	default:
+		return fmt.Errorf("unknown review subcommand %q", args[0])
+	}
+}
+
+// cmdVerifyBoard dispatches the "verify-board snapshot|check" subcommands for the manifest-based
+// integrity check (see pkg/daedalus/boarddiff.go): "snapshot" records the board's current state,
+// "check" rescans and reports what's changed since.
+func cmdVerifyBoard(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: verify-board snapshot|check")
+	}
+
+	switch args[0] {
+	case "snapshot":
+		state, err := daedalus.ScanBoard(context.Background(), boardPath)
+		if err != nil {
+			return err
+		}
+		manifest := daedalus.SnapshotBoard(state)
+		if err := daedalus.SaveManifest(boardPath, manifest); err != nil {
+			return err
+		}
+		return jsonOut(map[string]any{"cards": len(manifest.Cards)})
+	case "check":
+		manifest, err := daedalus.LoadManifest(boardPath)
+		if err != nil {
+			return err
+		}
+		result, err := daedalus.CheckBoard(boardPath, manifest)
+		if err != nil {
+			return err
+		}
+		return jsonOut(result)
+	default:
+		return fmt.Errorf("unknown verify-board subcommand %q", args[0])
+	}
+}
+
+// cmdDiff compares the board at boardPath against another copy of it (e.g. the other side of a
+// sync, or a checked-out snapshot dir), reporting every added/removed/moved/reordered card and
+// per-field metadata change between them (see daedalus.CompareBoards).
+func cmdDiff(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: diff <path-to-other-board-copy>")
+	}
+
+	curr, err := daedalus.ScanBoard(context.Background(), boardPath)
+	if err != nil {
+		return err
+	}
+	other, err := daedalus.ScanBoard(context.Background(), args[0])
+	if err != nil {
+		return fmt.Errorf("scanning %s: %w", args[0], err)
+	}
+
+	return jsonOut(daedalus.CompareBoards(other, curr))
+}
+
+// cmdAttach dispatches the "attach add|gc" subcommands for the content-addressed attachment
+// store (see pkg/daedalus/attachments.go).
+func cmdAttach(boardPath string, store daedalus.Store, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: attach add <card-id> <file>|gc")
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: attach add <card-id> <file>")
+		}
+		cardID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid card ID %q: %w", args[1], err)
+		}
+
+		state, err := store.Scan(context.Background())
+		if err != nil {
+			return err
+		}
+		for _, cards := range state.Lists {
+			for _, c := range cards {
+				if c.Metadata.ID == cardID {
+					attachment, err := daedalus.AttachCardFile(c.FilePath, args[2])
+					if err != nil {
+						return err
+					}
+					return jsonOut(attachment)
+				}
+			}
+		}
+		return fmt.Errorf("card with ID %d not found", cardID)
+	case "gc":
+		removed, err := daedalus.GCAttachments(boardPath)
+		if err != nil {
+			return err
+		}
+		return jsonOut(map[string]any{"removed": removed})
+	default:
+		return fmt.Errorf("unknown attach subcommand %q", args[0])
+	}
+}
+
+func cmdListCreate(store daedalus.Store, args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("usage: list-create <name>")
 	}
@@ -208,7 +477,7 @@ func cmdListCreate(boardPath string, args []string) error {
 		return err
 	}
 
-	state, err := daedalus.ScanBoard(boardPath)
+	state, err := store.Scan(context.Background())
 	if err != nil {
 		return err
 	}
@@ -217,11 +486,11 @@ func cmdListCreate(boardPath string, args []string) error {
 		return fmt.Errorf("list %q already exists", name)
 	}
 	// Also check config entries for lists that exist but have no cards
-	if daedalus.FindListEntry(state.Config.Lists, name) >= 0 {
+	if daedalus.FindListEntry(context.Background(), state.Config.Lists, name) >= 0 {
 		return fmt.Errorf("list %q already exists", name)
 	}
 
-	if err := daedalus.CreateListOnDisk(boardPath, name, state.Config); err != nil {
+	if err := store.CreateList(name, state.Config); err != nil {
 		return err
 	}
 
@@ -230,61 +499,454 @@ func cmdListCreate(boardPath string, args []string) error {
 	})
 }
 
-func cmdListDelete(boardPath string, args []string) error {
+func cmdListDelete(boardPath string, store daedalus.Store, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: list-delete <name>")
+		return fmt.Errorf("usage: list-delete <name> [--permanent]")
 	}
 	name := args[0]
+	permanent := len(args) > 1 && args[1] == "--permanent"
 
 	if strings.Contains(name, "/") || strings.Contains(name, "\\") || strings.Contains(name, "..") {
 		return fmt.Errorf("invalid list name")
 	}
 
-	state, err := daedalus.ScanBoard(boardPath)
+	state, err := store.Scan(context.Background())
 	if err != nil {
 		return err
 	}
 
 	_, inLists := state.Lists[name]
-	inConfig := daedalus.FindListEntry(state.Config.Lists, name) >= 0
+	inConfig := daedalus.FindListEntry(context.Background(), state.Config.Lists, name) >= 0
 	if !inLists && !inConfig {
 		return fmt.Errorf("list %q not found", name)
 	}
 
-	return daedalus.DeleteListOnDisk(boardPath, name, state.Config)
+	if permanent {
+		if err := daedalus.AutoSnapshot(boardPath, "pre-delete-list"); err != nil {
+			return fmt.Errorf("auto-snapshot before list delete: %w", err)
+		}
+	}
+	return store.DeleteList(name, state.Config, permanent)
 }
 
-func cmdExportJSON(boardPath string, args []string) error {
+// cmdArchive dispatches the "archive list|unarchive|purge" subcommands for the soft-delete
+// list archive (see pkg/daedalus/listarchive.go).
+func cmdArchive(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: archive list|unarchive <dir>|purge <days>")
+	}
+
+	switch args[0] {
+	case "list":
+		state, err := daedalus.ScanBoard(context.Background(), boardPath)
+		if err != nil {
+			return err
+		}
+		return jsonOut(state.Config.Archived)
+	case "unarchive":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: archive unarchive <archived-dir>")
+		}
+		state, err := daedalus.ScanBoard(context.Background(), boardPath)
+		if err != nil {
+			return err
+		}
+		_, _, _, err = daedalus.UnarchiveListFs(afero.NewOsFs(), boardPath, args[1], state.Config)
+		return err
+	case "purge":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: archive purge <older-than-days>")
+		}
+		days, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid day count %q: %w", args[1], err)
+		}
+		state, err := daedalus.ScanBoard(context.Background(), boardPath)
+		if err != nil {
+			return err
+		}
+		freed, err := daedalus.PurgeArchivedFs(afero.NewOsFs(), boardPath, state.Config, time.Duration(days)*24*time.Hour)
+		if err != nil {
+			return err
+		}
+		return jsonOut(map[string]any{"bytesFreed": freed})
+	default:
+		return fmt.Errorf("unknown archive subcommand %q", args[0])
+	}
+}
+
+func cmdExportJSON(boardPath string, args []string, noProgress bool) error {
 	if len(args) < 1 {
 		return fmt.Errorf("usage: export-json <output-path>")
 	}
 	outputPath := args[0]
 
-	state, err := daedalus.ScanBoard(boardPath)
+	state, err := daedalus.ScanBoard(context.Background(), boardPath)
+	if err != nil {
+		return err
+	}
+
+	iconsDir := filepath.Join(boardPath, "_assets", "icons")
+	return daedalus.ExportJSONCtx(context.Background(), state, iconsDir, outputPath, newProgress(noProgress))
+}
+
+func cmdExportZip(boardPath string, args []string, noProgress bool) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: export-zip <output-path>")
+	}
+	outputPath := args[0]
+
+	state, err := daedalus.ScanBoard(context.Background(), boardPath)
 	if err != nil {
 		return err
 	}
 
 	iconsDir := filepath.Join(boardPath, "_assets", "icons")
-	board, err := daedalus.BuildExportBoard(state, iconsDir)
+	return daedalus.ExportZipCtx(context.Background(), boardPath, state, iconsDir, outputPath, newProgress(noProgress))
+}
+
+// cmdExportTar writes a tar archive of the board to outputPath, or to stdout if it is "-".
+func cmdExportTar(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: export-tar <output-path|->")
+	}
+	outputPath := args[0]
+
+	state, err := daedalus.ScanBoard(context.Background(), boardPath)
 	if err != nil {
 		return err
 	}
 
-	return daedalus.WriteExportJSON(board, outputPath)
+	if outputPath == "-" {
+		return daedalus.WriteExportTar(state, os.Stdout)
+	}
+
+	outFile, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("creating tar file: %w", err)
+	}
+	defer outFile.Close()
+
+	return daedalus.WriteExportTar(state, outFile)
 }
 
-func cmdExportZip(boardPath string, args []string) error {
+// cmdExportTarGz writes a gzip-compressed tar archive of the board to outputPath, via
+// WriteExportSink (the shared ExportSink walk export-zip also uses).
+func cmdExportTarGz(boardPath string, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: export-zip <output-path>")
+		return fmt.Errorf("usage: export-targz <output-path>")
+	}
+	outputPath := args[0]
+
+	state, err := daedalus.ScanBoard(context.Background(), boardPath)
+	if err != nil {
+		return err
+	}
+
+	iconsDir := filepath.Join(boardPath, "_assets", "icons")
+	return daedalus.WriteExportTarGz(boardPath, state, iconsDir, outputPath)
+}
+
+// cmdExportCAR writes a content-addressed export of the board to outputPath: every unique card
+// body and icon is stored once under blobs/<sha256 digest>, referenced from manifest.json (see
+// daedalus.ExportCAR). Re-exporting an unchanged board reproduces byte-identical blobs, and two
+// exports of overlapping boards share most of their blobs when their directories are merged.
+func cmdExportCAR(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: export-car <output-dir>")
 	}
 	outputPath := args[0]
 
-	state, err := daedalus.ScanBoard(boardPath)
+	state, err := daedalus.ScanBoard(context.Background(), boardPath)
 	if err != nil {
 		return err
 	}
 
 	iconsDir := filepath.Join(boardPath, "_assets", "icons")
-	return daedalus.WriteExportZip(boardPath, state, iconsDir, outputPath)
+	return daedalus.ExportCAR(state, iconsDir, outputPath)
+}
+
+// cmdVerifyCAR re-hashes every blob in a CAR export against its manifest and root digest,
+// reporting the first mismatch or missing file it finds.
+func cmdVerifyCAR(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: verify-car <export-dir>")
+	}
+	if err := daedalus.VerifyExport(args[0]); err != nil {
+		return err
+	}
+	return jsonOut(map[string]any{"verified": true})
+}
+
+// cmdImportCAR imports a CAR export produced by export-car into boardPath, with the same
+// --merge/--strategy reconciliation semantics as import-json/import-zip.
+func cmdImportCAR(boardPath string, args []string, merge bool, strategy string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: import-car <export-dir> [--merge] [--strategy replace|skip|renumber]")
+	}
+	if err := daedalus.AutoSnapshot(boardPath, "pre-import-car"); err != nil {
+		return fmt.Errorf("auto-snapshot before import: %w", err)
+	}
+	s := mergeBoolToStrategy(merge)
+	if strategy != "" {
+		var err error
+		s, err = parseMergeStrategy(strategy)
+		if err != nil {
+			return err
+		}
+	}
+	return daedalus.ImportCAR(args[0], boardPath, s)
+}
+
+// mergeBoolToStrategy maps the legacy --merge flag onto a daedalus.MergeStrategy, mirroring
+// daedalus.mergeBoolToStrategy for callers (like cmdImportCAR) that don't go through
+// ImportJSON/ImportZip's own bool-to-strategy conversion.
+func mergeBoolToStrategy(merge bool) daedalus.MergeStrategy {
+	if merge {
+		return daedalus.MergeRenumberIDs
+	}
+	return daedalus.MergeReplace
+}
+
+// cmdImportTar reads a tar archive produced by export-tar and unpacks it into boardPath,
+// reading from stdin if inputPath is "-".
+func cmdImportTar(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: import-tar <input-path|->")
+	}
+	inputPath := args[0]
+
+	if inputPath == "-" {
+		return daedalus.ImportTar(os.Stdin, boardPath)
+	}
+
+	inFile, err := os.Open(inputPath)
+	if err != nil {
+		return fmt.Errorf("opening tar file: %w", err)
+	}
+	defer inFile.Close()
+
+	return daedalus.ImportTar(inFile, boardPath)
+}
+
+// cmdSnapshot dispatches the "snapshot create|list|restore|prune" subcommands.
+func cmdSnapshot(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: snapshot create|list|restore|prune ...")
+	}
+
+	switch args[0] {
+	case "create":
+		label := ""
+		if len(args) > 1 {
+			label = args[1]
+		}
+		state, err := daedalus.ScanBoard(context.Background(), boardPath)
+		if err != nil {
+			return err
+		}
+		info, err := daedalus.CreateSnapshot(boardPath, state, filepath.Join(boardPath, "_assets", "icons"), label)
+		if err != nil {
+			if _, ok := err.(*daedalus.ExportError); !ok {
+				return err
+			}
+		}
+		if jsonErr := jsonOut(info); jsonErr != nil {
+			return jsonErr
+		}
+		return err
+	case "list":
+		snapshots, err := daedalus.ListSnapshots(boardPath)
+		if err != nil {
+			return err
+		}
+		return jsonOut(snapshots)
+	case "restore":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: snapshot restore <id>")
+		}
+		return daedalus.RestoreSnapshot(boardPath, args[1])
+	case "prune":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: snapshot prune <keep>")
+		}
+		keep, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid keep count %q: %w", args[1], err)
+		}
+		return daedalus.PruneSnapshots(boardPath, keep)
+	default:
+		return fmt.Errorf("unknown snapshot subcommand %q", args[0])
+	}
+}
+
+// cmdKey dispatches the "key add|list|remove" subcommands, which manage a board's keyring for
+// per-card signing and encryption (see pkg/daedalus/sign.go).
+func cmdKey(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: key add|list|remove ...")
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: key add <armored-key-file>")
+		}
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("reading key file: %w", err)
+		}
+		info, err := daedalus.AddKeyFs(afero.NewOsFs(), boardPath, data)
+		if err != nil {
+			return err
+		}
+		return jsonOut(info)
+	case "list":
+		keys, err := daedalus.ListKeysFs(afero.NewOsFs(), boardPath)
+		if err != nil {
+			return err
+		}
+		return jsonOut(keys)
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: key remove <key-id>")
+		}
+		return daedalus.RemoveKeyFs(afero.NewOsFs(), boardPath, args[1])
+	default:
+		return fmt.Errorf("unknown key subcommand %q", args[0])
+	}
+}
+
+// cmdServeWebDAV serves boardPath over WebDAV at addr until the process exits, so the board
+// can be mounted as a network drive and edited with any external editor. board.yaml must
+// already have a webdav: {username, password} section; serving without one is refused by
+// the handler itself.
+func cmdServeWebDAV(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: serve-webdav <addr>")
+	}
+	addr := args[0]
+
+	config, err := daedalus.LoadBoardConfig(boardPath)
+	if err != nil {
+		return err
+	}
+	if config.WebDAV == nil {
+		return fmt.Errorf("board.yaml has no webdav section; add webdav: {username, password} first")
+	}
+
+	fmt.Fprintf(os.Stderr, "serving board %q over WebDAV at %s\n", boardPath, addr)
+	return http.ListenAndServe(addr, webdav.NewHandler(boardPath, config))
+}
+
+// cmdTailWAL prints every card mutation appended to boardPath's write-ahead log (see
+// pkg/daedalus/wal.go), one JSON object per line, as it happens -- "tail -f" for the WAL, useful
+// for watching what a sync or another editor is doing to the board in real time. It runs until
+// the process is interrupted.
+func cmdTailWAL(boardPath string) error {
+	ch := make(chan daedalus.WALEvent, 32)
+	daedalus.TailWAL(context.Background(), boardPath, ch)
+
+	fmt.Fprintf(os.Stderr, "tailing WAL for board %q (ctrl-c to stop)\n", boardPath)
+	enc := json.NewEncoder(os.Stdout)
+	for event := range ch {
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("encoding WAL event: %w", err)
+		}
+	}
+	return nil
+}
+
+// cmdWatchBoard prints every external change daedalus.WatchBoard detects under boardPath -- a
+// card created, edited, or removed by another editor or synced in from elsewhere -- one JSON
+// object per line, until the process is interrupted.
+func cmdWatchBoard(boardPath string) error {
+	events, err := daedalus.WatchBoard(context.Background(), boardPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "watching board %q for external changes (ctrl-c to stop)\n", boardPath)
+	enc := json.NewEncoder(os.Stdout)
+	for event := range events {
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("encoding board event: %w", err)
+		}
+	}
+	return nil
+}
+
+// cmdImportJSON imports a JSON export produced by export-json into boardPath. With merge,
+// existing lists/cards at boardPath are reconciled rather than overwritten; strategy, if
+// non-empty, overrides merge entirely and is passed straight to MergeImport.
+func cmdImportJSON(boardPath string, args []string, merge bool, strategy string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: import-json <path> [--merge] [--strategy replace|skip|renumber]")
+	}
+	if err := daedalus.AutoSnapshot(boardPath, "pre-import-json"); err != nil {
+		return fmt.Errorf("auto-snapshot before import: %w", err)
+	}
+	if strategy != "" {
+		s, err := parseMergeStrategy(strategy)
+		if err != nil {
+			return err
+		}
+		return daedalus.MergeImport(args[0], boardPath, s)
+	}
+	return daedalus.ImportJSON(args[0], boardPath, merge)
+}
+
+// cmdImportZip imports a ZIP export produced by export-zip into boardPath. With merge,
+// existing lists/cards at boardPath are reconciled rather than overwritten; strategy, if
+// non-empty, overrides merge entirely and is passed straight to MergeImport.
+func cmdImportZip(boardPath string, args []string, merge bool, strategy string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: import-zip <path> [--merge] [--strategy replace|skip|renumber]")
+	}
+	if err := daedalus.AutoSnapshot(boardPath, "pre-import-zip"); err != nil {
+		return fmt.Errorf("auto-snapshot before import: %w", err)
+	}
+	if strategy != "" {
+		s, err := parseMergeStrategy(strategy)
+		if err != nil {
+			return err
+		}
+		return daedalus.MergeImport(args[0], boardPath, s)
+	}
+	return daedalus.ImportZip(args[0], boardPath, merge)
+}
+
+// parseMergeStrategy maps the --strategy flag's value to a daedalus.MergeStrategy.
+func parseMergeStrategy(s string) (daedalus.MergeStrategy, error) {
+	switch s {
+	case "replace":
+		return daedalus.MergeReplace, nil
+	case "skip":
+		return daedalus.MergeSkipExisting, nil
+	case "renumber":
+		return daedalus.MergeRenumberIDs, nil
+	default:
+		return 0, fmt.Errorf("unknown --strategy %q (expected replace, skip, or renumber)", s)
+	}
+}
+
+// cmdMigrateConfig dispatches the "migrate-config sharded|monolithic" subcommands, converting
+// board.yaml between a single monolithic file and per-list .list.yaml shards (see
+// pkg/daedalus/configshard.go). Each is a no-op if the board is already in the target layout.
+func cmdMigrateConfig(boardPath string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: migrate-config sharded|monolithic")
+	}
+	if err := daedalus.AutoSnapshot(boardPath, "pre-migrate-config"); err != nil {
+		return fmt.Errorf("auto-snapshot before config migration: %w", err)
+	}
+
+	switch args[0] {
+	case "sharded":
+		return daedalus.MigrateToSharded(boardPath)
+	case "monolithic":
+		return daedalus.MigrateToMonolithic(boardPath)
+	default:
+		return fmt.Errorf("unknown migrate-config target %q", args[0])
+	}
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"archive/zip"
+	"context"
 	"encoding/json"
 	"io"
 	"os"
@@ -9,6 +10,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/spf13/afero"
+
 	"daedalus/pkg/daedalus"
 )
 
@@ -34,7 +37,7 @@ func setupTestBoard(t *testing.T) string {
 		ListOrder: 1.0,
 	}
 	cardPath := filepath.Join(listDir, "1.md")
-	if err := daedalus.WriteCardFile(cardPath, meta, "# Test Card\n\nCard body.\n"); err != nil {
+	if err := daedalus.WriteCardFile(context.Background(), cardPath, meta, "# Test Card\n\nCard body.\n"); err != nil {
 		t.Fatalf("WriteCardFile: %v", err)
 	}
 
@@ -43,7 +46,7 @@ func setupTestBoard(t *testing.T) string {
 		Title: "Test Board",
 		Lists: []daedalus.ListEntry{{Dir: "open"}},
 	}
-	if err := daedalus.SaveBoardConfig(dir, config); err != nil {
+	if err := daedalus.SaveBoardConfig(context.Background(), dir, config); err != nil {
 		t.Fatalf("SaveBoardConfig: %v", err)
 	}
 
@@ -76,7 +79,7 @@ func captureStdout(t *testing.T, fn func()) string {
 func TestCmdBoard(t *testing.T) {
 	dir := setupTestBoard(t)
 	output := captureStdout(t, func() {
-		if err := cmdBoard(dir); err != nil {
+		if err := cmdBoard(daedalus.NewFsStore(dir)); err != nil {
 			t.Fatalf("cmdBoard: %v", err)
 		}
 	})
@@ -101,7 +104,7 @@ func TestCmdBoard(t *testing.T) {
 func TestCmdLists(t *testing.T) {
 	dir := setupTestBoard(t)
 	output := captureStdout(t, func() {
-		if err := cmdLists(dir); err != nil {
+		if err := cmdLists(daedalus.NewFsStore(dir)); err != nil {
 			t.Fatalf("cmdLists: %v", err)
 		}
 	})
@@ -125,7 +128,7 @@ func TestCmdLists(t *testing.T) {
 func TestCmdCards(t *testing.T) {
 	dir := setupTestBoard(t)
 	output := captureStdout(t, func() {
-		if err := cmdCards(dir, []string{"open"}); err != nil {
+		if err := cmdCards(dir, daedalus.NewFsStore(dir), []string{"open"}, ""); err != nil {
 			t.Fatalf("cmdCards: %v", err)
 		}
 	})
@@ -148,7 +151,7 @@ func TestCmdCards(t *testing.T) {
 
 func TestCmdCards_InvalidList(t *testing.T) {
 	dir := setupTestBoard(t)
-	err := cmdCards(dir, []string{"nonexistent"})
+	err := cmdCards(dir, daedalus.NewFsStore(dir), []string{"nonexistent"}, "")
 	if err == nil {
 		t.Fatal("expected error for invalid list")
 	}
@@ -160,7 +163,7 @@ func TestCmdCards_InvalidList(t *testing.T) {
 func TestCmdCardGet(t *testing.T) {
 	dir := setupTestBoard(t)
 	output := captureStdout(t, func() {
-		if err := cmdCardGet(dir, []string{"1"}); err != nil {
+		if err := cmdCardGet(dir, daedalus.NewFsStore(dir), []string{"1"}, ""); err != nil {
 			t.Fatalf("cmdCardGet: %v", err)
 		}
 	})
@@ -184,7 +187,7 @@ func TestCmdCardGet(t *testing.T) {
 
 func TestCmdCardGet_NotFound(t *testing.T) {
 	dir := setupTestBoard(t)
-	err := cmdCardGet(dir, []string{"999"})
+	err := cmdCardGet(dir, daedalus.NewFsStore(dir), []string{"999"}, "")
 	if err == nil {
 		t.Fatal("expected error for missing card")
 	}
@@ -196,7 +199,7 @@ func TestCmdCardGet_NotFound(t *testing.T) {
 func TestCmdCardCreate(t *testing.T) {
 	dir := setupTestBoard(t)
 	output := captureStdout(t, func() {
-		if err := cmdCardCreate(dir, []string{"open", "New Card"}); err != nil {
+		if err := cmdCardCreate(daedalus.NewFsStore(dir), []string{"open", "New Card"}); err != nil {
 			t.Fatalf("cmdCardCreate: %v", err)
 		}
 	})
@@ -223,6 +226,135 @@ func TestCmdCardCreate(t *testing.T) {
 	}
 }
 
+func TestCmdCards_SelectorFilter(t *testing.T) {
+	dir := setupTestBoard(t)
+	if err := cmdCardUpdate(daedalus.NewFsStore(dir), []string{"1"}, "", false, []string{"bug"}, nil); err != nil {
+		t.Fatalf("cmdCardUpdate: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := cmdCards(dir, daedalus.NewFsStore(dir), []string{"open"}, "bug=yes"); err != nil {
+			t.Fatalf("cmdCards: %v", err)
+		}
+	})
+	var result []map[string]any
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if len(result) != 0 {
+		t.Fatalf("expected no cards to match a selector the card's labels don't satisfy, got %d", len(result))
+	}
+
+	output = captureStdout(t, func() {
+		if err := cmdCards(dir, daedalus.NewFsStore(dir), []string{"open"}, "bug"); err != nil {
+			t.Fatalf("cmdCards: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 card, got %d", len(result))
+	}
+}
+
+func TestCmdCards_InvalidSelector(t *testing.T) {
+	dir := setupTestBoard(t)
+	err := cmdCards(dir, daedalus.NewFsStore(dir), []string{"open"}, "not a valid selector")
+	if err == nil {
+		t.Fatal("expected error for malformed selector")
+	}
+}
+
+func TestCmdCardMove(t *testing.T) {
+	dir := setupTestBoard(t)
+	if err := os.MkdirAll(filepath.Join(dir, "done"), 0755); err != nil {
+		t.Fatalf("mkdir done: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := cmdCardMove(daedalus.NewFsStore(dir), []string{"1", "done"}, ""); err != nil {
+			t.Fatalf("cmdCardMove: %v", err)
+		}
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if result["list"] != "done" {
+		t.Errorf("list: got %v, want %q", result["list"], "done")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "open", "1.md")); !os.IsNotExist(err) {
+		t.Error("card file should no longer exist in the old list")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "done", "1.md")); err != nil {
+		t.Errorf("card file should exist in the new list: %v", err)
+	}
+}
+
+func TestCmdCardMove_NotFound(t *testing.T) {
+	dir := setupTestBoard(t)
+	if err := os.MkdirAll(filepath.Join(dir, "done"), 0755); err != nil {
+		t.Fatalf("mkdir done: %v", err)
+	}
+	err := cmdCardMove(daedalus.NewFsStore(dir), []string{"999", "done"}, "")
+	if err == nil {
+		t.Fatal("expected error for missing card")
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		t.Errorf("error should mention 'not found', got: %v", err)
+	}
+}
+
+func TestCmdCardUpdate(t *testing.T) {
+	dir := setupTestBoard(t)
+	title := "Renamed Card"
+
+	output := captureStdout(t, func() {
+		if err := cmdCardUpdate(daedalus.NewFsStore(dir), []string{"1"}, title, true, []string{"bug", "urgent"}, nil); err != nil {
+			t.Fatalf("cmdCardUpdate: %v", err)
+		}
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if result["title"] != title {
+		t.Errorf("title: got %v, want %q", result["title"], title)
+	}
+	labels, _ := result["labels"].([]any)
+	if len(labels) != 2 {
+		t.Fatalf("expected 2 labels, got %v", labels)
+	}
+
+	if err := cmdCardUpdate(daedalus.NewFsStore(dir), []string{"1"}, "", false, nil, []string{"urgent"}); err != nil {
+		t.Fatalf("cmdCardUpdate removing label: %v", err)
+	}
+	state, err := daedalus.ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("ScanBoard: %v", err)
+	}
+	card := state.Lists["open"][0]
+	if card.Metadata.Title != title {
+		t.Errorf("title should be unchanged by the second update, got %q", card.Metadata.Title)
+	}
+	if len(card.Metadata.Labels) != 1 || card.Metadata.Labels[0] != "bug" {
+		t.Errorf("expected only the 'bug' label to remain, got %v", card.Metadata.Labels)
+	}
+}
+
+func TestCmdCardUpdate_NotFound(t *testing.T) {
+	dir := setupTestBoard(t)
+	err := cmdCardUpdate(daedalus.NewFsStore(dir), []string{"999"}, "", false, nil, nil)
+	if err == nil {
+		t.Fatal("expected error for missing card")
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		t.Errorf("error should mention 'not found', got: %v", err)
+	}
+}
+
 func TestCmdCardDelete(t *testing.T) {
 	dir := setupTestBoard(t)
 	cardPath := filepath.Join(dir, "open", "1.md")
@@ -232,7 +364,7 @@ func TestCmdCardDelete(t *testing.T) {
 		t.Fatalf("card file should exist before delete")
 	}
 
-	if err := cmdCardDelete(dir, []string{"1"}); err != nil {
+	if err := cmdCardDelete(dir, daedalus.NewFsStore(dir), []string{"1"}); err != nil {
 		t.Fatalf("cmdCardDelete: %v", err)
 	}
 
@@ -244,7 +376,7 @@ func TestCmdCardDelete(t *testing.T) {
 
 func TestCmdCardDelete_NotFound(t *testing.T) {
 	dir := setupTestBoard(t)
-	err := cmdCardDelete(dir, []string{"999"})
+	err := cmdCardDelete(dir, daedalus.NewFsStore(dir), []string{"999"})
 	if err == nil {
 		t.Fatal("expected error for missing card")
 	}
@@ -256,7 +388,7 @@ func TestCmdCardDelete_NotFound(t *testing.T) {
 func TestCmdListCreate(t *testing.T) {
 	dir := setupTestBoard(t)
 	output := captureStdout(t, func() {
-		if err := cmdListCreate(dir, []string{"done"}); err != nil {
+		if err := cmdListCreate(daedalus.NewFsStore(dir), []string{"done"}); err != nil {
 			t.Fatalf("cmdListCreate: %v", err)
 		}
 	})
@@ -284,14 +416,14 @@ func TestCmdListCreate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LoadBoardConfig: %v", err)
 	}
-	if daedalus.FindListEntry(config.Lists, "done") < 0 {
+	if daedalus.FindListEntry(context.Background(), config.Lists, "done") < 0 {
 		t.Error("list 'done' not found in board config")
 	}
 }
 
 func TestCmdListCreate_Duplicate(t *testing.T) {
 	dir := setupTestBoard(t)
-	err := cmdListCreate(dir, []string{"open"})
+	err := cmdListCreate(daedalus.NewFsStore(dir), []string{"open"})
 	if err == nil {
 		t.Fatal("expected error for duplicate list")
 	}
@@ -304,7 +436,7 @@ func TestCmdListDelete(t *testing.T) {
 	dir := setupTestBoard(t)
 	listPath := filepath.Join(dir, "open")
 
-	if err := cmdListDelete(dir, []string{"open"}); err != nil {
+	if err := cmdListDelete(dir, daedalus.NewFsStore(dir), []string{"open"}); err != nil {
 		t.Fatalf("cmdListDelete: %v", err)
 	}
 
@@ -318,7 +450,7 @@ func TestCmdListDelete(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LoadBoardConfig: %v", err)
 	}
-	if daedalus.FindListEntry(config.Lists, "open") >= 0 {
+	if daedalus.FindListEntry(context.Background(), config.Lists, "open") >= 0 {
 		t.Error("list 'open' should not be in board config after delete")
 	}
 }
@@ -335,11 +467,11 @@ func TestCmdListDelete_Empty(t *testing.T) {
 		t.Fatalf("LoadBoardConfig: %v", err)
 	}
 	config.Lists = append(config.Lists, daedalus.ListEntry{Dir: "empty"})
-	if err := daedalus.SaveBoardConfig(dir, config); err != nil {
+	if err := daedalus.SaveBoardConfig(context.Background(), dir, config); err != nil {
 		t.Fatalf("SaveBoardConfig: %v", err)
 	}
 
-	if err := cmdListDelete(dir, []string{"empty"}); err != nil {
+	if err := cmdListDelete(dir, daedalus.NewFsStore(dir), []string{"empty"}); err != nil {
 		t.Fatalf("cmdListDelete empty list: %v", err)
 	}
 
@@ -348,11 +480,68 @@ func TestCmdListDelete_Empty(t *testing.T) {
 	}
 }
 
+// setupMemTestBoard builds the same board as setupTestBoard, but entirely in memory via
+// daedalus.NewMemStoreFs, so handler tests can run with no real tempdir or disk I/O.
+func setupMemTestBoard(t *testing.T) (string, daedalus.Store) {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	store := daedalus.NewMemStoreFs(fs, root)
+
+	if err := afero.WriteFile(fs, filepath.Join(root, "board.yaml"), []byte("title: Test Board\nlists:\n  - dir: open\n"), 0644); err != nil {
+		t.Fatalf("writing board.yaml: %v", err)
+	}
+	meta := daedalus.CardMetadata{ID: 1, Title: "Test Card", ListOrder: 1.0}
+	if err := daedalus.WriteCardFileFs(context.Background(), fs, filepath.Join(root, "open", "1.md"), meta, "# Test Card\n\nCard body.\n"); err != nil {
+		t.Fatalf("writing card file: %v", err)
+	}
+
+	return root, store
+}
+
+// Card handlers should run entirely against an in-memory Store, with no real filesystem
+// involved, mirroring how CLI tests exercise board logic without a tempdir.
+func TestCmdCards_MemStore(t *testing.T) {
+	root, store := setupMemTestBoard(t)
+
+	output := captureStdout(t, func() {
+		if err := cmdCards(root, store, []string{"open"}, ""); err != nil {
+			t.Fatalf("cmdCards: %v", err)
+		}
+	})
+
+	var result []map[string]any
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if len(result) != 1 || result[0]["title"] != "Test Card" {
+		t.Errorf("expected 1 card titled %q, got %v", "Test Card", result)
+	}
+}
+
+// cmdCardCreate writing through a memStore should update the in-memory board without touching
+// the OS filesystem, and be visible to a subsequent Scan through the same Store.
+func TestCmdCardCreate_MemStore(t *testing.T) {
+	_, store := setupMemTestBoard(t)
+
+	if err := cmdCardCreate(store, []string{"open", "New Card"}); err != nil {
+		t.Fatalf("cmdCardCreate: %v", err)
+	}
+
+	state, err := store.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(state.Lists["open"]) != 2 {
+		t.Fatalf("expected 2 cards in open, got %d", len(state.Lists["open"]))
+	}
+}
+
 func TestCmdExportJSON(t *testing.T) {
 	dir := setupTestBoard(t)
 	outputPath := filepath.Join(t.TempDir(), "export.json")
 
-	if err := cmdExportJSON(dir, []string{outputPath}); err != nil {
+	if err := cmdExportJSON(dir, []string{outputPath}, true); err != nil {
 		t.Fatalf("cmdExportJSON: %v", err)
 	}
 
@@ -381,7 +570,7 @@ func TestCmdExportZip(t *testing.T) {
 	dir := setupTestBoard(t)
 	outputPath := filepath.Join(t.TempDir(), "export.zip")
 
-	if err := cmdExportZip(dir, []string{outputPath}); err != nil {
+	if err := cmdExportZip(dir, []string{outputPath}, true); err != nil {
 		t.Fatalf("cmdExportZip: %v", err)
 	}
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"daedalus/pkg/daedalus"
 )
 
 func usage() {
@@ -12,33 +14,158 @@ func usage() {
 Commands:
   board                  Show board summary (title, list/card counts)
   lists                  List all lists with card counts
-  cards <list>           List cards in a list
-  card-get <id>          Show full card details
+  cards <list> [--selector <expr>]    List cards in a list, optionally filtered by label selector
+  card-get <id> [--selector <expr>]   Show full card details (errors if it doesn't match --selector)
   card-create <list> <title>  Create a new card
   card-delete <id>       Delete a card by ID
+  card-move <id> <list> [--position top|bottom|<index>]  Move a card to another list
+  card-update <id> [--title ...] [--add-label ...] [--remove-label ...]  Edit a card's title/labels
   list-create <name>     Create a new list
-  list-delete <name>     Delete a list and its cards
+  list-delete <name> [--permanent]  Archive a list (or permanently delete with --permanent)
+  archive list               List archived lists
+  archive unarchive <dir>    Restore an archived list
+  archive purge <days>       Permanently delete archived lists older than <days>
   export-json <path>     Export board to JSON file
-  export-zip <path>      Export board to ZIP archive`)
+  export-zip <path>      Export board to ZIP archive
+  export-targz <path>    Export board to a gzip-compressed tar archive
+  export-tar <path|->    Export board to a tar archive, or stdout if path is "-"
+  export-car <dir>       Export board to a content-addressed directory (blobs/ + manifest.json)
+  verify-car <dir>       Re-hash a content-addressed export's blobs against its manifest
+  import-tar <path|->    Import a tar archive into the board, or stdin if path is "-"
+  import-json <path>     Import a JSON export into the board (--merge, or --strategy replace|skip|renumber)
+  import-zip <path>      Import a ZIP export into the board (--merge, or --strategy replace|skip|renumber)
+  import-car <dir>       Import a content-addressed export into the board (--merge, or --strategy replace|skip|renumber)
+  snapshot create [label]  Create a point-in-time zip snapshot under _snapshots/
+  snapshot list            List snapshots, newest first
+  snapshot restore <id>    Restore the board from a snapshot
+  snapshot prune <keep>    Keep only the <keep> most recent snapshots
+  serve-webdav <addr>      Serve the board over WebDAV (requires webdav: {username, password} in board.yaml)
+  serve --webdav <addr>    Alias for serve-webdav, e.g. "daedalus serve --webdav :8787"
+  key add <armored-file>   Add a public or private OpenPGP key to the board's keyring
+  key list                 List keys in the board's keyring
+  key remove <key-id>      Remove a key from the board's keyring
+  migrate-config sharded     Convert board.yaml to per-list .list.yaml shards + board.order
+  migrate-config monolithic  Convert a sharded board back to a single board.yaml
+  review queue               List cards due for review today, soonest first
+  review grade <id> <q>      Grade a card's review (quality 0-5) and reschedule it via SM-2
+  verify-board snapshot      Record a content-digest manifest of the board's current state
+  verify-board check         Rescan and report what's changed since the last snapshot
+  diff <other-board-path>    Compare this board against another copy, card by card
+  tail-wal                   Stream card mutations from the WAL as they happen, until interrupted
+  watch-board                Stream external card/list changes as they happen, until interrupted
+  attach add <id> <file>     Attach a file to a card, storing it in the content-addressed blob store
+  attach gc                  Remove attachment blobs no longer referenced by any card
+
+Flags:
+  --board <path>          Path to the board directory (required)
+  --merge                 Reconcile with existing lists/cards for import-json/import-zip
+  --strategy <s>          replace|skip|renumber; overrides --merge for import-json/import-zip
+  --no-progress           Disable the terminal progress bar for export-json/export-zip
+  --selector <expr>       Kubernetes-style label selector for cards/card-get, e.g. "group=foo,priority!=low"
+  --position <p>          top|bottom|<index>; insertion position for card-move (default bottom)
+  --title <title>         New title for card-update
+  --add-label <label>     Label to add for card-update (repeatable)
+  --remove-label <label>  Label to remove for card-update (repeatable)
+  --webdav <addr>         Address to bind for the serve command, e.g. ":8787"`)
 }
 
 func main() {
 	args := os.Args[1:]
 
-	// Parse --board flag
+	// Parse --board, --merge, --no-progress, and the card-move/card-update/selector flags
 	boardPath := ""
+	merge := false
+	strategy := ""
+	noProgress := false
+	selector := ""
+	webdavAddr := ""
+	position := ""
+	title := ""
+	titleSet := false
+	var addLabels []string
+	var removeLabels []string
 	var rest []string
 	for i := 0; i < len(args); i++ {
-		if args[i] == "--board" {
+		switch {
+		case args[i] == "--board":
 			if i+1 >= len(args) {
 				fmt.Fprintln(os.Stderr, "Error: --board requires a path argument")
 				os.Exit(1)
 			}
 			boardPath = args[i+1]
 			i++ // skip value
-		} else if strings.HasPrefix(args[i], "--board=") {
+		case strings.HasPrefix(args[i], "--board="):
 			boardPath = strings.TrimPrefix(args[i], "--board=")
-		} else {
+		case args[i] == "--merge":
+			merge = true
+		case args[i] == "--strategy":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --strategy requires a value")
+				os.Exit(1)
+			}
+			strategy = args[i+1]
+			i++ // skip value
+		case strings.HasPrefix(args[i], "--strategy="):
+			strategy = strings.TrimPrefix(args[i], "--strategy=")
+		case args[i] == "--no-progress":
+			noProgress = true
+		case args[i] == "--selector":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --selector requires a value")
+				os.Exit(1)
+			}
+			selector = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--selector="):
+			selector = strings.TrimPrefix(args[i], "--selector=")
+		case args[i] == "--webdav":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --webdav requires an address argument")
+				os.Exit(1)
+			}
+			webdavAddr = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--webdav="):
+			webdavAddr = strings.TrimPrefix(args[i], "--webdav=")
+		case args[i] == "--position":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --position requires a value")
+				os.Exit(1)
+			}
+			position = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--position="):
+			position = strings.TrimPrefix(args[i], "--position=")
+		case args[i] == "--title":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --title requires a value")
+				os.Exit(1)
+			}
+			title = args[i+1]
+			titleSet = true
+			i++
+		case strings.HasPrefix(args[i], "--title="):
+			title = strings.TrimPrefix(args[i], "--title=")
+			titleSet = true
+		case args[i] == "--add-label":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --add-label requires a value")
+				os.Exit(1)
+			}
+			addLabels = append(addLabels, args[i+1])
+			i++
+		case strings.HasPrefix(args[i], "--add-label="):
+			addLabels = append(addLabels, strings.TrimPrefix(args[i], "--add-label="))
+		case args[i] == "--remove-label":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --remove-label requires a value")
+				os.Exit(1)
+			}
+			removeLabels = append(removeLabels, args[i+1])
+			i++
+		case strings.HasPrefix(args[i], "--remove-label="):
+			removeLabels = append(removeLabels, strings.TrimPrefix(args[i], "--remove-label="))
+		default:
 			rest = append(rest, args[i])
 		}
 	}
@@ -58,28 +185,81 @@ func main() {
 	command := rest[0]
 	cmdArgs := rest[1:]
 
+	// A single Store, constructed once, drives every board/list/card handler below -- tests
+	// construct the same handlers against daedalus.NewMemStore instead of NewFsStore to run with
+	// no real I/O.
+	store := daedalus.NewFsStore(boardPath)
+
 	var err error
 	switch command {
 	case "board":
-		err = cmdBoard(boardPath)
+		err = cmdBoard(store)
 	case "lists":
-		err = cmdLists(boardPath)
+		err = cmdLists(store)
 	case "list-create":
-		err = cmdListCreate(boardPath, cmdArgs)
+		err = cmdListCreate(store, cmdArgs)
 	case "list-delete":
-		err = cmdListDelete(boardPath, cmdArgs)
+		err = cmdListDelete(boardPath, store, cmdArgs)
+	case "archive":
+		err = cmdArchive(boardPath, cmdArgs)
 	case "cards":
-		err = cmdCards(boardPath, cmdArgs)
+		err = cmdCards(boardPath, store, cmdArgs, selector)
 	case "card-create":
-		err = cmdCardCreate(boardPath, cmdArgs)
+		err = cmdCardCreate(store, cmdArgs)
 	case "card-delete":
-		err = cmdCardDelete(boardPath, cmdArgs)
+		err = cmdCardDelete(boardPath, store, cmdArgs)
 	case "card-get":
-		err = cmdCardGet(boardPath, cmdArgs)
+		err = cmdCardGet(boardPath, store, cmdArgs, selector)
+	case "card-move":
+		err = cmdCardMove(store, cmdArgs, position)
+	case "card-update":
+		err = cmdCardUpdate(store, cmdArgs, title, titleSet, addLabels, removeLabels)
 	case "export-json":
-		err = cmdExportJSON(boardPath, cmdArgs)
+		err = cmdExportJSON(boardPath, cmdArgs, noProgress)
 	case "export-zip":
-		err = cmdExportZip(boardPath, cmdArgs)
+		err = cmdExportZip(boardPath, cmdArgs, noProgress)
+	case "export-targz":
+		err = cmdExportTarGz(boardPath, cmdArgs)
+	case "export-tar":
+		err = cmdExportTar(boardPath, cmdArgs)
+	case "export-car":
+		err = cmdExportCAR(boardPath, cmdArgs)
+	case "verify-car":
+		err = cmdVerifyCAR(cmdArgs)
+	case "import-tar":
+		err = cmdImportTar(boardPath, cmdArgs)
+	case "import-json":
+		err = cmdImportJSON(boardPath, cmdArgs, merge, strategy)
+	case "import-zip":
+		err = cmdImportZip(boardPath, cmdArgs, merge, strategy)
+	case "import-car":
+		err = cmdImportCAR(boardPath, cmdArgs, merge, strategy)
+	case "snapshot":
+		err = cmdSnapshot(boardPath, cmdArgs)
+	case "serve-webdav":
+		err = cmdServeWebDAV(boardPath, cmdArgs)
+	case "serve":
+		if webdavAddr == "" {
+			err = fmt.Errorf("usage: serve --webdav <addr>")
+		} else {
+			err = cmdServeWebDAV(boardPath, []string{webdavAddr})
+		}
+	case "key":
+		err = cmdKey(boardPath, cmdArgs)
+	case "migrate-config":
+		err = cmdMigrateConfig(boardPath, cmdArgs)
+	case "review":
+		err = cmdReview(boardPath, store, cmdArgs)
+	case "verify-board":
+		err = cmdVerifyBoard(boardPath, cmdArgs)
+	case "diff":
+		err = cmdDiff(boardPath, cmdArgs)
+	case "tail-wal":
+		err = cmdTailWAL(boardPath)
+	case "watch-board":
+		err = cmdWatchBoard(boardPath)
+	case "attach":
+		err = cmdAttach(boardPath, store, cmdArgs)
 	default:
 		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n", command)
 		usage()
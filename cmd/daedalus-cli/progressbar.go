@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+
+	"github.com/cheggaaa/pb/v3"
+	"golang.org/x/term"
+
+	"daedalus/pkg/daedalus"
+)
+
+// cliProgress implements daedalus.Progress by driving a cheggaaa/pb terminal progress bar
+// with a speed and ETA indicator.
+type cliProgress struct {
+	bar *pb.ProgressBar
+}
+
+func (p *cliProgress) Start(total int, op string) {
+	tmpl := `{{string . "op"}} {{bar . }} {{percent . }} ({{counters . }}, {{speed . "%s items/s"}}, {{etime . }} / {{rtime . "ETA %s"}})`
+	p.bar = pb.ProgressBarTemplate(tmpl).Start(total)
+	p.bar.Set("op", op)
+}
+
+func (p *cliProgress) Advance(n int, detail string) {
+	p.bar.Add(n)
+}
+
+func (p *cliProgress) Done(err error) {
+	p.bar.Finish()
+}
+
+// isTerminalStdout reports whether stdout is an interactive terminal, i.e. a progress bar
+// drawn there will render sensibly rather than flooding a log file or pipe with control codes.
+func isTerminalStdout() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// newProgress returns the progress reporter a command should use: a terminal progress bar,
+// unless noProgress was requested or stdout isn't an interactive terminal, in which case it
+// falls back to daedalus.NoopProgress.
+func newProgress(noProgress bool) daedalus.Progress {
+	if noProgress || !isTerminalStdout() {
+		return daedalus.NoopProgress{}
+	}
+	return &cliProgress{}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+
+	"daedalus/pkg/daedalus"
+)
+
+// writeArmoredKey generates a test OpenPGP entity, armors it (as a private key export, so it
+// can both sign and be added as a private key to a keyring), and writes it to a file, returning
+// the path and the key's short ID.
+func writeArmoredKey(t *testing.T, dir, name string) (path, keyID string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, "PGP PRIVATE KEY BLOCK", nil)
+	if err != nil {
+		t.Fatalf("armor encode: %v", err)
+	}
+	if err := entity.SerializePrivate(w, nil); err != nil {
+		t.Fatalf("serializing private key: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("armor close: %v", err)
+	}
+
+	path = filepath.Join(dir, name+".asc")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("writing key file: %v", err)
+	}
+	return path, entity.PrimaryKey.KeyIdShortString()
+}
+
+// A card written with sign: true and a keyring holding a private key should report a "valid"
+// signature via card-get.
+func TestCmdKeyAddAndSignedCardRoundTrip(t *testing.T) {
+	dir := setupTestBoard(t)
+	keyPath, _ := writeArmoredKey(t, dir, "alice")
+
+	if err := cmdKey(dir, []string{"add", keyPath}); err != nil {
+		t.Fatalf("key add: %v", err)
+	}
+
+	config, err := daedalus.LoadBoardConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadBoardConfig: %v", err)
+	}
+	sign := true
+	config.Sign = &sign
+	if err := daedalus.SaveBoardConfig(context.Background(), dir, config); err != nil {
+		t.Fatalf("SaveBoardConfig: %v", err)
+	}
+
+	// Re-save the existing card so it picks up the sidecar signature.
+	cardPath := filepath.Join(dir, "open", "1.md")
+	meta := daedalus.CardMetadata{ID: 1, Title: "Test Card", ListOrder: 1.0}
+	if err := daedalus.WriteCardFile(context.Background(), cardPath, meta, "# Test Card\n\nCard body.\n"); err != nil {
+		t.Fatalf("WriteCardFile: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := cmdCardGet(dir, daedalus.NewFsStore(dir), []string{"1"}, ""); err != nil {
+			t.Fatalf("cmdCardGet: %v", err)
+		}
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if result["signature"] != "valid" {
+		t.Errorf("signature: got %v, want %q", result["signature"], "valid")
+	}
+}
+
+// Tampering with a signed card's content after the fact should flip its reported signature
+// status from "valid" to "invalid".
+func TestCmdCardGet_TamperedSignatureReportsInvalid(t *testing.T) {
+	dir := setupTestBoard(t)
+	keyPath, _ := writeArmoredKey(t, dir, "alice")
+	if err := cmdKey(dir, []string{"add", keyPath}); err != nil {
+		t.Fatalf("key add: %v", err)
+	}
+
+	config, err := daedalus.LoadBoardConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadBoardConfig: %v", err)
+	}
+	sign := true
+	config.Sign = &sign
+	if err := daedalus.SaveBoardConfig(context.Background(), dir, config); err != nil {
+		t.Fatalf("SaveBoardConfig: %v", err)
+	}
+
+	cardPath := filepath.Join(dir, "open", "1.md")
+	meta := daedalus.CardMetadata{ID: 1, Title: "Test Card", ListOrder: 1.0}
+	if err := daedalus.WriteCardFile(context.Background(), cardPath, meta, "# Test Card\n\nCard body.\n"); err != nil {
+		t.Fatalf("WriteCardFile: %v", err)
+	}
+
+	raw, err := os.ReadFile(cardPath)
+	if err != nil {
+		t.Fatalf("reading card file: %v", err)
+	}
+	if err := os.WriteFile(cardPath, append(raw, []byte("tampered\n")...), 0644); err != nil {
+		t.Fatalf("tampering card file: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := cmdCardGet(dir, daedalus.NewFsStore(dir), []string{"1"}, ""); err != nil {
+			t.Fatalf("cmdCardGet: %v", err)
+		}
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if result["signature"] != "invalid" {
+		t.Errorf("signature: got %v, want %q", result["signature"], "invalid")
+	}
+}
+
+// key list should report an added key, and key remove should make it disappear.
+func TestCmdKeyListAndRemove(t *testing.T) {
+	dir := setupTestBoard(t)
+	keyPath, keyID := writeArmoredKey(t, dir, "alice")
+
+	if err := cmdKey(dir, []string{"add", keyPath}); err != nil {
+		t.Fatalf("key add: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := cmdKey(dir, []string{"list"}); err != nil {
+			t.Fatalf("key list: %v", err)
+		}
+	})
+	var keys []map[string]any
+	if err := json.Unmarshal([]byte(output), &keys); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if len(keys) != 1 || keys[0]["id"] != keyID {
+		t.Fatalf("expected one key with id %q, got %v", keyID, keys)
+	}
+
+	if err := cmdKey(dir, []string{"remove", keyID}); err != nil {
+		t.Fatalf("key remove: %v", err)
+	}
+
+	outputAfter := captureStdout(t, func() {
+		if err := cmdKey(dir, []string{"list"}); err != nil {
+			t.Fatalf("key list: %v", err)
+		}
+	})
+	var keysAfter []map[string]any
+	if err := json.Unmarshal([]byte(outputAfter), &keysAfter); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, outputAfter)
+	}
+	if len(keysAfter) != 0 {
+		t.Fatalf("expected no keys after remove, got %v", keysAfter)
+	}
+}
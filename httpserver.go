@@ -0,0 +1,435 @@
+package main
+
+import (
+	"context"
+	"daedalus/pkg/daedalus"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// apiRoute describes one endpoint of the headless HTTP/JSON API added by `daedalus serve`.
+// The same table drives both route registration (newAPIMux) and the served OpenAPI schema
+// (openAPISpec), so the two can't drift apart.
+type apiRoute struct {
+	Method  string
+	Path    string
+	Summary string
+	Scope   string // "read" or "write"; required bearer-token scope, checked against board.yaml's Tokens
+	Handle  func(svc *Service, w http.ResponseWriter, r *http.Request)
+}
+
+var apiRoutes = []apiRoute{
+	{"POST", "/api/board/load", "Load a board from a path on disk", "write", handleLoadBoard},
+	{"GET", "/api/board", "Get the currently loaded board, optionally filtered by ?selector=<label selector>", "read", handleGetBoard},
+	{"POST", "/api/cards", "Create a card in a list", "write", handleCreateCard},
+	{"PUT", "/api/cards", "Save a card's metadata and body", "write", handleSaveCard},
+	{"DELETE", "/api/cards", "Delete a card", "write", handleDeleteCard},
+	{"POST", "/api/cards/move", "Move a card to a list, or reorder it within one", "write", handleMoveCard},
+	{"POST", "/api/labels/rename", "Rename a label across every card", "write", handleRenameLabel},
+	{"POST", "/api/lists/order", "Reorder the board's lists", "write", handleSaveListOrder},
+	{"POST", "/api/cards/attachments", "Attach a file already on disk to a card", "write", handleAttachCardFile},
+	{"GET", "/api/attachments/", "Fetch an attachment blob by its SHA-256 digest, with Range support", "read", handleAttachment},
+}
+
+// runServe implements the `daedalus serve` subcommand: it loads a board and exposes it over a
+// headless HTTP/JSON API instead of the Wails desktop UI, so the board can run on a home server
+// and be driven by scripts, mobile clients, or a browser.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	boardPath := fs.String("board", defaultBoardPath, "path to the board directory")
+	addr := fs.String("addr", ":8090", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	app := NewApp()
+	app.startup(context.Background())
+	if app.LoadBoard(*boardPath) == nil {
+		return fmt.Errorf("failed to load board at %s", *boardPath)
+	}
+	svc := newService(app)
+
+	server := &http.Server{Addr: *addr, Handler: withAccessLog(newAPIMux(svc))}
+	slog.Info("serving board over http", "addr", *addr, "board", *boardPath)
+	return server.ListenAndServe()
+}
+
+// newAPIMux builds the HTTP handler for apiRoutes plus the /openapi.json schema, gating each
+// route on authorize per its declared Scope.
+func newAPIMux(svc *Service) http.Handler {
+	mux := http.NewServeMux()
+
+	byPath := make(map[string][]apiRoute)
+	for _, route := range apiRoutes {
+		byPath[route.Path] = append(byPath[route.Path], route)
+	}
+	for path, routes := range byPath {
+		routes := routes
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			for _, route := range routes {
+				if route.Method != r.Method {
+					continue
+				}
+				if route.Scope != "" && !authorize(svc, r, route.Scope) {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="daedalus"`)
+					writeJSONError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+					return
+				}
+				route.Handle(svc, w, r)
+				return
+			}
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		})
+	}
+
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, openAPISpec())
+	})
+	return mux
+}
+
+// authorize reports whether r carries a bearer token present in the board's Tokens with a scope
+// covering required ("write" tokens cover "read" endpoints too). A board with no Tokens
+// configured rejects every request -- the same "misconfigured, not open" default WebDAVConfig
+// uses in pkg/daedalus/webdav.
+func authorize(svc *Service, r *http.Request, required string) bool {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	token := strings.TrimPrefix(header, prefix)
+	if token == "" {
+		return false
+	}
+
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	board, err := svc.requireBoard()
+	if err != nil {
+		return false
+	}
+	for _, entry := range board.Config.Tokens {
+		if entry.Token != token {
+			continue
+		}
+		scope := entry.Scope
+		if scope == "" {
+			scope = "read"
+		}
+		return scope == "write" || scope == required
+	}
+	return false
+}
+
+// statusRecorder wraps a ResponseWriter to record the status code and byte count written, for
+// withAccessLog's structured slog entry.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// withAccessLog logs one structured slog entry per request: method, path, status, response
+// size, and duration.
+func withAccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		slog.Info("http request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"bytes", rec.bytes,
+			"duration", time.Since(start),
+		)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to encode json response", "error", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func handleLoadBoard(svc *Service, w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Path string `json:"path"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	svc.mu.Lock()
+	done := make(chan *BoardResponse, 1)
+	go func() {
+		defer svc.mu.Unlock()
+		done <- svc.LoadBoard(req.Path)
+	}()
+
+	// A client disconnect only aborts the response here -- LoadBoard has no context-aware
+	// variant, so the scan already in flight keeps running and still swaps in a.board once it
+	// finishes, under the lock the goroutine above is holding.
+	select {
+	case <-r.Context().Done():
+		writeJSONError(w, http.StatusRequestTimeout, "client disconnected before board scan completed")
+	case resp := <-done:
+		if resp == nil {
+			writeJSONError(w, http.StatusInternalServerError, "failed to load board")
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// handleGetBoard returns the loaded board, optionally filtered by a ?selector= query parameter
+// using the same Kubernetes-style label selector syntax as the CLI's --selector flag (see
+// daedalus.ParseLabelSelector). Only cards matching the selector are included; lists themselves
+// are never dropped, even if every card in them is filtered out.
+func handleGetBoard(svc *Service, w http.ResponseWriter, r *http.Request) {
+	selector, err := daedalus.ParseLabelSelector(r.URL.Query().Get("selector"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+
+	board, err := svc.requireBoard()
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, &BoardResponse{
+		Lists:     filterListsBySelector(board.Lists, selector),
+		Config:    board.Config,
+		BoardPath: board.RootPath,
+	})
+}
+
+// filterListsBySelector returns a copy of lists with every card that doesn't match selector
+// removed. A nil/empty selector (no ?selector= given) returns lists unchanged.
+func filterListsBySelector(lists map[string][]daedalus.KanbanCard, selector *daedalus.LabelSelector) map[string][]daedalus.KanbanCard {
+	filtered := make(map[string][]daedalus.KanbanCard, len(lists))
+	for listDir, cards := range lists {
+		kept := make([]daedalus.KanbanCard, 0, len(cards))
+		for _, c := range cards {
+			if selector.Matches(c.Metadata.Labels) {
+				kept = append(kept, c)
+			}
+		}
+		filtered[listDir] = kept
+	}
+	return filtered
+}
+
+func handleCreateCard(svc *Service, w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ListDir  string `json:"listDir"`
+		Title    string `json:"title"`
+		Body     string `json:"body"`
+		Position string `json:"position"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	card, err := svc.CreateCard(req.ListDir, req.Title, req.Body, req.Position)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, card)
+}
+
+func handleSaveCard(svc *Service, w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FilePath string                `json:"filePath"`
+		Metadata daedalus.CardMetadata `json:"metadata"`
+		Body     string                `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	card, err := svc.SaveCard(req.FilePath, req.Metadata, req.Body)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, card)
+}
+
+func handleDeleteCard(svc *Service, w http.ResponseWriter, r *http.Request) {
+	filePath := r.URL.Query().Get("filePath")
+	if filePath == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing filePath query parameter")
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	if err := svc.DeleteCard(filePath); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleMoveCard(svc *Service, w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FilePath   string  `json:"filePath"`
+		TargetList string  `json:"targetList"`
+		ListOrder  float64 `json:"listOrder"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	card, err := svc.MoveCard(req.FilePath, req.TargetList, req.ListOrder)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, card)
+}
+
+func handleRenameLabel(svc *Service, w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Old string `json:"old"`
+		New string `json:"new"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	if err := svc.RenameLabel(req.Old, req.New); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleAttachCardFile(svc *Service, w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FilePath string `json:"filePath"`
+		SrcPath  string `json:"srcPath"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	attachment, err := svc.AttachCardFile(req.FilePath, req.SrcPath)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, attachment)
+}
+
+// handleAttachment serves an attachment blob by the SHA-256 digest in the request path, e.g.
+// GET /api/attachments/<sha256>, via daedalus.NewAttachmentHandler so Range requests work the
+// same as they would reading the blob directly off disk.
+func handleAttachment(svc *Service, w http.ResponseWriter, r *http.Request) {
+	svc.mu.RLock()
+	board, err := svc.requireBoard()
+	svc.mu.RUnlock()
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	daedalus.NewAttachmentHandler(board.RootPath).ServeHTTP(w, r)
+}
+
+func handleSaveListOrder(svc *Service, w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Order []string `json:"order"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	if err := svc.SaveListOrder(req.Order); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// openAPISpec builds an OpenAPI 3.0 document from apiRoutes, so the served schema can never
+// drift from the routes newAPIMux actually registers.
+func openAPISpec() map[string]any {
+	paths := map[string]any{}
+	for _, route := range apiRoutes {
+		spec := map[string]any{
+			"summary":   route.Summary,
+			"responses": map[string]any{"200": map[string]any{"description": "OK"}},
+		}
+		if route.Scope != "" {
+			spec["security"] = []map[string][]string{{"bearerAuth": {}}}
+		}
+		methods, ok := paths[route.Path].(map[string]any)
+		if !ok {
+			methods = map[string]any{}
+			paths[route.Path] = methods
+		}
+		methods[strings.ToLower(route.Method)] = spec
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]any{"title": "Daedalus Board API", "version": "1"},
+		"paths":   paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
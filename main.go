@@ -25,6 +25,16 @@ func main() {
 	}
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
 
+	// `daedalus serve [--board path] [--addr addr]` runs headless, exposing the board over an
+	// HTTP/JSON API instead of opening the Wails desktop UI.
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := runServe(os.Args[2:]); err != nil {
+			slog.Error("serve failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	app := NewApp()
 	err := wails.Run(&options.App{
 		Title: "Daedalus",
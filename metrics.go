@@ -0,0 +1,9 @@
+package main
+
+// readProcessRSS returns the process's resident set size in megabytes.
+// readProcessCPUSeconds returns total CPU time (user + system) consumed by
+// the process so far, in seconds. Both are implemented per-platform in
+// metrics_linux.go, metrics_darwin.go, and metrics_windows.go, with
+// metrics_other.go as a zero-value fallback for anything else. Returning
+// seconds rather than raw ticks lets GetMetrics compute a CPU percentage
+// without knowing the platform's tick rate.
@@ -0,0 +1,37 @@
+//go:build darwin
+
+package main
+
+/*
+#include <libproc.h>
+#include <unistd.h>
+*/
+import "C"
+import "unsafe"
+
+// taskInfo fetches the current process's task_info via libproc, or ok=false on failure.
+func taskInfo() (info C.struct_proc_taskinfo, ok bool) {
+	pid := C.getpid()
+	n := C.proc_pidinfo(pid, C.PROC_PIDTASKINFO, 0, unsafe.Pointer(&info), C.int(C.sizeof_struct_proc_taskinfo))
+	return info, n > 0
+}
+
+// readProcessRSS returns the resident set size of the current process in
+// megabytes, read via libproc's proc_pidinfo(PROC_PIDTASKINFO).
+func readProcessRSS() float64 {
+	info, ok := taskInfo()
+	if !ok {
+		return 0
+	}
+	return float64(info.pti_resident_size) / 1024 / 1024
+}
+
+// readProcessCPUSeconds returns total user+system CPU time consumed by the
+// current process, in seconds, via libproc's proc_pidinfo(PROC_PIDTASKINFO).
+func readProcessCPUSeconds() float64 {
+	info, ok := taskInfo()
+	if !ok {
+		return 0
+	}
+	return float64(info.pti_total_user+info.pti_total_system) / 1e9
+}
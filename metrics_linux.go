@@ -9,6 +9,9 @@ import (
 	"strings"
 )
 
+// clockTicksPerSec is USER_HZ, the unit /proc/self/stat reports CPU time in.
+const clockTicksPerSec = 100
+
 // readProcessRSS reads the resident set size from /proc/self/statm in megabytes.
 func readProcessRSS() float64 {
 	data, err := os.ReadFile("/proc/self/statm")
@@ -22,8 +25,9 @@ func readProcessRSS() float64 {
 	return float64(resident*int64(os.Getpagesize())) / 1024 / 1024
 }
 
-// readProcessCPUTicks reads utime + stime from /proc/self/stat in clock ticks.
-func readProcessCPUTicks() int64 {
+// readProcessCPUSeconds reads utime + stime from /proc/self/stat and converts
+// them from clock ticks to seconds.
+func readProcessCPUSeconds() float64 {
 	data, err := os.ReadFile("/proc/self/stat")
 	if err != nil {
 		return 0
@@ -42,5 +46,5 @@ func readProcessCPUTicks() int64 {
 	}
 	utime, _ := strconv.ParseInt(fields[11], 10, 64)
 	stime, _ := strconv.ParseInt(fields[12], 10, 64)
-	return utime + stime
+	return float64(utime+stime) / clockTicksPerSec
 }
@@ -1,9 +1,9 @@
-//go:build !linux
+//go:build !linux && !darwin && !windows
 
 package main
 
-// readProcessRSS is a no-op on non-Linux platforms where /proc is unavailable.
+// readProcessRSS is a no-op on platforms with no process-metrics backend.
 func readProcessRSS() float64 { return 0 }
 
-// readProcessCPUTicks is a no-op on non-Linux platforms where /proc is unavailable.
-func readProcessCPUTicks() int64 { return 0 }
+// readProcessCPUSeconds is a no-op on platforms with no process-metrics backend.
+func readProcessCPUSeconds() float64 { return 0 }
@@ -0,0 +1,32 @@
+//go:build windows
+
+package main
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// readProcessRSS returns the resident set size (working set) of the current
+// process in megabytes, via GetProcessMemoryInfo.
+func readProcessRSS() float64 {
+	var counters windows.PROCESS_MEMORY_COUNTERS
+	h := windows.CurrentProcess()
+	if err := windows.GetProcessMemoryInfo(h, &counters); err != nil {
+		return 0
+	}
+	return float64(counters.WorkingSetSize) / 1024 / 1024
+}
+
+// readProcessCPUSeconds returns total user+kernel CPU time consumed by the
+// current process, in seconds, via GetProcessTimes.
+func readProcessCPUSeconds() float64 {
+	h := windows.CurrentProcess()
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return 0
+	}
+	// Filetime ticks are 100-nanosecond intervals.
+	kernelTicks := int64(kernel.HighDateTime)<<32 | int64(kernel.LowDateTime)
+	userTicks := int64(user.HighDateTime)<<32 | int64(user.LowDateTime)
+	return float64(kernelTicks+userTicks) / 1e7
+}
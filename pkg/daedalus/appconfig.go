@@ -1,10 +1,13 @@
 package daedalus
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -12,6 +15,21 @@ import (
 
 const maxRecentBoards = 10
 
+// remoteBoardSchemes are RecentBoard.Path / AppConfig.DefaultBoard prefixes that point at a
+// board opened through a non-local StorageConfig driver (see OpenStorageFs) rather than a path
+// on the local filesystem.
+var remoteBoardSchemes = []string{"s3://", "sftp://", "ftp://"}
+
+// isRemoteBoardPath reports whether path names a remote board rather than a local directory.
+func isRemoteBoardPath(path string) bool {
+	for _, scheme := range remoteBoardSchemes {
+		if strings.HasPrefix(path, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
 // RecentBoard is a board that was recently opened by the user.
 type RecentBoard struct {
 	Path       string    `yaml:"path" json:"path"`
@@ -23,6 +41,39 @@ type RecentBoard struct {
 type AppConfig struct {
 	DefaultBoard string        `yaml:"default_board,omitempty" json:"defaultBoard"`
 	RecentBoards []RecentBoard `yaml:"recent_boards,omitempty" json:"recentBoards"`
+
+	// DefaultSnapshotKeep is the rolling-snapshot retention count (see PruneSnapshots) applied
+	// after App.CreateSnapshot for boards whose own board.yaml doesn't set backup.keep. Zero
+	// means no app-level default: a board with no backup.keep of its own keeps every snapshot,
+	// same as today.
+	DefaultSnapshotKeep int `yaml:"default_snapshot_keep,omitempty" json:"defaultSnapshotKeep,omitempty"`
+
+	// ArchiveRetentionDays is how long an archived list (see ArchiveListFs) is kept before
+	// periodic maintenance garbage-collects it via PurgeArchivedFs. Zero means archived lists
+	// are kept indefinitely until a user purges them by hand.
+	ArchiveRetentionDays int `yaml:"archive_retention_days,omitempty" json:"archiveRetentionDays,omitempty"`
+
+	// DeviceID identifies this install as one entry in a card's version vector (see
+	// pkg/daedalus/vclock.go), so two devices sharing a board over Dropbox/Syncthing can tell
+	// their own edits apart from a concurrent edit made elsewhere. Generated once by
+	// EnsureDeviceID and persisted from then on -- it is never meant to change.
+	DeviceID string `yaml:"device_id,omitempty" json:"deviceId,omitempty"`
+}
+
+// EnsureDeviceID returns cfg.DeviceID, generating and setting a new random one first if it's
+// empty. Returns whether it generated a new ID, so the caller knows whether it needs to
+// SaveAppConfig to persist it.
+func EnsureDeviceID(cfg *AppConfig) (id string, generated bool) {
+	if cfg.DeviceID != "" {
+		return cfg.DeviceID, false
+	}
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		slog.Error("failed to generate device id", "error", err)
+		return "", false
+	}
+	cfg.DeviceID = hex.EncodeToString(buf)
+	return cfg.DeviceID, true
 }
 
 // LoadAppConfig reads config.yaml from configDir. Returns empty config if file is missing.
@@ -95,11 +146,14 @@ func RemoveRecentBoard(cfg *AppConfig, boardPath string) {
 	cfg.RecentBoards = filtered
 }
 
-// PruneInvalidBoards removes boards that no longer exist on disk from the recent list,
-// and clears the default board if its path is invalid. Returns true if any entries were removed.
+// PruneInvalidBoards removes boards that no longer exist on disk from the recent list, and
+// clears the default board if its path is invalid. Returns true if any entries were removed.
+// A remote board path (s3://, sftp://, ftp://, see isRemoteBoardPath) can't be validated here --
+// doing so needs the credentials that live in that board's own board.yaml, not in AppConfig --
+// so remote entries are always kept rather than pruned.
 func PruneInvalidBoards(cfg *AppConfig) bool {
 	changed := false
-	if cfg.DefaultBoard != "" {
+	if cfg.DefaultBoard != "" && !isRemoteBoardPath(cfg.DefaultBoard) {
 		if _, err := os.Stat(cfg.DefaultBoard); err != nil {
 			cfg.DefaultBoard = ""
 			changed = true
@@ -107,6 +161,10 @@ func PruneInvalidBoards(cfg *AppConfig) bool {
 	}
 	filtered := make([]RecentBoard, 0, len(cfg.RecentBoards))
 	for _, rb := range cfg.RecentBoards {
+		if isRemoteBoardPath(rb.Path) {
+			filtered = append(filtered, rb)
+			continue
+		}
 		if _, err := os.Stat(rb.Path); err == nil {
 			filtered = append(filtered, rb)
 		}
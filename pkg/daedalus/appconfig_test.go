@@ -132,6 +132,43 @@ func TestPruneInvalidBoards(t *testing.T) {
 	}
 }
 
+func TestSaveAndLoadAppConfig_DefaultSnapshotKeep(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &AppConfig{DefaultSnapshotKeep: 5}
+	if err := SaveAppConfig(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadAppConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.DefaultSnapshotKeep != 5 {
+		t.Errorf("DefaultSnapshotKeep: got %d, want 5", loaded.DefaultSnapshotKeep)
+	}
+}
+
+func TestPruneInvalidBoards_KeepsRemotePaths(t *testing.T) {
+	cfg := &AppConfig{
+		DefaultBoard: "sftp://board.example.com/kanban",
+		RecentBoards: []RecentBoard{
+			{Path: "s3://my-bucket/kanban"},
+			{Path: "/nonexistent/board"},
+		},
+	}
+	changed := PruneInvalidBoards(cfg)
+
+	if cfg.DefaultBoard != "sftp://board.example.com/kanban" {
+		t.Errorf("expected remote default board kept, got %q", cfg.DefaultBoard)
+	}
+	if len(cfg.RecentBoards) != 1 || cfg.RecentBoards[0].Path != "s3://my-bucket/kanban" {
+		t.Errorf("expected only the remote recent board to survive, got %v", cfg.RecentBoards)
+	}
+	if !changed {
+		t.Error("expected changed=true since the local nonexistent board was pruned")
+	}
+}
+
 func TestSaveAppConfig_CreatesDir(t *testing.T) {
 	dir := filepath.Join(t.TempDir(), "nested", "config")
 	cfg := &AppConfig{DefaultBoard: "/test"}
@@ -0,0 +1,249 @@
+package daedalus
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// WriteExportTar streams a board (board.yaml, every card file, and _assets/icons) as a
+// single tar archive to w. Card files are copied byte-for-byte, so frontmatter and
+// unknown YAML keys survive the round trip untouched.
+func WriteExportTar(state *BoardState, w io.Writer) error {
+	fs := state.Fs
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+
+	boardYamlPath := filepath.Join(state.RootPath, "board.yaml")
+	if data, err := afero.ReadFile(fs, boardYamlPath); err == nil {
+		if err := writeTarEntry(tw, "board.yaml", data); err != nil {
+			return fmt.Errorf("writing board.yaml to archive: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("reading board.yaml: %w", err)
+	}
+
+	for _, entry := range state.Config.Lists {
+		for _, card := range state.Lists[entry.Dir] {
+			data, err := afero.ReadFile(fs, card.FilePath)
+			if err != nil {
+				slog.Warn("export: failed to read card for archive", "path", card.FilePath, "error", err)
+				continue
+			}
+			name := entry.Dir + "/" + filepath.Base(card.FilePath)
+			if err := writeTarEntry(tw, name, data); err != nil {
+				return fmt.Errorf("writing %s to archive: %w", name, err)
+			}
+		}
+	}
+
+	iconsDir := filepath.Join(state.RootPath, "_assets", "icons")
+	if infos, err := afero.ReadDir(fs, iconsDir); err == nil {
+		for _, info := range infos {
+			if info.IsDir() || !IsIconExt(info.Name()) {
+				continue
+			}
+			data, err := afero.ReadFile(fs, filepath.Join(iconsDir, info.Name()))
+			if err != nil {
+				slog.Warn("export: failed to read icon for archive", "name", info.Name(), "error", err)
+				continue
+			}
+			name := "_assets/icons/" + info.Name()
+			if err := writeTarEntry(tw, name, data); err != nil {
+				return fmt.Errorf("writing %s to archive: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeTarEntry writes a single regular-file entry into a tar archive.
+func writeTarEntry(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ImportTar reads a tar archive produced by WriteExportTar and writes its contents into
+// destDir on the real OS filesystem.
+func ImportTar(r io.Reader, destDir string) error {
+	return ImportTarFs(afero.NewOsFs(), r, destDir)
+}
+
+// ImportTarFs reads a tar archive produced by WriteExportTar and writes its contents into
+// destDir on fs. Card files are routed through WriteCardFileFs so unknown YAML keys are
+// preserved, and the icons directory is validated against the cards that reference it. On
+// a card ID collision with an existing board at destDir, the incoming card is renumbered via
+// MaxID and its list_order rewritten with ComputeInsertPosition so it lands at the bottom
+// of its list instead of clobbering the existing card.
+func ImportTarFs(fs afero.Fs, r io.Reader, destDir string) error {
+	type tarEntry struct {
+		name string
+		data []byte
+	}
+
+	var entries []tarEntry
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("reading %s from archive: %w", hdr.Name, err)
+		}
+		entries = append(entries, tarEntry{name: hdr.Name, data: data})
+	}
+
+	existing, err := ScanBoardFs(context.Background(), fs, destDir)
+	if err != nil {
+		existing = &BoardState{Lists: make(map[string][]KanbanCard), RootPath: destDir}
+	}
+	maxID := existing.MaxID
+	knownIDs := make(map[int]bool)
+	for _, cards := range existing.Lists {
+		for _, c := range cards {
+			knownIDs[c.Metadata.ID] = true
+		}
+	}
+
+	iconNames := make(map[string]bool)
+	var cardEntries []tarEntry
+	for _, e := range entries {
+		switch {
+		case e.name == "board.yaml":
+			if err := afero.WriteFile(fs, filepath.Join(destDir, "board.yaml"), e.data, 0644); err != nil {
+				return fmt.Errorf("writing board.yaml: %w", err)
+			}
+		case strings.HasPrefix(e.name, "_assets/icons/"):
+			// filepath.Base collapses any directory components (including "../../..") in the
+			// entry name down to its final element, so a crafted icon path can't escape
+			// _assets/icons.
+			name := filepath.Base(strings.TrimPrefix(e.name, "_assets/icons/"))
+			dest := filepath.Join(destDir, "_assets", "icons", name)
+			if !pathWithinRoot(destDir, dest) {
+				slog.Warn("import: skipping icon with unsafe path", "name", e.name)
+				continue
+			}
+			iconNames[name] = true
+			if err := fs.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return fmt.Errorf("creating icons dir: %w", err)
+			}
+			if err := afero.WriteFile(fs, dest, e.data, 0644); err != nil {
+				return fmt.Errorf("writing icon %s: %w", name, err)
+			}
+		default:
+			cardEntries = append(cardEntries, e)
+		}
+	}
+
+	for _, e := range cardEntries {
+		parts := strings.SplitN(e.name, "/", 2)
+		if len(parts) != 2 || !strings.HasSuffix(parts[1], ".md") {
+			continue
+		}
+		listDir, fileName := parts[0], parts[1]
+		listDir, err := ValidateListName(listDir)
+		if err != nil {
+			slog.Warn("import: skipping card in unsafe list dir", "name", e.name, "error", err)
+			continue
+		}
+
+		meta, body, err := parseCardBytes(e.data)
+		if err != nil {
+			slog.Warn("import: skipping unreadable card", "name", e.name, "error", err)
+			continue
+		}
+
+		if meta.Icon != "" && !iconNames[meta.Icon] {
+			slog.Warn("import: card references missing icon", "card", meta.ID, "icon", meta.Icon)
+		}
+
+		if knownIDs[meta.ID] {
+			maxID++
+			meta.ID = maxID
+			fileName = fmt.Sprintf("%d.md", meta.ID)
+			meta.ListOrder, _ = ComputeInsertPosition(existing.Lists[listDir], "bottom")
+		}
+		knownIDs[meta.ID] = true
+		if meta.ID > maxID {
+			maxID = meta.ID
+		}
+
+		dest := filepath.Join(destDir, listDir, fileName)
+		if !pathWithinRoot(destDir, dest) {
+			slog.Warn("import: skipping card with unsafe path", "name", e.name)
+			continue
+		}
+		if err := fs.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("creating list dir %s: %w", listDir, err)
+		}
+		if err := WriteCardFileFs(context.Background(), fs, dest, meta, body); err != nil {
+			return fmt.Errorf("writing card %s: %w", dest, err)
+		}
+		existing.Lists[listDir] = append(existing.Lists[listDir], KanbanCard{
+			FilePath: dest,
+			ListName: listDir,
+			Metadata: meta,
+		})
+	}
+
+	return nil
+}
+
+// parseCardBytes extracts frontmatter and body from an in-memory card file, mirroring
+// parseFileHeaderFs but operating on already-read bytes instead of an open file.
+func parseCardBytes(data []byte) (CardMetadata, string, error) {
+	var frontmatterBuf, bodyBuf bytes.Buffer
+	s := bufio.NewScanner(bytes.NewReader(data))
+	scanCardFile(s,
+		func(line string) bool {
+			frontmatterBuf.WriteString(line + "\n")
+			return true
+		},
+		func(line string) bool {
+			bodyBuf.WriteString(line + "\n")
+			return true
+		},
+	)
+	if err := s.Err(); err != nil {
+		return CardMetadata{}, "", err
+	}
+
+	var meta CardMetadata
+	if frontmatterBuf.Len() > 0 {
+		if err := yaml.Unmarshal(frontmatterBuf.Bytes(), &meta); err != nil {
+			return CardMetadata{}, "", err
+		}
+	}
+	return meta, bodyBuf.String(), nil
+}
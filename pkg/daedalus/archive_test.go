@@ -0,0 +1,96 @@
+package daedalus
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// Exporting then importing a board into a fresh directory should round-trip its cards.
+func TestExportImportTar_RoundTrip(t *testing.T) {
+	src := NewBoardInMemory()
+	if err := src.Fs.MkdirAll("/board/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFileFs(context.Background(), src.Fs, "/board/todo/1.md", CardMetadata{ID: 1, Title: "Card One"}, "# Card One\n"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := SaveBoardConfigFs(context.Background(), src.Fs, "/board", &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	scanned, err := ScanBoardFs(context.Background(), src.Fs, "/board")
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteExportTar(scanned, &buf); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	dest := NewBoardInMemory()
+	if err := ImportTarFs(dest.Fs, &buf, "/imported"); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	imported, err := ScanBoardFs(context.Background(), dest.Fs, "/imported")
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	cards := imported.Lists["todo"]
+	if len(cards) != 1 || cards[0].Metadata.Title != "Card One" {
+		t.Fatalf("expected imported card 'Card One', got %+v", cards)
+	}
+}
+
+// Importing a card whose ID collides with an existing card should renumber it
+// rather than overwrite the existing file.
+func TestImportTar_IDCollisionRenumbers(t *testing.T) {
+	fs := NewBoardInMemory().Fs
+	if err := fs.MkdirAll("/board/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFileFs(context.Background(), fs, "/board/todo/1.md", CardMetadata{ID: 1, Title: "Existing"}, "body\n"); err != nil {
+		t.Fatalf("write existing: %v", err)
+	}
+
+	// Build a one-card tar archive with a colliding ID.
+	incoming := NewBoardInMemory()
+	if err := incoming.Fs.MkdirAll("/incoming/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFileFs(context.Background(), incoming.Fs, "/incoming/todo/1.md", CardMetadata{ID: 1, Title: "Incoming"}, "body\n"); err != nil {
+		t.Fatalf("write incoming: %v", err)
+	}
+	if err := SaveBoardConfigFs(context.Background(), incoming.Fs, "/incoming", &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	scanned, err := ScanBoardFs(context.Background(), incoming.Fs, "/incoming")
+	if err != nil {
+		t.Fatalf("scan incoming: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteExportTar(scanned, &buf); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if err := ImportTarFs(fs, &buf, "/board"); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	result, err := ScanBoardFs(context.Background(), fs, "/board")
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	cards := result.Lists["todo"]
+	if len(cards) != 2 {
+		t.Fatalf("expected 2 cards after collision import, got %d", len(cards))
+	}
+	titles := map[string]bool{}
+	for _, c := range cards {
+		titles[c.Metadata.Title] = true
+	}
+	if !titles["Existing"] || !titles["Incoming"] {
+		t.Fatalf("expected both cards to survive, got %+v", cards)
+	}
+}
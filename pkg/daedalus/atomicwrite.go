@@ -0,0 +1,62 @@
+package daedalus
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+// writeFileAtomicFs writes data to path without ever leaving a partially-written file behind:
+// it writes to a sibling "<path>.tmp", fsyncs it, then renames it over path, which is atomic
+// on POSIX filesystems. The previous contents of path, if any, are preserved as "<path>.bak"
+// so a reader can recover from a corrupt primary. This mirrors the descriptor-swap-via-rename
+// approach embedded stores like goleveldb use to survive a crash mid-write.
+func writeFileAtomicFs(fs afero.Fs, path string, data []byte, perm os.FileMode) error {
+	tmpPath := path + ".tmp"
+	bakPath := path + ".bak"
+
+	f, err := fs.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("syncing temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+
+	if exists, _ := afero.Exists(fs, path); exists {
+		if err := fs.Rename(path, bakPath); err != nil {
+			return fmt.Errorf("rotating previous file to .bak: %w", err)
+		}
+	}
+	if err := fs.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("renaming temp file into place: %w", err)
+	}
+	return nil
+}
+
+// fsyncDir best-effort fsyncs a directory after a rename into it, so the rename itself is
+// durable across a crash on filesystems that require it. Not every afero.Fs backend supports
+// opening a directory for read (a remote Fs, or the in-memory test Fs), so failures here are
+// logged rather than returned -- callers already have the primary temp-file-then-rename to lean
+// on for correctness.
+func fsyncDir(fs afero.Fs, dir string) {
+	f, err := fs.Open(dir)
+	if err != nil {
+		slog.Debug("directory fsync skipped: cannot open directory", "dir", dir, "error", err)
+		return
+	}
+	defer f.Close()
+	if err := f.Sync(); err != nil {
+		slog.Debug("directory fsync skipped: sync unsupported", "dir", dir, "error", err)
+	}
+}
@@ -0,0 +1,177 @@
+package daedalus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// attachmentsBlobDir returns the board's content-addressed attachment store, sharded one level
+// deep by the first two hex digits of each blob's digest so the directory doesn't accumulate an
+// unbounded flat list of files the way exportcar.go's blobs/ dir does for a one-shot export.
+func attachmentsBlobDir(rootPath string) string {
+	return filepath.Join(rootPath, ".daedalus", "blobs")
+}
+
+// attachmentBlobPath returns the on-disk path for the blob with the given hex SHA-256 digest.
+func attachmentBlobPath(rootPath, sha256hex string) string {
+	prefix := sha256hex
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+	return filepath.Join(attachmentsBlobDir(rootPath), prefix, sha256hex)
+}
+
+// AttachCardFile reads srcPath, stores its content in the board's blob store (deduplicated by
+// SHA-256, so attaching the same file to two cards or attaching it twice only stores it once),
+// and appends the resulting Attachment to the card file at cardPath's metadata.
+func AttachCardFile(cardPath, srcPath string) (Attachment, error) {
+	return AttachCardFileFs(afero.NewOsFs(), cardPath, srcPath)
+}
+
+// AttachCardFileFs is AttachCardFile against an injected afero.Fs.
+func AttachCardFileFs(fs afero.Fs, cardPath, srcPath string) (Attachment, error) {
+	data, err := afero.ReadFile(fs, srcPath)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("reading attachment source: %w", err)
+	}
+
+	rootPath := filepath.Dir(filepath.Dir(cardPath))
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	blobPath := attachmentBlobPath(rootPath, digest)
+
+	if exists, _ := afero.Exists(fs, blobPath); !exists {
+		if err := fs.MkdirAll(filepath.Dir(blobPath), 0755); err != nil {
+			return Attachment{}, fmt.Errorf("creating blob dir: %w", err)
+		}
+		if err := afero.WriteFile(fs, blobPath, data, 0644); err != nil {
+			return Attachment{}, fmt.Errorf("writing blob: %w", err)
+		}
+	}
+
+	attachment := Attachment{
+		Name:     filepath.Base(srcPath),
+		Sha256:   digest,
+		Size:     int64(len(data)),
+		MimeType: http.DetectContentType(data),
+	}
+
+	meta, body, err := readCardFileFs(fs, cardPath)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("reading card: %w", err)
+	}
+	meta.Attachments = append(meta.Attachments, attachment)
+	now := time.Now()
+	meta.Updated = &now
+	if err := WriteCardFileFs(context.Background(), fs, cardPath, meta, body); err != nil {
+		return Attachment{}, fmt.Errorf("writing card with attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// OpenAttachment opens the blob for sha256hex under the board at root on the real OS
+// filesystem, returning a ReadSeeker suitable for http.ServeContent. The caller is responsible
+// for closing it (via an io.Closer type assertion) once done.
+func OpenAttachment(root, sha256hex string) (io.ReadSeeker, error) {
+	path := attachmentBlobPath(root, sha256hex)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening attachment blob: %w", err)
+	}
+	return f, nil
+}
+
+// NewAttachmentHandler returns an http.Handler that serves a board's attachment blobs by SHA-256
+// digest -- the last path segment of the request URL -- via http.ServeContent, so browsers get
+// proper Range support (resumable and partial downloads) for free.
+func NewAttachmentHandler(root string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		digest := filepath.Base(r.URL.Path)
+		if digest == "" || digest == "." || digest == "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		path := attachmentBlobPath(root, digest)
+		f, err := os.Open(path)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			http.Error(w, "failed to stat attachment", http.StatusInternalServerError)
+			return
+		}
+
+		http.ServeContent(w, r, digest, info.ModTime(), f)
+	})
+}
+
+// GCAttachments removes every blob in the board at root's attachment store that's no longer
+// referenced by any card's Attachments, returning the number of blobs removed. Call this
+// periodically (or after a bulk card deletion) to reclaim space from attachments whose last
+// referencing card was deleted or had the attachment removed.
+func GCAttachments(root string) (removed int, err error) {
+	return GCAttachmentsFs(afero.NewOsFs(), root)
+}
+
+// GCAttachmentsFs is GCAttachments against an injected afero.Fs.
+func GCAttachmentsFs(fs afero.Fs, root string) (removed int, err error) {
+	state, err := ScanBoardFs(context.Background(), fs, root)
+	if err != nil {
+		return 0, fmt.Errorf("scanning board: %w", err)
+	}
+
+	referenced := make(map[string]bool)
+	for _, cards := range state.Lists {
+		for _, card := range cards {
+			for _, a := range card.Metadata.Attachments {
+				referenced[a.Sha256] = true
+			}
+		}
+	}
+
+	blobsDir := attachmentsBlobDir(root)
+	shards, err := afero.ReadDir(fs, blobsDir)
+	if err != nil {
+		if isNotExist(fs, blobsDir) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("reading blob store: %w", err)
+	}
+
+	for _, shard := range shards {
+		if !shard.IsDir() {
+			continue
+		}
+		shardDir := filepath.Join(blobsDir, shard.Name())
+		blobs, err := afero.ReadDir(fs, shardDir)
+		if err != nil {
+			continue
+		}
+		for _, blob := range blobs {
+			if referenced[blob.Name()] {
+				continue
+			}
+			if err := fs.Remove(filepath.Join(shardDir, blob.Name())); err != nil {
+				return removed, fmt.Errorf("removing unreferenced blob %s: %w", blob.Name(), err)
+			}
+			removed++
+		}
+	}
+
+	return removed, nil
+}
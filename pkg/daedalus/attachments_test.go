@@ -0,0 +1,200 @@
+package daedalus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func setupAttachmentBoard(t *testing.T, fs afero.Fs) (root, cardPath string) {
+	t.Helper()
+	root = "/board"
+	cardPath = filepath.Join(root, "todo", "1.md")
+	if err := WriteCardFileFs(context.Background(), fs, cardPath, CardMetadata{ID: 1, Title: "Task"}, "body\n"); err != nil {
+		t.Fatalf("WriteCardFileFs: %v", err)
+	}
+	return root, cardPath
+}
+
+// AttachCardFile should store the source file's content in the blob store and record an
+// Attachment on the card, and WriteCardFile/readCardFileFs should round-trip the attachments
+// YAML key just like any other card field.
+func TestAttachCardFile_RoundTrip(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root, cardPath := setupAttachmentBoard(t, fs)
+
+	srcPath := "/uploads/photo.png"
+	pngHeader := []byte("\x89PNG\r\n\x1a\nrest of the file")
+	if err := afero.WriteFile(fs, srcPath, pngHeader, 0644); err != nil {
+		t.Fatalf("writing source file: %v", err)
+	}
+
+	attachment, err := AttachCardFileFs(fs, cardPath, srcPath)
+	if err != nil {
+		t.Fatalf("AttachCardFileFs: %v", err)
+	}
+	if attachment.Name != "photo.png" || attachment.Size != int64(len(pngHeader)) {
+		t.Fatalf("unexpected attachment: %+v", attachment)
+	}
+	if attachment.MimeType != "image/png" {
+		t.Errorf("mimeType: got %q, want image/png", attachment.MimeType)
+	}
+
+	blobPath := attachmentBlobPath(root, attachment.Sha256)
+	blobData, err := afero.ReadFile(fs, blobPath)
+	if err != nil {
+		t.Fatalf("reading blob: %v", err)
+	}
+	if string(blobData) != string(pngHeader) {
+		t.Errorf("blob content mismatch: got %q", blobData)
+	}
+
+	meta, _, err := readCardFileFs(fs, cardPath)
+	if err != nil {
+		t.Fatalf("readCardFileFs: %v", err)
+	}
+	if len(meta.Attachments) != 1 || meta.Attachments[0].Sha256 != attachment.Sha256 {
+		t.Fatalf("expected attachment to round-trip through the card file, got %+v", meta.Attachments)
+	}
+}
+
+// Attaching the same content twice should dedupe to a single blob.
+func TestAttachCardFile_DedupesIdenticalContent(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root, cardPath := setupAttachmentBoard(t, fs)
+
+	srcPath := "/uploads/a.txt"
+	if err := afero.WriteFile(fs, srcPath, []byte("same bytes"), 0644); err != nil {
+		t.Fatalf("writing source: %v", err)
+	}
+
+	first, err := AttachCardFileFs(fs, cardPath, srcPath)
+	if err != nil {
+		t.Fatalf("AttachCardFileFs (first): %v", err)
+	}
+	second, err := AttachCardFileFs(fs, cardPath, srcPath)
+	if err != nil {
+		t.Fatalf("AttachCardFileFs (second): %v", err)
+	}
+	if first.Sha256 != second.Sha256 {
+		t.Fatalf("expected identical content to share a digest, got %q and %q", first.Sha256, second.Sha256)
+	}
+
+	meta, _, err := readCardFileFs(fs, cardPath)
+	if err != nil {
+		t.Fatalf("readCardFileFs: %v", err)
+	}
+	if len(meta.Attachments) != 2 {
+		t.Fatalf("expected two attachment records even though they share a blob, got %d", len(meta.Attachments))
+	}
+
+	shards, err := afero.ReadDir(fs, attachmentsBlobDir(root))
+	if err != nil {
+		t.Fatalf("reading blob store: %v", err)
+	}
+	blobCount := 0
+	for _, shard := range shards {
+		entries, err := afero.ReadDir(fs, filepath.Join(attachmentsBlobDir(root), shard.Name()))
+		if err != nil {
+			t.Fatalf("reading shard: %v", err)
+		}
+		blobCount += len(entries)
+	}
+	if blobCount != 1 {
+		t.Fatalf("expected exactly one blob on disk, got %d", blobCount)
+	}
+}
+
+// GCAttachmentsFs should remove a blob once no card references it, and keep one that's still
+// referenced.
+func TestGCAttachmentsFs_RemovesUnreferencedBlobs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root, cardPath := setupAttachmentBoard(t, fs)
+
+	if err := afero.WriteFile(fs, "/uploads/keep.txt", []byte("keep me"), 0644); err != nil {
+		t.Fatalf("writing source: %v", err)
+	}
+	if err := afero.WriteFile(fs, "/uploads/drop.txt", []byte("drop me"), 0644); err != nil {
+		t.Fatalf("writing source: %v", err)
+	}
+	kept, err := AttachCardFileFs(fs, cardPath, "/uploads/keep.txt")
+	if err != nil {
+		t.Fatalf("attach keep: %v", err)
+	}
+	dropped, err := AttachCardFileFs(fs, cardPath, "/uploads/drop.txt")
+	if err != nil {
+		t.Fatalf("attach drop: %v", err)
+	}
+
+	// Simulate the card's reference to "drop.txt" being removed (e.g. the card was edited to
+	// drop that attachment) by rewriting the card with only the kept attachment.
+	meta, body, err := readCardFileFs(fs, cardPath)
+	if err != nil {
+		t.Fatalf("readCardFileFs: %v", err)
+	}
+	meta.Attachments = []Attachment{kept}
+	if err := WriteCardFileFs(context.Background(), fs, cardPath, meta, body); err != nil {
+		t.Fatalf("rewriting card: %v", err)
+	}
+
+	removed, err := GCAttachmentsFs(fs, root)
+	if err != nil {
+		t.Fatalf("GCAttachmentsFs: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 blob removed, got %d", removed)
+	}
+
+	if exists, _ := afero.Exists(fs, attachmentBlobPath(root, kept.Sha256)); !exists {
+		t.Error("expected the still-referenced blob to survive GC")
+	}
+	if exists, _ := afero.Exists(fs, attachmentBlobPath(root, dropped.Sha256)); exists {
+		t.Error("expected the unreferenced blob to be removed by GC")
+	}
+}
+
+// NewAttachmentHandler should serve a blob's content and honor Range requests via
+// http.ServeContent.
+func TestAttachmentHandler_ServesContentWithRange(t *testing.T) {
+	root := t.TempDir()
+	digest := "abcd1234"
+	blobPath := attachmentBlobPath(root, digest)
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0755); err != nil {
+		t.Fatalf("mkdir blob dir: %v", err)
+	}
+	if err := os.WriteFile(blobPath, []byte("0123456789"), 0644); err != nil {
+		t.Fatalf("writing blob: %v", err)
+	}
+
+	handler := NewAttachmentHandler(root)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/"+digest, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Range", "bytes=2-4")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		t.Fatalf("expected 206 Partial Content, got %d", resp.StatusCode)
+	}
+	gotBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	if string(gotBody) != "234" {
+		t.Errorf("range body: got %q, want %q", gotBody, "234")
+	}
+}
@@ -0,0 +1,318 @@
+package daedalus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+)
+
+// MovedCard records a card whose list changed between two board snapshots, identified by ID
+// rather than file path so a rename-on-disk (same card, new list directory) is recognized as a
+// move rather than a remove-then-add pair.
+type MovedCard struct {
+	ID       int    `json:"id"`
+	FromList string `json:"fromList"`
+	ToList   string `json:"toList"`
+}
+
+// ReorderedCard records a card whose ListOrder changed within the same list between two
+// snapshots.
+type ReorderedCard struct {
+	ID       int     `json:"id"`
+	List     string  `json:"list"`
+	OldOrder float64 `json:"oldOrder"`
+	NewOrder float64 `json:"newOrder"`
+}
+
+// FieldChange records one metadata field that differs for the same card ID between two
+// snapshots. Old/New are human-readable summaries, not necessarily the raw field values --
+// Labels reports the old and new label sets, Checklist reports one change per item index whose
+// Done flag toggled (field "checklist[<idx>]"), and Counter reports the old and new Current
+// values.
+type FieldChange struct {
+	ID    int    `json:"id"`
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// BoardDiff is the result of CompareBoards: every card added or removed between two snapshots,
+// every card that moved lists or was reordered within one, and every per-field metadata change
+// on a card present in both. Used as the basis for undo/redo, audit logs, and conflict display.
+type BoardDiff struct {
+	Added     []KanbanCard    `json:"added"`
+	Removed   []KanbanCard    `json:"removed"`
+	Moved     []MovedCard     `json:"moved"`
+	Reordered []ReorderedCard `json:"reordered"`
+	Changed   []FieldChange   `json:"changed"`
+}
+
+// cardIndex flattens a BoardState's lists into a lookup by card ID, for CompareBoards to pair up
+// the same card across two snapshots regardless of which list it's in.
+func cardIndex(state *BoardState) map[int]struct {
+	list string
+	card KanbanCard
+} {
+	idx := make(map[int]struct {
+		list string
+		card KanbanCard
+	}, state.MaxID)
+	for listName, cards := range state.Lists {
+		for _, card := range cards {
+			idx[card.Metadata.ID] = struct {
+				list string
+				card KanbanCard
+			}{list: listName, card: card}
+		}
+	}
+	return idx
+}
+
+// CompareBoards diffs two scans of the same board (e.g. before and after a sync pull, or two
+// undo-log snapshots) by card ID, so a card whose file moved between list directories is
+// reported as a move rather than a spurious remove-and-add pair.
+func CompareBoards(prev, curr *BoardState) BoardDiff {
+	var diff BoardDiff
+	prevIdx := cardIndex(prev)
+	currIdx := cardIndex(curr)
+
+	for id, p := range prevIdx {
+		c, ok := currIdx[id]
+		if !ok {
+			diff.Removed = append(diff.Removed, p.card)
+			continue
+		}
+		if p.list != c.list {
+			diff.Moved = append(diff.Moved, MovedCard{ID: id, FromList: p.list, ToList: c.list})
+		} else if p.card.Metadata.ListOrder != c.card.Metadata.ListOrder {
+			diff.Reordered = append(diff.Reordered, ReorderedCard{
+				ID: id, List: c.list,
+				OldOrder: p.card.Metadata.ListOrder, NewOrder: c.card.Metadata.ListOrder,
+			})
+		}
+		diff.Changed = append(diff.Changed, diffCardFields(id, p.card.Metadata, c.card.Metadata)...)
+	}
+	for id, c := range currIdx {
+		if _, ok := prevIdx[id]; !ok {
+			diff.Added = append(diff.Added, c.card)
+		}
+	}
+
+	sortDiff(&diff)
+	return diff
+}
+
+// diffCardFields compares the non-structural metadata fields (title, labels, checklist item
+// toggles, counter value) of the same card ID across two snapshots.
+func diffCardFields(id int, prev, curr CardMetadata) []FieldChange {
+	var changes []FieldChange
+
+	if prev.Title != curr.Title {
+		changes = append(changes, FieldChange{ID: id, Field: "title", Old: prev.Title, New: curr.Title})
+	}
+
+	if !equalStringSlices(prev.Labels, curr.Labels) {
+		changes = append(changes, FieldChange{
+			ID: id, Field: "labels",
+			Old: fmt.Sprintf("%v", prev.Labels), New: fmt.Sprintf("%v", curr.Labels),
+		})
+	}
+
+	changes = append(changes, diffChecklist(id, prev.Checklist, curr.Checklist)...)
+
+	if prev.Counter != nil && curr.Counter != nil && prev.Counter.Current != curr.Counter.Current {
+		changes = append(changes, FieldChange{
+			ID: id, Field: "counter",
+			Old: fmt.Sprintf("%d", prev.Counter.Current), New: fmt.Sprintf("%d", curr.Counter.Current),
+		})
+	}
+
+	return changes
+}
+
+// diffChecklist reports one FieldChange per checklist item index whose Done flag toggled between
+// snapshots. Items are matched by Idx, since a checklist item's position in the slice isn't
+// guaranteed stable across edits the way its Idx is (see CheckListItem).
+func diffChecklist(id int, prev, curr []CheckListItem) []FieldChange {
+	prevByIdx := make(map[int]bool, len(prev))
+	for _, item := range prev {
+		prevByIdx[item.Idx] = item.Done
+	}
+	var changes []FieldChange
+	for _, item := range curr {
+		if wasDone, ok := prevByIdx[item.Idx]; ok && wasDone != item.Done {
+			changes = append(changes, FieldChange{
+				ID: id, Field: fmt.Sprintf("checklist[%d]", item.Idx),
+				Old: fmt.Sprintf("%v", wasDone), New: fmt.Sprintf("%v", item.Done),
+			})
+		}
+	}
+	return changes
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortDiff orders every slice in diff by card ID (and Changed by field name, secondarily) so
+// CompareBoards' output is deterministic regardless of map iteration order.
+func sortDiff(diff *BoardDiff) {
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Metadata.ID < diff.Added[j].Metadata.ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Metadata.ID < diff.Removed[j].Metadata.ID })
+	sort.Slice(diff.Moved, func(i, j int) bool { return diff.Moved[i].ID < diff.Moved[j].ID })
+	sort.Slice(diff.Reordered, func(i, j int) bool { return diff.Reordered[i].ID < diff.Reordered[j].ID })
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		if diff.Changed[i].ID != diff.Changed[j].ID {
+			return diff.Changed[i].ID < diff.Changed[j].ID
+		}
+		return diff.Changed[i].Field < diff.Changed[j].Field
+	})
+}
+
+// ManifestEntry is one card's position and content digests in a BoardManifest.
+type ManifestEntry struct {
+	List          string  `json:"list"`
+	ListOrder     float64 `json:"listOrder"`
+	ContentSHA256 string  `json:"contentSha256"`
+	MetaSHA256    string  `json:"metaSha256"`
+}
+
+// BoardManifest is a lightweight, serializable snapshot of a board's cards for later integrity
+// verification via CheckBoard -- a card ID to ManifestEntry map, unlike ExportManifest's full
+// content-addressed export which also carries the card bodies and icons themselves.
+type BoardManifest struct {
+	Cards map[int]ManifestEntry `json:"cards"`
+}
+
+// metaDigest hashes everything in meta except ContentSHA256 (the body's own digest, tracked
+// separately as ManifestEntry.ContentSHA256) so a body-only edit doesn't also appear to change
+// the metadata digest, and vice versa.
+func metaDigest(meta CardMetadata) string {
+	meta.ContentSHA256 = ""
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return ""
+	}
+	return hashBlob(data)
+}
+
+// SnapshotBoard builds a BoardManifest from state, for CheckBoard to later verify the board still
+// matches. Cards are keyed by ID; state's own CardMetadata.ContentSHA256 (set by SaveCard et al.
+// on every write) is reused directly rather than re-hashing a body SnapshotBoard doesn't have in
+// memory.
+func SnapshotBoard(state *BoardState) *BoardManifest {
+	manifest := &BoardManifest{Cards: make(map[int]ManifestEntry)}
+	for listName, cards := range state.Lists {
+		for _, card := range cards {
+			manifest.Cards[card.Metadata.ID] = ManifestEntry{
+				List:          listName,
+				ListOrder:     card.Metadata.ListOrder,
+				ContentSHA256: card.Metadata.ContentSHA256,
+				MetaSHA256:    metaDigest(card.Metadata),
+			}
+		}
+	}
+	return manifest
+}
+
+// BoardCheckResult is CheckBoard's report: Failures are cards present in both the manifest and
+// the current board whose content or metadata digest no longer matches, Missing are cards the
+// manifest expected that the current board no longer has, and Extra are cards on the current
+// board the manifest never recorded.
+type BoardCheckResult struct {
+	Failures []int `json:"failures"`
+	Missing  []int `json:"missing"`
+	Extra    []int `json:"extra"`
+}
+
+// manifestPath returns the file a board's last-saved BoardManifest is persisted to, so a later
+// "check" run doesn't need the snapshotting process to still be alive to hand it the manifest.
+func manifestPath(rootPath string) string {
+	return filepath.Join(rootPath, ".daedalus", "manifest.json")
+}
+
+// SaveManifest persists manifest to root's manifest file, overwriting any previous one, for
+// CheckBoard to compare a later scan against.
+func SaveManifest(root string, manifest *BoardManifest) error {
+	return SaveManifestFs(afero.NewOsFs(), root, manifest)
+}
+
+// SaveManifestFs is SaveManifest against an injected afero.Fs.
+func SaveManifestFs(fs afero.Fs, root string, manifest *BoardManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	path := manifestPath(root)
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating manifest dir: %w", err)
+	}
+	if err := afero.WriteFile(fs, path, data, 0644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return nil
+}
+
+// LoadManifest reads the BoardManifest a prior SaveManifest wrote for root.
+func LoadManifest(root string) (*BoardManifest, error) {
+	return LoadManifestFs(afero.NewOsFs(), root)
+}
+
+// LoadManifestFs is LoadManifest against an injected afero.Fs.
+func LoadManifestFs(fs afero.Fs, root string) (*BoardManifest, error) {
+	data, err := afero.ReadFile(fs, manifestPath(root))
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	manifest := &BoardManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// CheckBoard rescans the board at root and compares it against manifest (a prior SnapshotBoard),
+// in the spirit of a directory-hierarchy integrity check: it reports which cards' content or
+// metadata no longer matches what the manifest recorded, which expected cards are gone, and
+// which cards exist now that the manifest never saw.
+func CheckBoard(root string, manifest *BoardManifest) (*BoardCheckResult, error) {
+	state, err := ScanBoard(context.Background(), root)
+	if err != nil {
+		return nil, fmt.Errorf("scanning board: %w", err)
+	}
+	current := SnapshotBoard(state)
+
+	result := &BoardCheckResult{}
+	for id, want := range manifest.Cards {
+		got, ok := current.Cards[id]
+		if !ok {
+			result.Missing = append(result.Missing, id)
+			continue
+		}
+		if got.ContentSHA256 != want.ContentSHA256 || got.MetaSHA256 != want.MetaSHA256 || got.List != want.List {
+			result.Failures = append(result.Failures, id)
+		}
+	}
+	for id := range current.Cards {
+		if _, ok := manifest.Cards[id]; !ok {
+			result.Extra = append(result.Extra, id)
+		}
+	}
+
+	sort.Ints(result.Failures)
+	sort.Ints(result.Missing)
+	sort.Ints(result.Extra)
+	return result, nil
+}
@@ -0,0 +1,165 @@
+package daedalus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompareBoards_AddedAndRemoved(t *testing.T) {
+	prev := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {{Metadata: CardMetadata{ID: 1, Title: "Stays"}}, {Metadata: CardMetadata{ID: 2, Title: "Goes"}}},
+	}}
+	curr := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {{Metadata: CardMetadata{ID: 1, Title: "Stays"}}, {Metadata: CardMetadata{ID: 3, Title: "New"}}},
+	}}
+
+	diff := CompareBoards(prev, curr)
+
+	if len(diff.Added) != 1 || diff.Added[0].Metadata.ID != 3 {
+		t.Fatalf("expected card 3 added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Metadata.ID != 2 {
+		t.Fatalf("expected card 2 removed, got %+v", diff.Removed)
+	}
+}
+
+// A card whose file moved between list directories but kept the same ID should be reported as a
+// move, not as a remove from one list plus an add to another.
+func TestCompareBoards_RenameDetectedAsMove(t *testing.T) {
+	prev := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {{Metadata: CardMetadata{ID: 1, Title: "Task", ListOrder: 1}}},
+		"done": {},
+	}}
+	curr := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {},
+		"done": {{Metadata: CardMetadata{ID: 1, Title: "Task", ListOrder: 1}}},
+	}}
+
+	diff := CompareBoards(prev, curr)
+
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 {
+		t.Fatalf("expected no added/removed for a same-ID move, got added=%+v removed=%+v", diff.Added, diff.Removed)
+	}
+	if len(diff.Moved) != 1 {
+		t.Fatalf("expected exactly one move, got %+v", diff.Moved)
+	}
+	want := MovedCard{ID: 1, FromList: "todo", ToList: "done"}
+	if diff.Moved[0] != want {
+		t.Errorf("Moved[0] = %+v, want %+v", diff.Moved[0], want)
+	}
+}
+
+func TestCompareBoards_Reordered(t *testing.T) {
+	prev := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {{Metadata: CardMetadata{ID: 1, ListOrder: 1}}},
+	}}
+	curr := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {{Metadata: CardMetadata{ID: 1, ListOrder: 2}}},
+	}}
+
+	diff := CompareBoards(prev, curr)
+
+	if len(diff.Reordered) != 1 {
+		t.Fatalf("expected one reorder, got %+v", diff.Reordered)
+	}
+	want := ReorderedCard{ID: 1, List: "todo", OldOrder: 1, NewOrder: 2}
+	if diff.Reordered[0] != want {
+		t.Errorf("Reordered[0] = %+v, want %+v", diff.Reordered[0], want)
+	}
+}
+
+func TestCompareBoards_FieldChanges(t *testing.T) {
+	prev := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {{Metadata: CardMetadata{
+			ID: 1, Title: "Old", Labels: []string{"a"},
+			Checklist: []CheckListItem{{Idx: 0, Desc: "step", Done: false}},
+			Counter:   &Counter{Current: 1, Max: 10},
+		}}},
+	}}
+	curr := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {{Metadata: CardMetadata{
+			ID: 1, Title: "New", Labels: []string{"a", "b"},
+			Checklist: []CheckListItem{{Idx: 0, Desc: "step", Done: true}},
+			Counter:   &Counter{Current: 3, Max: 10},
+		}}},
+	}}
+
+	diff := CompareBoards(prev, curr)
+
+	fields := make(map[string]FieldChange, len(diff.Changed))
+	for _, c := range diff.Changed {
+		fields[c.Field] = c
+	}
+	if _, ok := fields["title"]; !ok {
+		t.Errorf("expected a title change, got %+v", diff.Changed)
+	}
+	if _, ok := fields["labels"]; !ok {
+		t.Errorf("expected a labels change, got %+v", diff.Changed)
+	}
+	if _, ok := fields["checklist[0]"]; !ok {
+		t.Errorf("expected a checklist[0] change, got %+v", diff.Changed)
+	}
+	if c, ok := fields["counter"]; !ok || c.Old != "1" || c.New != "3" {
+		t.Errorf("expected counter change 1 -> 3, got %+v", c)
+	}
+}
+
+func TestSnapshotBoard_CheckBoard_RoundTrip(t *testing.T) {
+	state := &BoardState{Lists: map[string][]KanbanCard{
+		"todo": {{Metadata: CardMetadata{ID: 1, Title: "Task", ContentSHA256: "abc123"}}},
+	}}
+
+	manifest := SnapshotBoard(state)
+	if len(manifest.Cards) != 1 {
+		t.Fatalf("expected 1 manifest entry, got %d", len(manifest.Cards))
+	}
+	entry := manifest.Cards[1]
+	if entry.List != "todo" || entry.ContentSHA256 != "abc123" {
+		t.Fatalf("unexpected manifest entry: %+v", entry)
+	}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "todo"), 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(root, "todo", "1.md"), state.Lists["todo"][0].Metadata, "body"); err != nil {
+		t.Fatalf("WriteCardFile: %v", err)
+	}
+
+	result, err := CheckBoard(root, manifest)
+	if err != nil {
+		t.Fatalf("CheckBoard: %v", err)
+	}
+	if len(result.Failures) != 0 || len(result.Missing) != 0 || len(result.Extra) != 0 {
+		t.Fatalf("expected a clean check, got %+v", result)
+	}
+}
+
+func TestCheckBoard_DetectsMissingAndExtra(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "todo"), 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	meta := CardMetadata{ID: 1, Title: "Task"}
+	if err := WriteCardFile(context.Background(), filepath.Join(root, "todo", "1.md"), meta, "body"); err != nil {
+		t.Fatalf("WriteCardFile: %v", err)
+	}
+
+	manifest := &BoardManifest{Cards: map[int]ManifestEntry{
+		2: {List: "todo", ContentSHA256: "does-not-exist"},
+	}}
+
+	result, err := CheckBoard(root, manifest)
+	if err != nil {
+		t.Fatalf("CheckBoard: %v", err)
+	}
+	if !reflect.DeepEqual(result.Missing, []int{2}) {
+		t.Errorf("expected card 2 missing, got %+v", result.Missing)
+	}
+	if !reflect.DeepEqual(result.Extra, []int{1}) {
+		t.Errorf("expected card 1 extra, got %+v", result.Extra)
+	}
+}
@@ -0,0 +1,409 @@
+package daedalus
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/webdav"
+)
+
+// BoardFileSystem is a golang.org/x/net/webdav.FileSystem backed by a board already loaded in
+// memory (see App.StartWebDAV), rather than one read fresh off disk per request the way
+// pkg/daedalus/webdav's boardFS is. Card writes route through WriteCardFileFs, list
+// creation/removal routes through CreateListOnDiskFs/DeleteListOnDiskFs, and both keep
+// state.Lists and state.TotalFileBytes in sync in place, so a board edited over WebDAV and one
+// edited through the app's own UI see the same state without a rescan. Every method takes a
+// context.Context per the webdav.FileSystem contract, so per-request auth and cancellation
+// propagate, even though none of them use ctx beyond that.
+type BoardFileSystem struct {
+	state *BoardState
+	mu    sync.Mutex
+}
+
+// NewBoardFileSystem returns a BoardFileSystem serving state. Calls into it are serialized
+// against each other with an internal lock, but not against board mutations made elsewhere
+// (e.g. the app's own CreateCard/MoveCard) -- a WebDAV server should only be started against a
+// board the user isn't also editing through the UI at the same time.
+func NewBoardFileSystem(state *BoardState) *BoardFileSystem {
+	return &BoardFileSystem{state: state}
+}
+
+// resolvePath validates that name (a slash-rooted WebDAV path) stays within the board root and
+// returns its absolute path, mirroring App.validatePath.
+func (bfs *BoardFileSystem) resolvePath(name string) (string, error) {
+	rel := strings.TrimPrefix(filepath.ToSlash(name), "/")
+	absPath, err := filepath.Abs(filepath.Join(bfs.state.RootPath, filepath.FromSlash(rel)))
+	if err != nil {
+		slog.Warn("webdav path resolution failed", "path", name, "error", err)
+		return "", fmt.Errorf("invalid path")
+	}
+	absRoot, err := filepath.Abs(bfs.state.RootPath)
+	if err != nil {
+		slog.Error("webdav board root resolution failed", "root", bfs.state.RootPath, "error", err)
+		return "", fmt.Errorf("invalid root path")
+	}
+	if absPath != absRoot && !strings.HasPrefix(absPath, absRoot+string(filepath.Separator)) {
+		slog.Warn("webdav path traversal rejected", "path", name)
+		return "", fmt.Errorf("path outside board directory")
+	}
+	return absPath, nil
+}
+
+// pathParts splits a WebDAV path into its board-relative segments, e.g. "/todo/3.md" ->
+// ["todo", "3.md"].
+func pathParts(name string) []string {
+	clean := strings.Trim(filepath.ToSlash(name), "/")
+	if clean == "" {
+		return nil
+	}
+	return strings.Split(clean, "/")
+}
+
+// cardIDFromName extracts a card ID from a "<id>.md" filename.
+func cardIDFromName(name string) (int, bool) {
+	if !strings.HasSuffix(name, ".md") {
+		return 0, false
+	}
+	id, err := strconv.Atoi(strings.TrimSuffix(name, ".md"))
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func (bfs *BoardFileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	bfs.mu.Lock()
+	defer bfs.mu.Unlock()
+
+	if _, err := bfs.resolvePath(name); err != nil {
+		return err
+	}
+	parts := pathParts(name)
+	if len(parts) != 1 {
+		return fmt.Errorf("nested list directories are not supported")
+	}
+
+	dirName, err := ValidateListNameWithSlug(parts[0], bfs.state.Config.Slug)
+	if err != nil {
+		return err
+	}
+	if _, exists := bfs.state.Lists[dirName]; exists {
+		return fmt.Errorf("list already exists: %s", dirName)
+	}
+
+	if err := CreateListOnDiskFs(bfs.state.Fs, bfs.state.RootPath, dirName, bfs.state.Config); err != nil {
+		return err
+	}
+	bfs.state.Lists[dirName] = []KanbanCard{}
+	slog.Info("webdav created list", "name", dirName)
+	return nil
+}
+
+func (bfs *BoardFileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	bfs.mu.Lock()
+	defer bfs.mu.Unlock()
+
+	absPath, err := bfs.resolvePath(name)
+	if err != nil {
+		return nil, err
+	}
+	parts := pathParts(name)
+	writing := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	if writing && len(parts) == 2 && strings.HasSuffix(parts[1], ".md") {
+		listDir := parts[0]
+		if _, ok := bfs.state.Lists[listDir]; !ok {
+			return nil, fmt.Errorf("list not found: %s", listDir)
+		}
+		if IsListLocked(bfs.state.Config, listDir) {
+			slog.Warn("webdav write blocked by locked list", "list", listDir)
+			return nil, fmt.Errorf("list %q is locked", listDir)
+		}
+		return &boardCardFile{bfs: bfs, listDir: listDir, path: absPath}, nil
+	}
+	return bfs.state.Fs.OpenFile(absPath, flag, perm)
+}
+
+func (bfs *BoardFileSystem) RemoveAll(ctx context.Context, name string) error {
+	bfs.mu.Lock()
+	defer bfs.mu.Unlock()
+
+	absPath, err := bfs.resolvePath(name)
+	if err != nil {
+		return err
+	}
+	parts := pathParts(name)
+	if len(parts) == 1 {
+		if _, ok := bfs.state.Lists[parts[0]]; ok {
+			return bfs.removeList(parts[0])
+		}
+	}
+	if len(parts) == 2 && strings.HasSuffix(parts[1], ".md") {
+		if IsListLocked(bfs.state.Config, parts[0]) {
+			slog.Warn("webdav delete blocked by locked list", "list", parts[0])
+			return fmt.Errorf("list %q is locked", parts[0])
+		}
+		return bfs.removeCard(parts[0], absPath)
+	}
+	return bfs.state.Fs.RemoveAll(absPath)
+}
+
+// removeList archives name (see DeleteListOnDiskFs) and drops it from in-memory state.
+func (bfs *BoardFileSystem) removeList(name string) error {
+	if IsListLocked(bfs.state.Config, name) {
+		slog.Warn("webdav delete blocked by locked list", "list", name)
+		return fmt.Errorf("list %q is locked", name)
+	}
+
+	var totalBytes int64
+	for _, card := range bfs.state.Lists[name] {
+		totalBytes += GetFileSize(card.FilePath)
+	}
+	cardCount := len(bfs.state.Lists[name])
+	if err := DeleteListOnDiskFs(bfs.state.Fs, bfs.state.RootPath, name, bfs.state.Config, false); err != nil {
+		return err
+	}
+	bfs.state.TotalFileBytes -= totalBytes
+	delete(bfs.state.Lists, name)
+	slog.Info("webdav archived list", "name", name, "cards", cardCount)
+	return nil
+}
+
+// removeCard deletes a single card file and drops it from listDir's in-memory slice.
+func (bfs *BoardFileSystem) removeCard(listDir, absPath string) error {
+	removedBytes := GetFileSize(absPath)
+	if err := bfs.state.Fs.RemoveAll(absPath); err != nil {
+		return err
+	}
+	bfs.state.TotalFileBytes -= removedBytes
+
+	cards := bfs.state.Lists[listDir]
+	for i, card := range cards {
+		if card.FilePath == absPath {
+			bfs.state.Lists[listDir] = append(cards[:i], cards[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (bfs *BoardFileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	bfs.mu.Lock()
+	defer bfs.mu.Unlock()
+
+	oldPath, err := bfs.resolvePath(oldName)
+	if err != nil {
+		return err
+	}
+	newPath, err := bfs.resolvePath(newName)
+	if err != nil {
+		return err
+	}
+
+	oldParts, newParts := pathParts(oldName), pathParts(newName)
+
+	// A MOVE or COPY-destination rename of a card file, possibly crossing list directories --
+	// reindex it into the target list exactly as App.MoveCard would.
+	if len(oldParts) == 2 && len(newParts) == 2 && strings.HasSuffix(oldParts[1], ".md") {
+		return bfs.renameCard(oldParts[0], newParts[0], oldPath, newPath)
+	}
+	// Renaming a top-level list directory.
+	if len(oldParts) == 1 && len(newParts) == 1 {
+		if _, ok := bfs.state.Lists[oldParts[0]]; ok {
+			return bfs.renameList(oldParts[0], newParts[0], oldPath, newPath)
+		}
+	}
+	return bfs.state.Fs.Rename(oldPath, newPath)
+}
+
+// renameCard moves a card file on disk and updates state.Lists to match, bumping its Updated
+// time and appending it to the bottom of targetList's order when the move crosses lists -- the
+// same reindex App.MoveCard performs for a drag-and-drop move in the UI.
+func (bfs *BoardFileSystem) renameCard(sourceList, targetList, oldPath, newPath string) error {
+	if IsListLocked(bfs.state.Config, sourceList) {
+		slog.Warn("webdav move blocked by locked source list", "list", sourceList)
+		return fmt.Errorf("list %q is locked", sourceList)
+	}
+	if IsListLocked(bfs.state.Config, targetList) {
+		slog.Warn("webdav move blocked by locked target list", "list", targetList)
+		return fmt.Errorf("list %q is locked", targetList)
+	}
+
+	srcCards := bfs.state.Lists[sourceList]
+	idx := -1
+	for i, card := range srcCards {
+		if card.FilePath == oldPath {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		// Not a tracked card (e.g. a .sig sidecar) -- just move the file.
+		return bfs.state.Fs.Rename(oldPath, newPath)
+	}
+	card := srcCards[idx]
+
+	if err := bfs.state.Fs.Rename(oldPath, newPath); err != nil {
+		return err
+	}
+	bfs.state.Lists[sourceList] = append(srcCards[:idx], srcCards[idx+1:]...)
+	card.FilePath = newPath
+
+	if sourceList == targetList {
+		bfs.state.Lists[targetList] = insertSortedCards(bfs.state.Lists[targetList], card)
+		slog.Debug("webdav card renamed", "id", card.Metadata.ID, "list", sourceList)
+		return nil
+	}
+
+	body, err := ReadCardContentFs(context.Background(), bfs.state.Fs, newPath)
+	if err != nil {
+		return fmt.Errorf("reading moved card: %w", err)
+	}
+	now := time.Now()
+	card.ListName = targetList
+	card.Metadata.Updated = &now
+	card.Metadata.ListOrder = nextListOrder(bfs.state.Lists[targetList])
+	if err := WriteCardFileFs(context.Background(), bfs.state.Fs, newPath, card.Metadata, body); err != nil {
+		return fmt.Errorf("writing moved card: %w", err)
+	}
+
+	bfs.state.Lists[targetList] = insertSortedCards(bfs.state.Lists[targetList], card)
+	slog.Info("webdav moved card", "id", card.Metadata.ID, "from", sourceList, "to", targetList)
+	return nil
+}
+
+// renameList renames a top-level list directory, rewriting its config entry and every card's
+// in-memory FilePath/ListName to match.
+func (bfs *BoardFileSystem) renameList(oldName, newName, oldPath, newPath string) error {
+	if IsListLocked(bfs.state.Config, oldName) {
+		slog.Warn("webdav rename blocked by locked list", "list", oldName)
+		return fmt.Errorf("list %q is locked", oldName)
+	}
+	if _, exists := bfs.state.Lists[newName]; exists {
+		return fmt.Errorf("list already exists: %s", newName)
+	}
+	if err := bfs.state.Fs.Rename(oldPath, newPath); err != nil {
+		return err
+	}
+
+	cards := bfs.state.Lists[oldName]
+	delete(bfs.state.Lists, oldName)
+	for i := range cards {
+		cards[i].FilePath = filepath.Join(newPath, filepath.Base(cards[i].FilePath))
+		cards[i].ListName = newName
+	}
+	bfs.state.Lists[newName] = cards
+
+	if idx := FindListEntry(context.Background(), bfs.state.Config.Lists, oldName); idx >= 0 {
+		bfs.state.Config.Lists[idx].Dir = newName
+	}
+	if err := SaveBoardConfigFs(context.Background(), bfs.state.Fs, bfs.state.RootPath, bfs.state.Config); err != nil {
+		return err
+	}
+	slog.Info("webdav renamed list", "from", oldName, "to", newName)
+	return nil
+}
+
+func (bfs *BoardFileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	absPath, err := bfs.resolvePath(name)
+	if err != nil {
+		return nil, err
+	}
+	return bfs.state.Fs.Stat(absPath)
+}
+
+// nextListOrder returns the ListOrder a card appended to the bottom of cards should get.
+func nextListOrder(cards []KanbanCard) float64 {
+	if len(cards) == 0 {
+		return 0
+	}
+	return cards[len(cards)-1].Metadata.ListOrder + 1
+}
+
+// insertSortedCards inserts card into a slice kept sorted by ListOrder then ID, the same order
+// ScanBoard produces.
+func insertSortedCards(cards []KanbanCard, card KanbanCard) []KanbanCard {
+	idx := sort.Search(len(cards), func(i int) bool {
+		if cards[i].Metadata.ListOrder != card.Metadata.ListOrder {
+			return cards[i].Metadata.ListOrder > card.Metadata.ListOrder
+		}
+		return cards[i].Metadata.ID > card.Metadata.ID
+	})
+	cards = append(cards, KanbanCard{})
+	copy(cards[idx+1:], cards[idx:])
+	cards[idx] = card
+	return cards
+}
+
+// boardCardFile buffers a WebDAV write to a card file and, on Close, parses it and routes it
+// through WriteCardFileFs and BoardFileSystem's in-memory state, the way a PUT through the
+// app's own SaveCard does -- see that method for why frontmatter is re-parsed rather than
+// written raw.
+type boardCardFile struct {
+	bfs     *BoardFileSystem
+	listDir string
+	path    string
+	buf     []byte
+}
+
+func (f *boardCardFile) Write(p []byte) (int, error) {
+	f.buf = append(f.buf, p...)
+	return len(p), nil
+}
+
+func (f *boardCardFile) Close() error {
+	meta, body, err := ParseCardBytes(f.buf)
+	if err != nil {
+		return err
+	}
+	if meta.ID == 0 {
+		if id, ok := cardIDFromName(filepath.Base(f.path)); ok {
+			meta.ID = id
+		}
+	}
+	now := time.Now()
+	meta.Updated = &now
+
+	f.bfs.mu.Lock()
+	defer f.bfs.mu.Unlock()
+	return f.bfs.applyCardWrite(f.listDir, f.path, meta, body)
+}
+
+// applyCardWrite writes a card to disk via WriteCardFileFs and keeps state.Lists and
+// state.TotalFileBytes in sync, whether this is an edit to an existing card or a new one
+// created by a WebDAV PUT or COPY into listDir.
+func (bfs *BoardFileSystem) applyCardWrite(listDir, path string, meta CardMetadata, body string) error {
+	oldBytes := GetFileSize(path)
+	if err := WriteCardFileFs(context.Background(), bfs.state.Fs, path, meta, body); err != nil {
+		return err
+	}
+	bfs.state.TotalFileBytes += GetFileSize(path) - oldBytes
+	if meta.ID > bfs.state.MaxID {
+		bfs.state.MaxID = meta.ID
+	}
+
+	cards := bfs.state.Lists[listDir]
+	for i, card := range cards {
+		if card.FilePath == path {
+			cards[i].Metadata = meta
+			cards[i].PreviewText = TruncatePreview(body)
+			return nil
+		}
+	}
+
+	card := KanbanCard{FilePath: path, ListName: listDir, Metadata: meta, PreviewText: TruncatePreview(body)}
+	bfs.state.Lists[listDir] = insertSortedCards(cards, card)
+	return nil
+}
+
+func (f *boardCardFile) Read(p []byte) (int, error)                   { return 0, os.ErrInvalid }
+func (f *boardCardFile) Seek(offset int64, whence int) (int64, error) { return 0, os.ErrInvalid }
+func (f *boardCardFile) Readdir(count int) ([]os.FileInfo, error)     { return nil, os.ErrInvalid }
+func (f *boardCardFile) Stat() (os.FileInfo, error)                   { return f.bfs.state.Fs.Stat(f.path) }
@@ -0,0 +1,187 @@
+package daedalus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/webdav"
+)
+
+// setupBoardFSTest builds a temp board with two lists ("todo" and "done", "todo" locked) each
+// holding one card, and returns the BoardState alongside an httptest.Server fronting a
+// BoardFileSystem for it.
+func setupBoardFSTest(t *testing.T) (state *BoardState, srv *httptest.Server) {
+	t.Helper()
+	root := t.TempDir()
+
+	for _, dir := range []string{"todo", "done"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(root, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card One"}, "original body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(root, "done", "2.md"), CardMetadata{ID: 2, Title: "Card Two"}, "done body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	config := &BoardConfig{Lists: []ListEntry{{Dir: "todo", Locked: true}, {Dir: "done"}}}
+	if err := SaveBoardConfig(context.Background(), root, config); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	state, err := ScanBoard(context.Background(), root)
+	if err != nil {
+		t.Fatalf("scan board: %v", err)
+	}
+
+	srv = httptest.NewServer(&webdav.Handler{
+		FileSystem: NewBoardFileSystem(state),
+		LockSystem: webdav.NewMemLS(),
+	})
+	t.Cleanup(srv.Close)
+	return state, srv
+}
+
+func doRequest(t *testing.T, srv *httptest.Server, method, path string, headers map[string]string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// A GET for a path that climbs out of the board root must be rejected, mirroring
+// App.validatePath's traversal check.
+func TestBoardFileSystem_PathTraversalRejected(t *testing.T) {
+	_, srv := setupBoardFSTest(t)
+
+	resp := doRequest(t, srv, "GET", "/../../etc/passwd", nil)
+	if resp.StatusCode == http.StatusOK {
+		t.Fatalf("expected traversal to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+// PUTting a card into a locked list must be rejected without touching the file on disk.
+func TestBoardFileSystem_LockedListRejectsWrite(t *testing.T) {
+	state, srv := setupBoardFSTest(t)
+
+	req, _ := http.NewRequest("PUT", srv.URL+"/todo/1.md", strings.NewReader("---\nid: 1\ntitle: edited\n---\nedited body\n"))
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusNoContent {
+		t.Fatalf("expected locked list write to be rejected, got %d", resp.StatusCode)
+	}
+	if state.Lists["todo"][0].Metadata.Title != "Card One" {
+		t.Errorf("locked list card should be unchanged, got title %q", state.Lists["todo"][0].Metadata.Title)
+	}
+}
+
+// MOVEing a card out of a locked list must be rejected.
+func TestBoardFileSystem_LockedListRejectsMove(t *testing.T) {
+	_, srv := setupBoardFSTest(t)
+
+	req, _ := http.NewRequest("MOVE", srv.URL+"/todo/1.md", nil)
+	req.Header.Set("Destination", srv.URL+"/done/1.md")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusNoContent {
+		t.Fatalf("expected move out of locked list to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+// A MOVE into a locked list is rejected and leaves the card in its source list's in-memory
+// state, while a MOVE that renames the card within its own unlocked list succeeds on disk.
+func TestBoardFileSystem_MoveIntoLockedListRejected_RenameWithinListSucceeds(t *testing.T) {
+	state, srv := setupBoardFSTest(t)
+
+	req, _ := http.NewRequest("MOVE", srv.URL+"/done/2.md", nil)
+	req.Header.Set("Destination", srv.URL+"/todo/2.md")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	resp.Body.Close()
+
+	// todo is locked, so the move must have been rejected and state left unchanged.
+	if _, ok := state.Lists["done"]; !ok {
+		t.Fatalf("done list missing from state")
+	}
+	found := false
+	for _, card := range state.Lists["done"] {
+		if card.Metadata.ID == 2 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("card 2 should still be in done since todo is locked")
+	}
+
+	// Now rename it within done, the unlocked list, instead.
+	req2, _ := http.NewRequest("MOVE", srv.URL+"/done/2.md", nil)
+	req2.Header.Set("Destination", srv.URL+"/done/3.md")
+	resp2, err := srv.Client().Do(req2)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	resp2.Body.Close()
+	if resp2.StatusCode != http.StatusCreated {
+		t.Fatalf("expected rename within done to succeed, got %d", resp2.StatusCode)
+	}
+	if _, err := os.Stat(filepath.Join(state.RootPath, "done", "3.md")); err != nil {
+		t.Errorf("expected renamed card file to exist: %v", err)
+	}
+}
+
+// COPYing a card to a new file name in its list creates it there and tracks it in that list's
+// in-memory state, without removing the original.
+func TestBoardFileSystem_CopyAddsToTargetList(t *testing.T) {
+	state, srv := setupBoardFSTest(t)
+
+	req, _ := http.NewRequest("COPY", srv.URL+"/done/2.md", nil)
+	req.Header.Set("Destination", srv.URL+"/done/4.md")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected copy to succeed, got %d", resp.StatusCode)
+	}
+
+	foundOriginal, foundCopy := false, false
+	for _, card := range state.Lists["done"] {
+		if card.Metadata.ID == 2 {
+			foundOriginal = true
+		}
+		if card.FilePath == filepath.Join(state.RootPath, "done", "4.md") {
+			foundCopy = true
+		}
+	}
+	if !foundOriginal {
+		t.Errorf("original card should remain after copy")
+	}
+	if !foundCopy {
+		t.Errorf("copied card should be tracked in done list state")
+	}
+}
@@ -0,0 +1,158 @@
+package daedalus
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// BoardEventType classifies one change BoardEvent reports from WatchBoard.
+type BoardEventType string
+
+const (
+	CardCreated  BoardEventType = "card_created"
+	CardModified BoardEventType = "card_modified"
+	CardDeleted  BoardEventType = "card_deleted"
+	ListAdded    BoardEventType = "list_added"
+	ListRemoved  BoardEventType = "list_removed"
+)
+
+// BoardEvent is one external change WatchBoard detected under a board root. Metadata is the
+// re-parsed frontmatter for CardCreated/CardModified, and nil for CardDeleted/ListAdded/
+// ListRemoved, since there's nothing left on disk to parse by the time those are reported.
+type BoardEvent struct {
+	Type     BoardEventType
+	Path     string
+	List     string
+	Metadata *CardMetadata
+}
+
+// boardWatchState is WatchBoard's view of the board as of its last scan, used to tell a create
+// from a modify from a delete out of FileWatcher's plain "these paths changed" callback.
+type boardWatchState struct {
+	cardModTimes map[string]int64
+	listDirs     map[string]bool
+}
+
+// WatchBoard watches the list directories under root for external changes -- edits made in
+// another editor, or files synced in from elsewhere -- and emits a typed BoardEvent for each
+// one on the returned channel. It reuses FileWatcher's existing fsnotify-preferring, debounced
+// backend (so atomic-rename editors like vim and VS Code are handled the same way the rest of
+// the app already relies on), and applies the same hidden-directory and non-.md filtering as
+// ScanBoard. The channel is closed, and the underlying watcher stopped, when ctx is done.
+func WatchBoard(ctx context.Context, root string) (<-chan BoardEvent, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan BoardEvent, 32)
+	state := snapshotBoardWatchState(absRoot)
+
+	var fw *FileWatcher
+	fw = NewFileWatcher(absRoot, func(paths []string) {
+		for _, path := range paths {
+			emitBoardEvents(absRoot, path, state, events)
+		}
+	})
+
+	go func() {
+		<-ctx.Done()
+		fw.Close()
+		close(events)
+	}()
+
+	return events, nil
+}
+
+// snapshotBoardWatchState records every card file's current mod time and every watchable list
+// directory currently present, so the next change callback can classify what kind of change
+// happened to a given path.
+func snapshotBoardWatchState(rootPath string) *boardWatchState {
+	state := &boardWatchState{
+		cardModTimes: make(map[string]int64),
+		listDirs:     make(map[string]bool),
+	}
+
+	entries, err := os.ReadDir(rootPath)
+	if err != nil {
+		slog.Warn("watch board: failed to read root dir", "path", rootPath, "error", err)
+		return state
+	}
+
+	for _, entry := range entries {
+		if !isWatchableListDir(entry) {
+			continue
+		}
+		listDir := filepath.Join(rootPath, entry.Name())
+		state.listDirs[listDir] = true
+
+		files, err := os.ReadDir(listDir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".md") {
+				continue
+			}
+			if info, err := file.Info(); err == nil {
+				state.cardModTimes[filepath.Join(listDir, file.Name())] = info.ModTime().UnixNano()
+			}
+		}
+	}
+	return state
+}
+
+// emitBoardEvents classifies a single changed path against state (updating it in place) and
+// sends the resulting BoardEvent(s), if any, to events.
+func emitBoardEvents(rootPath, path string, state *boardWatchState, events chan<- BoardEvent) {
+	info, statErr := os.Stat(path)
+
+	if filepath.Dir(path) == rootPath {
+		// A direct child of the root: only list directories are interesting here, filtered by
+		// name the same way isWatchableListDir filters them for the initial snapshot.
+		if name := filepath.Base(path); strings.HasPrefix(name, ".") || name == "assets" {
+			return
+		}
+		existed := state.listDirs[path]
+		switch {
+		case statErr == nil && info.IsDir() && !existed:
+			state.listDirs[path] = true
+			events <- BoardEvent{Type: ListAdded, Path: path, List: filepath.Base(path)}
+		case statErr != nil && existed:
+			delete(state.listDirs, path)
+			events <- BoardEvent{Type: ListRemoved, Path: path, List: filepath.Base(path)}
+		}
+		return
+	}
+
+	if !strings.HasSuffix(path, ".md") {
+		return
+	}
+	list := filepath.Base(filepath.Dir(path))
+	_, wasKnown := state.cardModTimes[path]
+
+	if statErr != nil {
+		if wasKnown {
+			delete(state.cardModTimes, path)
+			events <- BoardEvent{Type: CardDeleted, Path: path, List: list}
+		}
+		return
+	}
+
+	state.cardModTimes[path] = info.ModTime().UnixNano()
+	meta, _, err := ReadCardFileFs(afero.NewOsFs(), path)
+	if err != nil {
+		slog.Warn("watch board: failed to parse changed card", "path", path, "error", err)
+		return
+	}
+	if wasKnown {
+		events <- BoardEvent{Type: CardModified, Path: path, List: list, Metadata: &meta}
+	} else {
+		events <- BoardEvent{Type: CardCreated, Path: path, List: list, Metadata: &meta}
+	}
+}
@@ -0,0 +1,112 @@
+package daedalus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// emitBoardEvents should report a create, then a modify, then a delete for the same card path,
+// driven directly against the real filesystem the same way TestFileWatcher_PollingReportsChangedPaths
+// drives FileWatcher's polling backend -- this avoids any dependency on fsnotify delivery timing.
+func TestEmitBoardEvents_CardCreateModifyDelete(t *testing.T) {
+	root := t.TempDir()
+	listDir := filepath.Join(root, "todo")
+	if err := os.MkdirAll(listDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cardPath := filepath.Join(listDir, "1.md")
+
+	state := snapshotBoardWatchState(root)
+	events := make(chan BoardEvent, 4)
+
+	if err := os.WriteFile(cardPath, []byte("---\nid: 1\ntitle: One\nlist_order: 0\n---\nbody\n"), 0644); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	emitBoardEvents(root, cardPath, state, events)
+	assertNextEvent(t, events, CardCreated, cardPath)
+
+	if err := os.WriteFile(cardPath, []byte("---\nid: 1\ntitle: One edited\nlist_order: 0\n---\nbody2\n"), 0644); err != nil {
+		t.Fatalf("rewrite card: %v", err)
+	}
+	emitBoardEvents(root, cardPath, state, events)
+	got := assertNextEvent(t, events, CardModified, cardPath)
+	if got.Metadata == nil || got.Metadata.Title != "One edited" {
+		t.Fatalf("expected re-parsed metadata with updated title, got %+v", got.Metadata)
+	}
+
+	if err := os.Remove(cardPath); err != nil {
+		t.Fatalf("remove card: %v", err)
+	}
+	emitBoardEvents(root, cardPath, state, events)
+	assertNextEvent(t, events, CardDeleted, cardPath)
+}
+
+// A card file moved (renamed) into a different list directory should surface as a delete at its
+// old path and a create at its new one, since that's what two atomic-rename editors watching
+// different directories actually observe on disk.
+func TestEmitBoardEvents_CardMovedBetweenLists(t *testing.T) {
+	root := t.TempDir()
+	todoDir := filepath.Join(root, "todo")
+	doneDir := filepath.Join(root, "done")
+	if err := os.MkdirAll(todoDir, 0755); err != nil {
+		t.Fatalf("mkdir todo: %v", err)
+	}
+	if err := os.MkdirAll(doneDir, 0755); err != nil {
+		t.Fatalf("mkdir done: %v", err)
+	}
+	oldPath := filepath.Join(todoDir, "1.md")
+	newPath := filepath.Join(doneDir, "1.md")
+	if err := os.WriteFile(oldPath, []byte("---\nid: 1\ntitle: Moving\nlist_order: 0\n---\nbody\n"), 0644); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+
+	state := snapshotBoardWatchState(root)
+	events := make(chan BoardEvent, 4)
+
+	if err := os.Rename(oldPath, newPath); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	emitBoardEvents(root, oldPath, state, events)
+	emitBoardEvents(root, newPath, state, events)
+
+	assertNextEvent(t, events, CardDeleted, oldPath)
+	got := assertNextEvent(t, events, CardCreated, newPath)
+	if got.List != "done" {
+		t.Errorf("expected new event's List to be \"done\", got %q", got.List)
+	}
+}
+
+// Adding and then removing a list directory should surface as ListAdded/ListRemoved.
+func TestEmitBoardEvents_ListAddedAndRemoved(t *testing.T) {
+	root := t.TempDir()
+	state := snapshotBoardWatchState(root)
+	events := make(chan BoardEvent, 4)
+
+	archiveDir := filepath.Join(root, "archive")
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	emitBoardEvents(root, archiveDir, state, events)
+	assertNextEvent(t, events, ListAdded, archiveDir)
+
+	if err := os.RemoveAll(archiveDir); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	emitBoardEvents(root, archiveDir, state, events)
+	assertNextEvent(t, events, ListRemoved, archiveDir)
+}
+
+func assertNextEvent(t *testing.T, events chan BoardEvent, wantType BoardEventType, wantPath string) BoardEvent {
+	t.Helper()
+	select {
+	case got := <-events:
+		if got.Type != wantType || got.Path != wantPath {
+			t.Fatalf("expected event {%s %s}, got %+v", wantType, wantPath, got)
+		}
+		return got
+	default:
+		t.Fatalf("expected an event of type %s for %s, got none", wantType, wantPath)
+		return BoardEvent{}
+	}
+}
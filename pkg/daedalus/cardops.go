@@ -1,12 +1,15 @@
 package daedalus
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/spf13/afero"
 )
 
 // ComputeInsertPosition determines list_order and insertion index for a new card.
@@ -46,10 +49,21 @@ func InsertSorted(cards []KanbanCard, card KanbanCard) []KanbanCard {
 	return cards
 }
 
-// CreateCardOnDisk computes the new card ID, builds metadata, writes the file to disk,
+// CreateCardOnDisk computes the new card ID, builds metadata, writes the file to the real
+// OS filesystem, and returns the metadata, file path, and insertion index. The caller is
+// responsible for updating any in-memory state.
+func CreateCardOnDisk(
+	boardPath, listDir, title, body, position string,
+	cards []KanbanCard, maxID int,
+) (CardMetadata, string, int, error) {
+	return CreateCardOnDiskFs(afero.NewOsFs(), boardPath, listDir, title, body, position, cards, maxID)
+}
+
+// CreateCardOnDiskFs computes the new card ID, builds metadata, writes the file via fs,
 // and returns the metadata, file path, and insertion index. The caller is responsible
 // for updating any in-memory state.
-func CreateCardOnDisk(
+func CreateCardOnDiskFs(
+	fs afero.Fs,
 	boardPath, listDir, title, body, position string,
 	cards []KanbanCard, maxID int,
 ) (CardMetadata, string, int, error) {
@@ -73,9 +87,108 @@ func CreateCardOnDisk(
 	fullBody := fmt.Sprintf("# %s\n\n%s", title, body)
 	filePath := filepath.Join(boardPath, listDir, fmt.Sprintf("%d.md", newID))
 
-	if err := WriteCardFile(filePath, meta, fullBody); err != nil {
+	if err := WriteCardFileFs(context.Background(), fs, filePath, meta, fullBody); err != nil {
 		return CardMetadata{}, "", 0, fmt.Errorf("writing card file: %w", err)
 	}
 
 	return meta, filePath, insertIdx, nil
 }
+
+// MoveCardOnDisk moves card into destListDir at position, renaming its file if destListDir
+// differs from its current list directory, and returns its updated metadata and file path. The
+// caller is responsible for updating any in-memory state.
+func MoveCardOnDisk(boardPath, destListDir string, card KanbanCard, destCards []KanbanCard, position string) (CardMetadata, string, error) {
+	return MoveCardOnDiskFs(afero.NewOsFs(), boardPath, destListDir, card, destCards, position)
+}
+
+// MoveCardOnDiskFs is MoveCardOnDisk against an injected afero.Fs.
+func MoveCardOnDiskFs(fs afero.Fs, boardPath, destListDir string, card KanbanCard, destCards []KanbanCard, position string) (CardMetadata, string, error) {
+	listOrder, _ := ComputeInsertPosition(destCards, position)
+
+	body, err := ReadCardContentFs(context.Background(), fs, card.FilePath)
+	if err != nil {
+		return CardMetadata{}, "", fmt.Errorf("reading card body: %w", err)
+	}
+
+	meta := card.Metadata
+	meta.ListOrder = listOrder
+	now := time.Now()
+	meta.Updated = &now
+
+	destPath := filepath.Join(boardPath, destListDir, fmt.Sprintf("%d.md", meta.ID))
+
+	if err := WriteCardFileFs(context.Background(), fs, destPath, meta, body); err != nil {
+		return CardMetadata{}, "", fmt.Errorf("writing moved card file: %w", err)
+	}
+	if destPath != card.FilePath {
+		if err := fs.Remove(card.FilePath); err != nil {
+			return CardMetadata{}, "", fmt.Errorf("removing card from old list: %w", err)
+		}
+	}
+
+	return meta, destPath, nil
+}
+
+// CardUpdate describes the fields cmdCardUpdate may change on an existing card. A nil Title
+// leaves the title unchanged; AddLabels/RemoveLabels are applied in that order against the
+// card's existing Labels.
+type CardUpdate struct {
+	Title        *string
+	AddLabels    []string
+	RemoveLabels []string
+}
+
+// UpdateCardOnDisk applies update to card's metadata and re-writes its file in place, returning
+// the updated metadata. The caller is responsible for updating any in-memory state.
+func UpdateCardOnDisk(card KanbanCard, update CardUpdate) (CardMetadata, error) {
+	return UpdateCardOnDiskFs(afero.NewOsFs(), card, update)
+}
+
+// UpdateCardOnDiskFs is UpdateCardOnDisk against an injected afero.Fs.
+func UpdateCardOnDiskFs(fs afero.Fs, card KanbanCard, update CardUpdate) (CardMetadata, error) {
+	body, err := ReadCardContentFs(context.Background(), fs, card.FilePath)
+	if err != nil {
+		return CardMetadata{}, fmt.Errorf("reading card body: %w", err)
+	}
+
+	meta := card.Metadata
+	if update.Title != nil {
+		meta.Title = *update.Title
+	}
+	meta.Labels = applyLabelEdits(meta.Labels, update.AddLabels, update.RemoveLabels)
+	now := time.Now()
+	meta.Updated = &now
+
+	if err := WriteCardFileFs(context.Background(), fs, card.FilePath, meta, body); err != nil {
+		return CardMetadata{}, fmt.Errorf("writing updated card file: %w", err)
+	}
+	return meta, nil
+}
+
+// applyLabelEdits adds each of add then removes each of remove from labels, without duplicating
+// a label that's already present.
+func applyLabelEdits(labels []string, add, remove []string) []string {
+	result := append([]string(nil), labels...)
+	for _, l := range add {
+		found := false
+		for _, existing := range result {
+			if existing == l {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, l)
+		}
+	}
+	for _, l := range remove {
+		filtered := result[:0]
+		for _, existing := range result {
+			if existing != l {
+				filtered = append(filtered, existing)
+			}
+		}
+		result = filtered
+	}
+	return result
+}
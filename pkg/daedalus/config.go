@@ -1,11 +1,15 @@
 package daedalus
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
+	"github.com/spf13/afero"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,39 +37,171 @@ type BoardConfig struct {
 	DarkMode         *bool             `yaml:"dark_mode,omitempty" json:"darkMode,omitempty"`
 	MinimalView      *bool             `yaml:"minimal_view,omitempty" json:"minimalView,omitempty"`
 	Zoom             *float64          `yaml:"zoom,omitempty" json:"zoom,omitempty"`
+	WebDAV           *WebDAVConfig     `yaml:"webdav,omitempty" json:"webdav,omitempty"`
+	Storage          *StorageConfig    `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Backup           *BackupConfig     `yaml:"backup,omitempty" json:"backup,omitempty"`
+	Sign             *bool             `yaml:"sign,omitempty" json:"sign,omitempty"`
+	Encrypt          string            `yaml:"encrypt,omitempty" json:"encrypt,omitempty"`
+	Archived         []ArchivedList    `yaml:"archived,omitempty" json:"archived,omitempty"`
+	Tokens           []TokenEntry      `yaml:"tokens,omitempty" json:"tokens,omitempty"`
+	LoadTimeoutMs    int               `yaml:"load_timeout_ms,omitempty" json:"loadTimeoutMs,omitempty"`
+	Sync             *SyncConfig       `yaml:"sync,omitempty" json:"sync,omitempty"`
+	Slug             *SlugConfig       `yaml:"slug,omitempty" json:"slug,omitempty"`
 }
 
-// LoadBoardConfig reads board.yaml from rootPath. Returns empty config if file is missing.
+// SlugConfig controls how ValidateListName sanitizes new list directory names (see
+// pkg/daedalus/slug). A nil Slug leaves names NFC-normalized and stripped of
+// slug.DefaultDisallowed only -- case and accents pass through untouched, so upgrading to a
+// version of daedalus with this config never rewrites an existing board's directory names
+// underneath it.
+type SlugConfig struct {
+	RemoveAccents bool `yaml:"removeAccents,omitempty" json:"removeAccents,omitempty"`
+	Lower         bool `yaml:"lower,omitempty" json:"lower,omitempty"`
+}
+
+// TokenEntry is a bearer token accepted by the headless HTTP/JSON API (`daedalus serve`),
+// scoped to either read-only or read+write access. A board with no Tokens configured rejects
+// every API request, the same "misconfigured, not open" default WebDAVConfig uses.
+type TokenEntry struct {
+	Token string `yaml:"token" json:"token"`
+	Scope string `yaml:"scope,omitempty" json:"scope,omitempty"` // "read" (default) or "write"
+}
+
+// ArchivedList records a list that was soft-deleted via ArchiveListFs instead of removed
+// outright, so its cards can be reviewed or restored later (see UnarchiveListFs) instead of
+// destroyed on an accidental delete click.
+type ArchivedList struct {
+	Dir          string    `yaml:"dir" json:"dir"`                    // path under <root>/.archive
+	OriginalName string    `yaml:"original_name" json:"originalName"` // list dir name to restore to
+	ArchivedAt   time.Time `yaml:"archived_at" json:"archivedAt"`
+	CardCount    int       `yaml:"card_count" json:"cardCount"`
+	Bytes        int64     `yaml:"bytes" json:"bytes"`
+}
+
+// BackupConfig opts a board into automatic pre-destructive snapshots (see AutoSnapshot),
+// keeping only the Keep most recent ones. A nil Backup leaves auto-snapshotting off.
+type BackupConfig struct {
+	Keep int `yaml:"keep,omitempty" json:"keep,omitempty"`
+}
+
+// StorageConfig selects the afero.Fs backend ScanBoard mounts the board's lists, cards, and
+// config onto (see OpenStorageFs). Driver "" or "local" (the default) is the OS filesystem
+// rooted at the board directory itself, so most boards never set this at all.
+type StorageConfig struct {
+	Driver             string `yaml:"driver,omitempty" json:"driver,omitempty"` // "local" (default), "s3", or "sftp"
+	Bucket             string `yaml:"bucket,omitempty" json:"bucket,omitempty"` // s3 only
+	Prefix             string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	Region             string `yaml:"region,omitempty" json:"region,omitempty"` // s3 only
+	Endpoint           string `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	Username           string `yaml:"username,omitempty" json:"username,omitempty"`                     // sftp only
+	Password           string `yaml:"password,omitempty" json:"password,omitempty"`                     // sftp only
+	KnownHostsFile     string `yaml:"knownHostsFile,omitempty" json:"knownHostsFile,omitempty"`         // sftp only; path to an OpenSSH known_hosts file
+	HostKeyFingerprint string `yaml:"hostKeyFingerprint,omitempty" json:"hostKeyFingerprint,omitempty"` // sftp only; ssh.FingerprintSHA256 form, e.g. "SHA256:abcd...", used if KnownHostsFile is unset
+}
+
+// WebDAVConfig holds HTTP basic-auth credentials for serving this board over WebDAV (see
+// pkg/daedalus/webdav). Both fields must be set for the server to accept any request.
+type WebDAVConfig struct {
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+}
+
+// SyncConfig opts a board into background replication against a remote (see Syncer). Remote
+// reuses StorageConfig rather than inventing a second remote-location shape, so a sync peer is
+// configured exactly like a storage backend -- an S3 bucket, an SFTP host, or another daedalus
+// instance's shared mount. A nil Sync leaves the board local-only, the default for every
+// existing board.
+type SyncConfig struct {
+	Remote           *StorageConfig `yaml:"remote,omitempty" json:"remote,omitempty"`
+	PollIntervalSecs int            `yaml:"poll_interval_secs,omitempty" json:"pollIntervalSecs,omitempty"`
+}
+
+// LoadBoardConfig reads board.yaml from rootPath on the real OS filesystem.
+// Returns empty config if file is missing.
 func LoadBoardConfig(rootPath string) (*BoardConfig, error) {
+	return LoadBoardConfigFs(afero.NewOsFs(), rootPath)
+}
+
+// LoadBoardConfigFs reads board.yaml from rootPath on the given filesystem. If the primary
+// file is missing or fails to parse, it falls back to the board.yaml.bak left by the previous
+// successful SaveBoardConfigFs rotation, logging a recovery event. Returns empty config if
+// neither file is present. If rootPath is laid out as a sharded board (see IsShardedFs), the
+// Lists array is transparently assembled from board.order and each list dir's .list.yaml
+// instead of board.yaml's own (by then empty) Lists field -- callers don't need to know or care
+// which layout is on disk.
+func LoadBoardConfigFs(fs afero.Fs, rootPath string) (*BoardConfig, error) {
+	config, err := loadBoardConfigMonolithicFs(fs, rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsShardedFs(fs, rootPath) {
+		lists, err := loadShardedListsFs(fs, rootPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading sharded list config: %w", err)
+		}
+		config.Lists = lists
+	}
+	return config, nil
+}
+
+// loadBoardConfigMonolithicFs reads global settings and (for a monolithic board) the Lists
+// array from board.yaml itself, falling back to board.yaml.bak per LoadBoardConfigFs's contract.
+func loadBoardConfigMonolithicFs(fs afero.Fs, rootPath string) (*BoardConfig, error) {
 	config := &BoardConfig{}
+	boardYamlPath := filepath.Join(rootPath, "board.yaml")
 
-	data, err := os.ReadFile(filepath.Join(rootPath, "board.yaml"))
+	data, err := afero.ReadFile(fs, boardYamlPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			slog.Debug("board.yaml not found, using empty config", "path", rootPath)
-			return config, nil
+			return loadBoardConfigBackupFs(fs, rootPath, config, "board.yaml not found")
 		}
 		slog.Error("failed to read board.yaml", "path", rootPath, "error", err)
 		return nil, err
 	}
 
 	if err := yaml.Unmarshal(data, config); err != nil {
-		slog.Error("failed to parse board.yaml", "path", rootPath, "error", err)
-		return nil, err
+		return loadBoardConfigBackupFs(fs, rootPath, &BoardConfig{}, fmt.Sprintf("board.yaml failed to parse: %v", err))
 	}
 
 	slog.Debug("board config loaded", "path", rootPath, "lists", len(config.Lists))
 	return config, nil
 }
 
-// SaveBoardConfig writes the config to board.yaml in rootPath.
-func SaveBoardConfig(rootPath string, config *BoardConfig) error {
+// loadBoardConfigBackupFs recovers from board.yaml.bak when the primary file is missing or
+// unreadable, logging why the fallback was needed.
+func loadBoardConfigBackupFs(fs afero.Fs, rootPath string, config *BoardConfig, reason string) (*BoardConfig, error) {
+	bakData, bakErr := afero.ReadFile(fs, filepath.Join(rootPath, "board.yaml.bak"))
+	if bakErr != nil {
+		slog.Debug("no board.yaml.bak to recover from, using empty config", "path", rootPath, "reason", reason)
+		return config, nil
+	}
+	if err := yaml.Unmarshal(bakData, config); err != nil {
+		slog.Error("board.yaml.bak also failed to parse, using empty config", "path", rootPath, "error", err)
+		return &BoardConfig{}, nil
+	}
+	slog.Warn("recovered board config from board.yaml.bak", "path", rootPath, "reason", reason)
+	return config, nil
+}
+
+// SaveBoardConfig writes the config to board.yaml in rootPath on the real OS filesystem.
+func SaveBoardConfig(ctx context.Context, rootPath string, config *BoardConfig) error {
+	return SaveBoardConfigFs(ctx, afero.NewOsFs(), rootPath, config)
+}
+
+// SaveBoardConfigFs writes the config to board.yaml in rootPath on the given filesystem.
+// The write is atomic: it lands in board.yaml.tmp, is fsynced, then renamed over board.yaml,
+// with the previous contents preserved as board.yaml.bak.
+func SaveBoardConfigFs(ctx context.Context, fs afero.Fs, rootPath string, config *BoardConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	data, err := yaml.Marshal(config)
 	if err != nil {
 		slog.Error("failed to marshal board config", "error", err)
 		return err
 	}
-	if err := os.WriteFile(filepath.Join(rootPath, "board.yaml"), data, 0644); err != nil {
+	if err := writeFileAtomicFs(fs, filepath.Join(rootPath, "board.yaml"), data, 0644); err != nil {
 		slog.Error("failed to write board.yaml", "path", rootPath, "error", err)
 		return err
 	}
@@ -73,8 +209,13 @@ func SaveBoardConfig(rootPath string, config *BoardConfig) error {
 	return nil
 }
 
-// FindListEntry returns the index of the entry with the given dir, or -1.
-func FindListEntry(lists []ListEntry, dir string) int {
+// FindListEntry returns the index of the entry with the given dir, or -1. It takes ctx purely
+// for consistency with the rest of the package's board-scoped operations; a lookup over an
+// already in-memory slice never blocks.
+func FindListEntry(ctx context.Context, lists []ListEntry, dir string) int {
+	if ctx.Err() != nil {
+		return -1
+	}
 	for i, entry := range lists {
 		if entry.Dir == dir {
 			return i
@@ -95,7 +236,7 @@ func InitBoardDir(path string) error {
 		slog.Error("failed to create board directory", "path", path, "error", err)
 		return err
 	}
-	if err := SaveBoardConfig(path, &BoardConfig{}); err != nil {
+	if err := SaveBoardConfig(context.Background(), path, &BoardConfig{}); err != nil {
 		slog.Error("failed to initialize board.yaml", "path", path, "error", err)
 		return err
 	}
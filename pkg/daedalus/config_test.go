@@ -1,6 +1,7 @@
 package daedalus
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -61,7 +62,7 @@ func TestBoardConfig_SaveRoundTrip(t *testing.T) {
 		},
 	}
 
-	if err := SaveBoardConfig(root, original); err != nil {
+	if err := SaveBoardConfig(context.Background(), root, original); err != nil {
 		t.Fatalf("save error: %v", err)
 	}
 
@@ -97,7 +98,7 @@ func TestBoardConfig_CollapseRoundTrip(t *testing.T) {
 		},
 	}
 
-	if err := SaveBoardConfig(root, original); err != nil {
+	if err := SaveBoardConfig(context.Background(), root, original); err != nil {
 		t.Fatalf("save error: %v", err)
 	}
 
@@ -128,13 +129,13 @@ func TestFindListEntry(t *testing.T) {
 		{Dir: "done"},
 	}
 
-	if idx := FindListEntry(lists, "wip"); idx != 1 {
+	if idx := FindListEntry(context.Background(), lists, "wip"); idx != 1 {
 		t.Errorf("expected index 1 for 'wip', got %d", idx)
 	}
-	if idx := FindListEntry(lists, "nonexistent"); idx != -1 {
+	if idx := FindListEntry(context.Background(), lists, "nonexistent"); idx != -1 {
 		t.Errorf("expected -1 for nonexistent, got %d", idx)
 	}
-	if idx := FindListEntry(lists, "open"); idx != 0 {
+	if idx := FindListEntry(context.Background(), lists, "open"); idx != 0 {
 		t.Errorf("expected index 0 for 'open', got %d", idx)
 	}
 }
@@ -153,7 +154,7 @@ func TestInitBoardDir_CreatesConfig(t *testing.T) {
 func TestInitBoardDir_SkipsExisting(t *testing.T) {
 	dir := t.TempDir()
 	cfg := &BoardConfig{Title: "My Board"}
-	if err := SaveBoardConfig(dir, cfg); err != nil {
+	if err := SaveBoardConfig(context.Background(), dir, cfg); err != nil {
 		t.Fatal(err)
 	}
 
@@ -235,7 +236,7 @@ func TestBoardConfig_ArrayOrderIsDisplayOrder(t *testing.T) {
 		},
 	}
 
-	if err := SaveBoardConfig(root, original); err != nil {
+	if err := SaveBoardConfig(context.Background(), root, original); err != nil {
 		t.Fatalf("save error: %v", err)
 	}
 
@@ -0,0 +1,125 @@
+package daedalus
+
+import (
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const configPollInterval = 2 * time.Second
+
+// ConfigChangeFuncs holds typed callbacks for individual board.yaml fields. ConfigWatcher
+// invokes whichever fields are non-nil when it detects that field changed between polls, so a
+// caller that only cares about, say, list order doesn't have to diff the whole BoardConfig
+// itself. A nil field is simply never called.
+type ConfigChangeFuncs struct {
+	OnTitleChanged       func(old, new string)
+	OnListsChanged       func(old, new []ListEntry)
+	OnLabelColorsChanged func(old, new map[string]string)
+}
+
+// ConfigWatcher polls a board's board.yaml for changes made outside the running process (a hand
+// edit, or another daedalus instance sharing the board) and fires typed callbacks for the fields
+// that actually changed, so a caller can hot-reload just the parts of its state that went stale
+// instead of rescanning the whole board.
+type ConfigWatcher struct {
+	fs          afero.Fs
+	rootPath    string
+	callbacks   ConfigChangeFuncs
+	done        chan struct{}
+	mu          sync.Mutex
+	current     *BoardConfig
+	pausedUntil time.Time
+}
+
+// NewConfigWatcher creates and starts a polling watcher for rootPath's board.yaml on the real
+// OS filesystem. initial is the config already loaded by the caller, used as the baseline for
+// the first diff.
+func NewConfigWatcher(rootPath string, initial *BoardConfig, callbacks ConfigChangeFuncs) *ConfigWatcher {
+	return NewConfigWatcherFs(afero.NewOsFs(), rootPath, initial, callbacks)
+}
+
+// NewConfigWatcherFs creates and starts a polling watcher for rootPath's board.yaml on fs.
+func NewConfigWatcherFs(fs afero.Fs, rootPath string, initial *BoardConfig, callbacks ConfigChangeFuncs) *ConfigWatcher {
+	cw := &ConfigWatcher{
+		fs:        fs,
+		rootPath:  rootPath,
+		callbacks: callbacks,
+		done:      make(chan struct{}),
+		current:   initial,
+	}
+	go cw.run()
+
+	slog.Info("config watcher started", "path", rootPath)
+	return cw
+}
+
+// Close stops the config watcher.
+func (cw *ConfigWatcher) Close() {
+	close(cw.done)
+	slog.Info("config watcher stopped")
+}
+
+// Pause suppresses callback delivery for d, so a caller that's about to write board.yaml itself
+// (via SaveBoardConfigFs) doesn't get notified of its own change on the next poll.
+func (cw *ConfigWatcher) Pause(d time.Duration) {
+	cw.mu.Lock()
+	cw.pausedUntil = time.Now().Add(d)
+	cw.mu.Unlock()
+}
+
+// run is the main polling loop.
+func (cw *ConfigWatcher) run() {
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cw.done:
+			return
+		case <-ticker.C:
+			cw.poll()
+		}
+	}
+}
+
+// poll reloads board.yaml and, unless currently paused, dispatches typed callbacks for any
+// fields that differ from the last known config.
+func (cw *ConfigWatcher) poll() {
+	next, err := LoadBoardConfigFs(cw.fs, cw.rootPath)
+	if err != nil {
+		slog.Warn("config watcher: failed to reload board.yaml", "path", cw.rootPath, "error", err)
+		return
+	}
+
+	cw.mu.Lock()
+	prev := cw.current
+	cw.current = next
+	paused := time.Now().Before(cw.pausedUntil)
+	cw.mu.Unlock()
+
+	if prev == nil || paused {
+		return
+	}
+	cw.dispatch(prev, next)
+}
+
+// dispatch compares prev and next field-by-field and fires the matching typed callback for
+// anything that changed.
+func (cw *ConfigWatcher) dispatch(prev, next *BoardConfig) {
+	if cw.callbacks.OnTitleChanged != nil && prev.Title != next.Title {
+		slog.Debug("config watcher: title changed", "old", prev.Title, "new", next.Title)
+		cw.callbacks.OnTitleChanged(prev.Title, next.Title)
+	}
+	if cw.callbacks.OnListsChanged != nil && !reflect.DeepEqual(prev.Lists, next.Lists) {
+		slog.Debug("config watcher: lists changed", "oldCount", len(prev.Lists), "newCount", len(next.Lists))
+		cw.callbacks.OnListsChanged(prev.Lists, next.Lists)
+	}
+	if cw.callbacks.OnLabelColorsChanged != nil && !reflect.DeepEqual(prev.LabelColors, next.LabelColors) {
+		slog.Debug("config watcher: label colors changed")
+		cw.callbacks.OnLabelColorsChanged(prev.LabelColors, next.LabelColors)
+	}
+}
@@ -0,0 +1,77 @@
+package daedalus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// poll should fire only the typed callback for the field that actually changed on disk.
+func TestConfigWatcher_PollFiresChangedFieldOnly(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	initial := &BoardConfig{Title: "Old Title", Lists: []ListEntry{{Dir: "todo"}}}
+	if err := SaveBoardConfigFs(context.Background(), fs, root, initial); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	var titleCalls, listsCalls, colorCalls int
+	cw := &ConfigWatcher{
+		fs:       fs,
+		rootPath: root,
+		current:  initial,
+		callbacks: ConfigChangeFuncs{
+			OnTitleChanged:       func(old, new string) { titleCalls++ },
+			OnListsChanged:       func(old, new []ListEntry) { listsCalls++ },
+			OnLabelColorsChanged: func(old, new map[string]string) { colorCalls++ },
+		},
+	}
+
+	// No change yet: poll should fire nothing.
+	cw.poll()
+	if titleCalls != 0 || listsCalls != 0 || colorCalls != 0 {
+		t.Fatalf("expected no callbacks before any change, got title=%d lists=%d colors=%d", titleCalls, listsCalls, colorCalls)
+	}
+
+	// Change only the title on disk.
+	updated := &BoardConfig{Title: "New Title", Lists: initial.Lists}
+	if err := SaveBoardConfigFs(context.Background(), fs, root, updated); err != nil {
+		t.Fatalf("save updated config: %v", err)
+	}
+	cw.poll()
+	if titleCalls != 1 {
+		t.Errorf("expected OnTitleChanged to fire once, got %d", titleCalls)
+	}
+	if listsCalls != 0 || colorCalls != 0 {
+		t.Errorf("expected unrelated callbacks not to fire, got lists=%d colors=%d", listsCalls, colorCalls)
+	}
+}
+
+// Pause should suppress callback delivery until the pause window elapses.
+func TestConfigWatcher_PausedPollSuppressesCallbacks(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	initial := &BoardConfig{Title: "Old Title"}
+	if err := SaveBoardConfigFs(context.Background(), fs, root, initial); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	var titleCalls int
+	cw := &ConfigWatcher{
+		fs:        fs,
+		rootPath:  root,
+		current:   initial,
+		callbacks: ConfigChangeFuncs{OnTitleChanged: func(old, new string) { titleCalls++ }},
+	}
+	cw.Pause(time.Minute)
+
+	if err := SaveBoardConfigFs(context.Background(), fs, root, &BoardConfig{Title: "New Title"}); err != nil {
+		t.Fatalf("save updated config: %v", err)
+	}
+	cw.poll()
+	if titleCalls != 0 {
+		t.Errorf("expected callback to be suppressed while paused, got %d calls", titleCalls)
+	}
+}
@@ -0,0 +1,215 @@
+package daedalus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// listConfigFileName is the per-list config file the sharded layout writes inside each list
+// directory (see SaveBoardConfigShardedFs).
+const listConfigFileName = ".list.yaml"
+
+// boardOrderFileName holds display order in sharded layout, one list dir per line. This is the
+// sharded equivalent of array order in a monolithic board.yaml's Lists field.
+const boardOrderFileName = "board.order"
+
+// IsShardedFs reports whether rootPath is laid out as per-list config files (board.order plus
+// each list dir's .list.yaml) rather than a single monolithic board.yaml with an embedded
+// Lists array.
+func IsShardedFs(fs afero.Fs, rootPath string) bool {
+	exists, _ := afero.Exists(fs, filepath.Join(rootPath, boardOrderFileName))
+	return exists
+}
+
+// SaveBoardConfigSharded writes config to rootPath in sharded form on the real OS filesystem.
+// See SaveBoardConfigShardedFs.
+func SaveBoardConfigSharded(rootPath string, config *BoardConfig) error {
+	return SaveBoardConfigShardedFs(afero.NewOsFs(), rootPath, config)
+}
+
+// SaveBoardConfigShardedFs persists config in sharded form: global settings (everything but
+// Lists) atomically to board.yaml as usual, each ListEntry atomically to
+// <rootPath>/<dir>/.list.yaml, and display order to board.order, one dir per line. Every write
+// lands via writeFileAtomicFs's temp-file-then-rename, and each touched directory is fsynced
+// afterward so the renames themselves survive a crash.
+func SaveBoardConfigShardedFs(fs afero.Fs, rootPath string, config *BoardConfig) error {
+	global := *config
+	global.Lists = nil
+	if err := SaveBoardConfigFs(context.Background(), fs, rootPath, &global); err != nil {
+		return err
+	}
+
+	var order strings.Builder
+	for _, entry := range config.Lists {
+		data, err := yaml.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("marshaling list entry %q: %w", entry.Dir, err)
+		}
+		listDir := filepath.Join(rootPath, entry.Dir)
+		if err := fs.MkdirAll(listDir, 0755); err != nil {
+			return fmt.Errorf("creating list directory %q: %w", entry.Dir, err)
+		}
+		if err := writeFileAtomicFs(fs, filepath.Join(listDir, listConfigFileName), data, 0644); err != nil {
+			return fmt.Errorf("writing %s for list %q: %w", listConfigFileName, entry.Dir, err)
+		}
+		fsyncDir(fs, listDir)
+		order.WriteString(entry.Dir)
+		order.WriteByte('\n')
+	}
+
+	if err := writeFileAtomicFs(fs, filepath.Join(rootPath, boardOrderFileName), []byte(order.String()), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", boardOrderFileName, err)
+	}
+	fsyncDir(fs, rootPath)
+
+	slog.Debug("board config saved (sharded)", "path", rootPath, "lists", len(config.Lists))
+	return nil
+}
+
+// SaveShardedListEntryFs rewrites a single list's .list.yaml and appends it to board.order if
+// it isn't already tracked there, without touching any other list's shard. Used by
+// saveListBoolFlags so a flag toggle on one list doesn't rewrite every list's file.
+func SaveShardedListEntryFs(fs afero.Fs, rootPath string, entry ListEntry) error {
+	data, err := yaml.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling list entry %q: %w", entry.Dir, err)
+	}
+	listDir := filepath.Join(rootPath, entry.Dir)
+	if err := fs.MkdirAll(listDir, 0755); err != nil {
+		return fmt.Errorf("creating list directory %q: %w", entry.Dir, err)
+	}
+	if err := writeFileAtomicFs(fs, filepath.Join(listDir, listConfigFileName), data, 0644); err != nil {
+		return fmt.Errorf("writing %s for list %q: %w", listConfigFileName, entry.Dir, err)
+	}
+	fsyncDir(fs, listDir)
+
+	order, err := loadOrderFs(fs, rootPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", boardOrderFileName, err)
+	}
+	for _, dir := range order {
+		if dir == entry.Dir {
+			return nil
+		}
+	}
+	order = append(order, entry.Dir)
+	if err := writeFileAtomicFs(fs, filepath.Join(rootPath, boardOrderFileName), []byte(strings.Join(order, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", boardOrderFileName, err)
+	}
+	fsyncDir(fs, rootPath)
+	return nil
+}
+
+// loadOrderFs reads board.order into a slice of list dir names, in file order.
+func loadOrderFs(fs afero.Fs, rootPath string) ([]string, error) {
+	data, err := afero.ReadFile(fs, filepath.Join(rootPath, boardOrderFileName))
+	if err != nil {
+		return nil, err
+	}
+	var order []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		if dir := strings.TrimSpace(scanner.Text()); dir != "" {
+			order = append(order, dir)
+		}
+	}
+	return order, nil
+}
+
+// loadShardedListsFs reads board.order and each listed dir's .list.yaml, in order. A dir named
+// in board.order with no readable .list.yaml is skipped with a warning rather than failing the
+// whole load, so one half-written shard doesn't take down the board.
+func loadShardedListsFs(fs afero.Fs, rootPath string) ([]ListEntry, error) {
+	order, err := loadOrderFs(fs, rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var lists []ListEntry
+	for _, dir := range order {
+		entryPath := filepath.Join(rootPath, dir, listConfigFileName)
+		data, err := afero.ReadFile(fs, entryPath)
+		if err != nil {
+			slog.Warn("sharded list config missing, skipping", "dir", dir, "error", err)
+			continue
+		}
+		var entry ListEntry
+		if err := yaml.Unmarshal(data, &entry); err != nil {
+			slog.Warn("sharded list config failed to parse, skipping", "dir", dir, "error", err)
+			continue
+		}
+		if entry.Dir == "" {
+			entry.Dir = dir
+		}
+		lists = append(lists, entry)
+	}
+	return lists, nil
+}
+
+// MigrateToSharded converts a board at rootPath from a monolithic board.yaml to the sharded
+// layout on the real OS filesystem. See MigrateToShardedFs.
+func MigrateToSharded(rootPath string) error {
+	return MigrateToShardedFs(afero.NewOsFs(), rootPath)
+}
+
+// MigrateToShardedFs is a one-shot conversion from monolithic to sharded layout: it loads the
+// current board.yaml (Lists and all), writes it back out sharded, and leaves the original
+// board.yaml.bak rotation from that write as the rollback path. A board that's already sharded
+// is left untouched.
+func MigrateToShardedFs(fs afero.Fs, rootPath string) error {
+	if IsShardedFs(fs, rootPath) {
+		slog.Debug("board already sharded, skipping migration", "path", rootPath)
+		return nil
+	}
+	config, err := LoadBoardConfigFs(fs, rootPath)
+	if err != nil {
+		return fmt.Errorf("loading monolithic config: %w", err)
+	}
+	if err := SaveBoardConfigShardedFs(fs, rootPath, config); err != nil {
+		return fmt.Errorf("writing sharded layout: %w", err)
+	}
+	slog.Info("board migrated to sharded config layout", "path", rootPath, "lists", len(config.Lists))
+	return nil
+}
+
+// MigrateToMonolithic converts a sharded board at rootPath back to a single board.yaml with an
+// embedded Lists array on the real OS filesystem. See MigrateToMonolithicFs.
+func MigrateToMonolithic(rootPath string) error {
+	return MigrateToMonolithicFs(afero.NewOsFs(), rootPath)
+}
+
+// MigrateToMonolithicFs is a one-shot conversion from sharded to monolithic layout: it loads the
+// current sharded config, writes it back out as a single board.yaml, then removes board.order
+// and every list-dir/.list.yaml. A board that's already monolithic is left untouched.
+func MigrateToMonolithicFs(fs afero.Fs, rootPath string) error {
+	if !IsShardedFs(fs, rootPath) {
+		slog.Debug("board already monolithic, skipping migration", "path", rootPath)
+		return nil
+	}
+	config, err := LoadBoardConfigFs(fs, rootPath)
+	if err != nil {
+		return fmt.Errorf("loading sharded config: %w", err)
+	}
+	if err := SaveBoardConfigFs(context.Background(), fs, rootPath, config); err != nil {
+		return fmt.Errorf("writing monolithic board.yaml: %w", err)
+	}
+
+	for _, entry := range config.Lists {
+		if err := fs.Remove(filepath.Join(rootPath, entry.Dir, listConfigFileName)); err != nil {
+			slog.Warn("failed to remove list shard during migration", "dir", entry.Dir, "error", err)
+		}
+	}
+	if err := fs.Remove(filepath.Join(rootPath, boardOrderFileName)); err != nil {
+		return fmt.Errorf("removing %s: %w", boardOrderFileName, err)
+	}
+
+	slog.Info("board migrated to monolithic config layout", "path", rootPath, "lists", len(config.Lists))
+	return nil
+}
@@ -0,0 +1,115 @@
+package daedalus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func shardedTestConfig() *BoardConfig {
+	return &BoardConfig{
+		Title: "My Board",
+		Lists: []ListEntry{
+			{Dir: "open", Title: "Open Items", Limit: 50},
+			{Dir: "wip", Limit: 5, Collapsed: true},
+		},
+	}
+}
+
+// Saving a board sharded should leave board.yaml with no Lists, a .list.yaml per list dir, and
+// a board.order recording display order.
+func TestSaveBoardConfigSharded_WritesShardFiles(t *testing.T) {
+	root := t.TempDir()
+	original := shardedTestConfig()
+
+	if err := SaveBoardConfigSharded(root, original); err != nil {
+		t.Fatalf("save sharded: %v", err)
+	}
+
+	if !IsShardedFs(afero.NewOsFs(), root) {
+		t.Fatalf("expected board to be detected as sharded")
+	}
+	for _, dir := range []string{"open", "wip"} {
+		if _, err := os.Stat(filepath.Join(root, dir, listConfigFileName)); err != nil {
+			t.Errorf("expected %s/%s to exist", dir, listConfigFileName)
+		}
+	}
+
+	monolithic, err := loadBoardConfigMonolithicFs(afero.NewOsFs(), root)
+	if err != nil {
+		t.Fatalf("load monolithic: %v", err)
+	}
+	if len(monolithic.Lists) != 0 {
+		t.Errorf("expected board.yaml Lists to be empty once sharded, got %d entries", len(monolithic.Lists))
+	}
+	if monolithic.Title != "My Board" {
+		t.Errorf("expected global settings preserved in board.yaml, got title %q", monolithic.Title)
+	}
+}
+
+// LoadBoardConfigFs should transparently assemble Lists from the shard files when a board is
+// sharded, without callers having to know the layout.
+func TestLoadBoardConfigFs_AssemblesShardedLists(t *testing.T) {
+	root := t.TempDir()
+	original := shardedTestConfig()
+	if err := SaveBoardConfigSharded(root, original); err != nil {
+		t.Fatalf("save sharded: %v", err)
+	}
+
+	loaded, err := LoadBoardConfig(root)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded.Lists) != 2 {
+		t.Fatalf("expected 2 list entries, got %d", len(loaded.Lists))
+	}
+	if loaded.Lists[0].Dir != "open" || loaded.Lists[0].Title != "Open Items" || loaded.Lists[0].Limit != 50 {
+		t.Errorf("unexpected lists[0]: %+v", loaded.Lists[0])
+	}
+	if loaded.Lists[1].Dir != "wip" || !loaded.Lists[1].Collapsed {
+		t.Errorf("unexpected lists[1]: %+v", loaded.Lists[1])
+	}
+}
+
+// MigrateToSharded then MigrateToMonolithic should round-trip a board's config exactly, and
+// each should be a no-op when the board is already in the target layout.
+func TestMigrateConfig_RoundTrip(t *testing.T) {
+	root := t.TempDir()
+	original := shardedTestConfig()
+	if err := SaveBoardConfig(context.Background(), root, original); err != nil {
+		t.Fatalf("save monolithic: %v", err)
+	}
+
+	if err := MigrateToSharded(root); err != nil {
+		t.Fatalf("migrate to sharded: %v", err)
+	}
+	if err := MigrateToSharded(root); err != nil {
+		t.Fatalf("second migrate to sharded should be a no-op, got error: %v", err)
+	}
+
+	sharded, err := LoadBoardConfig(root)
+	if err != nil {
+		t.Fatalf("load sharded: %v", err)
+	}
+	if len(sharded.Lists) != 2 {
+		t.Fatalf("expected 2 list entries after sharding, got %d", len(sharded.Lists))
+	}
+
+	if err := MigrateToMonolithic(root); err != nil {
+		t.Fatalf("migrate to monolithic: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, boardOrderFileName)); err == nil {
+		t.Errorf("expected %s to be removed after migrating to monolithic", boardOrderFileName)
+	}
+
+	monolithic, err := LoadBoardConfig(root)
+	if err != nil {
+		t.Fatalf("load monolithic: %v", err)
+	}
+	if len(monolithic.Lists) != 2 || monolithic.Lists[0].Dir != "open" || monolithic.Lists[1].Dir != "wip" {
+		t.Errorf("expected list order preserved through round trip, got %v", monolithic.Lists)
+	}
+}
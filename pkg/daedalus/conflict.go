@@ -0,0 +1,123 @@
+package daedalus
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// conflictStatePath returns the file a board's last-seen card version vectors are persisted to,
+// so LoadBoard (and MoveCard, in between loads) can tell a vector it has never seen apart from
+// one a concurrent device wrote since this device last looked.
+func conflictStatePath(rootPath string) string {
+	return filepath.Join(rootPath, ".daedalus", "state.json")
+}
+
+// VersionState is the last version vector this device observed for each card, keyed by its path
+// relative to the board root (see AppendOpLogFs for the same relative-path convention).
+type VersionState struct {
+	Versions map[string][]VersionEntry `json:"versions"`
+}
+
+// LoadVersionState reads a board's persisted version state, returning an empty VersionState if
+// the file doesn't exist yet (a fresh board, or one saved before this feature existed).
+func LoadVersionState(fs afero.Fs, rootPath string) (*VersionState, error) {
+	state := &VersionState{Versions: make(map[string][]VersionEntry)}
+	data, err := afero.ReadFile(fs, conflictStatePath(rootPath))
+	if err != nil {
+		if ok, _ := afero.Exists(fs, conflictStatePath(rootPath)); !ok {
+			return state, nil
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return state, nil
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing version state: %w", err)
+	}
+	if state.Versions == nil {
+		state.Versions = make(map[string][]VersionEntry)
+	}
+	return state, nil
+}
+
+// SaveVersionState persists a board's version state, creating .daedalus/ if needed.
+func SaveVersionState(fs afero.Fs, rootPath string, state *VersionState) error {
+	path := conflictStatePath(rootPath)
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating .daedalus dir: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling version state: %w", err)
+	}
+	if err := afero.WriteFile(fs, path, data, 0644); err != nil {
+		return fmt.Errorf("writing version state: %w", err)
+	}
+	return nil
+}
+
+// relToRoot normalizes path (absolute or already-relative) to a slash-separated path relative to
+// rootPath, matching the key convention VersionState.Versions is stored under.
+func relToRoot(rootPath, path string) string {
+	rel := path
+	if filepath.IsAbs(path) {
+		if r, err := filepath.Rel(rootPath, path); err == nil {
+			rel = r
+		}
+	}
+	return filepath.ToSlash(rel)
+}
+
+// RecordSeenVersion updates state in place with version as the last-seen vector for path,
+// persists it, and logs (without failing) if the write couldn't be persisted -- a missed write
+// here just means the next LoadBoard re-derives it from the card's on-disk vector instead.
+func RecordSeenVersion(fs afero.Fs, rootPath string, state *VersionState, path string, version []VersionEntry) {
+	if state.Versions == nil {
+		state.Versions = make(map[string][]VersionEntry)
+	}
+	state.Versions[relToRoot(rootPath, path)] = version
+	if err := SaveVersionState(fs, rootPath, state); err != nil {
+		slog.Warn("failed to persist card version state", "path", path, "error", err)
+	}
+}
+
+// ConflictError is returned by MoveCard (and any other mutation that checks version vectors)
+// when the card's on-disk version and the last version this device saw are concurrent -- each
+// has an edit the other doesn't, so neither can be silently preferred. Local is the vector this
+// device last saw (and would write if it proceeded); Remote is what's actually on disk now.
+type ConflictError struct {
+	Path   string
+	Local  []VersionEntry
+	Remote []VersionEntry
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflicting edits to %s: local and remote versions have diverged", e.Path)
+}
+
+// ReadCardFileFs reads and parses the card file at path, metadata and body together. It's a thin
+// exported wrapper around the package-internal readCardFileFs (see tx.go), for callers like
+// App.ResolveConflict in the main package that need a conflicting card's on-disk content.
+func ReadCardFileFs(fs afero.Fs, path string) (CardMetadata, string, error) {
+	return readCardFileFs(fs, path)
+}
+
+// DetectConflict reads the current on-disk version vector for the card at path and compares it
+// to lastSeen, the vector this device last recorded for it in state (see RecordSeenVersion). It
+// returns the on-disk vector (so the caller can BumpVersion from it), the last-seen vector, and
+// whether the two are concurrent -- meaning a remote device wrote an edit this device hasn't
+// incorporated yet, so this device's own edit can't just be written over it.
+func DetectConflict(fs afero.Fs, rootPath string, state *VersionState, path string) (onDisk []VersionEntry, lastSeen []VersionEntry, conflict bool, err error) {
+	meta, _, err := readCardFileFs(fs, path)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("reading card for conflict check: %w", err)
+	}
+	lastSeen = state.Versions[relToRoot(rootPath, path)]
+	conflict = CompareVersions(meta.Version, lastSeen) == VectorConcurrent
+	return meta.Version, lastSeen, conflict, nil
+}
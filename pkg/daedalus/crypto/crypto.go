@@ -0,0 +1,111 @@
+// Package crypto provides the OpenPGP primitives daedalus uses to sign and optionally
+// encrypt card content: detached signatures over a card's full file content (frontmatter +
+// body), and armored message encryption/decryption for card bodies. It is deliberately
+// fs-agnostic -- callers (see pkg/daedalus/sign.go) own loading keys from and writing sidecar
+// files to a board's keyring directory.
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// KeyInfo describes a single key in a board's keyring, as returned by daedalus key list.
+type KeyInfo struct {
+	ID       string `json:"id"`
+	Identity string `json:"identity"`
+	Private  bool   `json:"private"`
+}
+
+// ParseArmoredKey parses one ASCII-armored OpenPGP key (public or private) and returns its
+// Entity. It is an error for data to contain more than one key.
+func ParseArmoredKey(data []byte) (*openpgp.Entity, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing armored key: %w", err)
+	}
+	if len(keyring) != 1 {
+		return nil, fmt.Errorf("expected exactly one key, got %d", len(keyring))
+	}
+	return keyring[0], nil
+}
+
+// Info summarizes an entity for KeyInfo listing.
+func Info(entity *openpgp.Entity) KeyInfo {
+	info := KeyInfo{
+		ID:      entity.PrimaryKey.KeyIdShortString(),
+		Private: entity.PrivateKey != nil,
+	}
+	for _, identity := range entity.Identities {
+		info.Identity = identity.Name
+		break
+	}
+	return info
+}
+
+// SignDetached returns an ASCII-armored detached signature of data, signed by signer.
+func SignDetached(signer *openpgp.Entity, data []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, signer, bytes.NewReader(data), nil); err != nil {
+		return "", fmt.Errorf("signing: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// VerifyDetached checks an ASCII-armored detached signature of data against keyring, returning
+// nil if and only if the signature is valid and made by a key in keyring.
+func VerifyDetached(keyring openpgp.EntityList, data []byte, armoredSig string) error {
+	_, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(data), bytes.NewReader([]byte(armoredSig)))
+	if err != nil {
+		return fmt.Errorf("signature verification failed: %w", err)
+	}
+	return nil
+}
+
+// Encrypt returns plaintext encrypted as an ASCII-armored OpenPGP message for recipients.
+func Encrypt(recipients openpgp.EntityList, plaintext []byte) (string, error) {
+	var armored bytes.Buffer
+	armorWriter, err := armor.Encode(&armored, "PGP MESSAGE", nil)
+	if err != nil {
+		return "", fmt.Errorf("armoring message: %w", err)
+	}
+	cipherWriter, err := openpgp.Encrypt(armorWriter, recipients, nil, nil, nil)
+	if err != nil {
+		armorWriter.Close()
+		return "", fmt.Errorf("encrypting: %w", err)
+	}
+	if _, err := cipherWriter.Write(plaintext); err != nil {
+		cipherWriter.Close()
+		armorWriter.Close()
+		return "", fmt.Errorf("writing plaintext: %w", err)
+	}
+	if err := cipherWriter.Close(); err != nil {
+		armorWriter.Close()
+		return "", fmt.Errorf("finalizing ciphertext: %w", err)
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", fmt.Errorf("finalizing armor: %w", err)
+	}
+	return armored.String(), nil
+}
+
+// Decrypt decrypts an ASCII-armored OpenPGP message using a private key from keyring.
+func Decrypt(keyring openpgp.EntityList, armoredCiphertext string) ([]byte, error) {
+	block, err := armor.Decode(bytes.NewReader([]byte(armoredCiphertext)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding armor: %w", err)
+	}
+	msg, err := openpgp.ReadMessage(block.Body, keyring, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting: %w", err)
+	}
+	plaintext, err := io.ReadAll(msg.UnverifiedBody)
+	if err != nil {
+		return nil, fmt.Errorf("reading plaintext: %w", err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,119 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// armorPublicKey wraps a serialized public key in ASCII armor, the format ParseArmoredKey
+// and a real `daedalus key add` both expect.
+func armorPublicKey(t *testing.T, serialized []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, "PGP PUBLIC KEY BLOCK", nil)
+	if err != nil {
+		t.Fatalf("armor encode: %v", err)
+	}
+	if _, err := w.Write(serialized); err != nil {
+		t.Fatalf("armor write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("armor close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func newTestEntity(t *testing.T, name string) *openpgp.Entity {
+	t.Helper()
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+	if err != nil {
+		t.Fatalf("generating test key: %v", err)
+	}
+	return entity
+}
+
+// A detached signature made by an entity should verify against a keyring containing that
+// entity, and fail against one that doesn't.
+func TestSignAndVerifyDetached(t *testing.T) {
+	signer := newTestEntity(t, "Alice")
+	other := newTestEntity(t, "Mallory")
+	data := []byte("---\nid: 1\n---\nbody\n")
+
+	sig, err := SignDetached(signer, data)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	if err := VerifyDetached(openpgp.EntityList{signer}, data, sig); err != nil {
+		t.Fatalf("expected valid signature, got error: %v", err)
+	}
+	if err := VerifyDetached(openpgp.EntityList{other}, data, sig); err == nil {
+		t.Fatalf("expected verification against the wrong keyring to fail")
+	}
+}
+
+// Tampering with the signed data after the fact must flip verification to failure.
+func TestVerifyDetached_RejectsTamperedData(t *testing.T) {
+	signer := newTestEntity(t, "Alice")
+	data := []byte("---\nid: 1\n---\nbody\n")
+
+	sig, err := SignDetached(signer, data)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	tampered := append(bytes.Clone(data), []byte("extra")...)
+	if err := VerifyDetached(openpgp.EntityList{signer}, tampered, sig); err == nil {
+		t.Fatalf("expected verification of tampered data to fail")
+	}
+}
+
+// A message encrypted to a recipient should decrypt back to the original plaintext using
+// that recipient's private key, and fail to decrypt with an unrelated key.
+func TestEncryptAndDecrypt(t *testing.T) {
+	recipient := newTestEntity(t, "Bob")
+	other := newTestEntity(t, "Mallory")
+	plaintext := []byte("a secret card body")
+
+	ciphertext, err := Encrypt(openpgp.EntityList{recipient}, plaintext)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	decrypted, err := Decrypt(openpgp.EntityList{recipient}, ciphertext)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if string(decrypted) != string(plaintext) {
+		t.Fatalf("expected %q, got %q", plaintext, decrypted)
+	}
+
+	if _, err := Decrypt(openpgp.EntityList{other}, ciphertext); err == nil {
+		t.Fatalf("expected decryption with the wrong key to fail")
+	}
+}
+
+// ParseArmoredKey should round-trip Info's ID and Private fields through an armored public key
+// export.
+func TestParseArmoredKey(t *testing.T) {
+	entity := newTestEntity(t, "Alice")
+	var buf bytes.Buffer
+	if err := entity.Serialize(&buf); err != nil {
+		t.Fatalf("serialize: %v", err)
+	}
+
+	parsed, err := ParseArmoredKey(armorPublicKey(t, buf.Bytes()))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := Info(parsed)
+	if info.ID != entity.PrimaryKey.KeyIdShortString() {
+		t.Fatalf("expected ID %q, got %q", entity.PrimaryKey.KeyIdShortString(), info.ID)
+	}
+	if info.Private {
+		t.Fatalf("expected a public-only key to report Private=false")
+	}
+}
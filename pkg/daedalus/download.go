@@ -0,0 +1,76 @@
+package daedalus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ProgressFunc reports incremental download progress: read is the cumulative number of bytes
+// read so far, total is the response's Content-Length (0 if the server didn't send one, meaning
+// progress is indeterminate). Called after every chunk read, so it should be cheap -- a Wails
+// EventsEmit call or a channel send, not blocking work.
+type ProgressFunc func(read, total int64)
+
+// countingReader wraps an io.Reader and invokes cb after every Read with the cumulative bytes
+// read and the known total, so a caller can report download progress instead of blocking
+// silently until the whole body has been read.
+type countingReader struct {
+	r     io.Reader
+	read  int64
+	total int64
+	cb    ProgressFunc
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.read += int64(n)
+		if c.cb != nil {
+			c.cb(c.read, c.total)
+		}
+	}
+	return n, err
+}
+
+// downloadWithProgress issues a GET to rawURL through client and streams the response body into
+// dst, invoking cb after every chunk with cumulative bytes read and the response's
+// Content-Length. The download is capped at maxBytes via io.LimitReader and aborts as soon as
+// ctx is cancelled, since the request carries ctx through to every Read. This is the generic
+// streaming mechanism behind FetchIconWithProgress; a future "attach binary asset to card"
+// feature can call it directly with its own dst and cb instead of duplicating the
+// counting-reader/cancellation plumbing.
+func downloadWithProgress(ctx context.Context, client *http.Client, rawURL string, dst io.Writer, maxBytes int64, cb ProgressFunc) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("building request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("download returned status %d", resp.StatusCode)
+	}
+
+	total := resp.ContentLength
+	if total < 0 {
+		total = 0
+	}
+
+	counting := &countingReader{r: resp.Body, total: total, cb: cb}
+	written, err := io.Copy(dst, io.LimitReader(counting, maxBytes+1))
+	if err != nil {
+		if ctx.Err() != nil {
+			return written, ctx.Err()
+		}
+		return written, fmt.Errorf("reading response: %w", err)
+	}
+	if written > maxBytes {
+		return written, fmt.Errorf("download exceeds max size of %d bytes", maxBytes)
+	}
+	return written, nil
+}
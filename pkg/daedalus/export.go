@@ -2,9 +2,13 @@ package daedalus
 
 import (
 	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -36,23 +40,76 @@ type ExportIcon struct {
 	Content string `json:"content"`
 }
 
+// CurrentSchemaVersion is the export archive schema version written by this binary.
+// ImportJSON and ImportZip refuse archives whose SchemaVersion is newer than this.
+const CurrentSchemaVersion = 1
+
+// ExportErrorKind identifies what kind of item failed while building or writing an export.
+type ExportErrorKind string
+
+const (
+	ExportErrorCard ExportErrorKind = "card"
+	ExportErrorIcon ExportErrorKind = "icon"
+)
+
+// ExportErrorItem is a single per-item failure encountered during export.
+type ExportErrorItem struct {
+	Kind ExportErrorKind
+	Path string
+	Err  error
+}
+
+// ExportError aggregates per-item failures encountered while building or writing an export
+// archive. The archive is still produced best-effort with whatever items succeeded; callers
+// can inspect Items to decide whether a partial export is acceptable.
+type ExportError struct {
+	Items []ExportErrorItem
+}
+
+func (e *ExportError) Error() string {
+	if len(e.Items) == 1 {
+		item := e.Items[0]
+		return fmt.Sprintf("export: %s %s: %v", item.Kind, item.Path, item.Err)
+	}
+	return fmt.Sprintf("export: %d items failed (first: %s %s: %v)", len(e.Items), e.Items[0].Kind, e.Items[0].Path, e.Items[0].Err)
+}
+
+// add records a per-item failure.
+func (e *ExportError) add(kind ExportErrorKind, path string, err error) {
+	e.Items = append(e.Items, ExportErrorItem{Kind: kind, Path: path, Err: err})
+}
+
+// orNil returns e as an error if it recorded any items, or nil otherwise, so callers that
+// hit no failures still get a plain nil.
+func (e *ExportError) orNil() error {
+	if e == nil || len(e.Items) == 0 {
+		return nil
+	}
+	return e
+}
+
 // ExportBoard is the top-level export structure for a board.
 type ExportBoard struct {
-	Title      string       `json:"title"`
-	ExportedAt time.Time    `json:"exportedAt"`
-	Config     *BoardConfig `json:"config"`
-	Lists      []ExportList `json:"lists"`
-	Icons      []ExportIcon `json:"icons"`
+	SchemaVersion int          `json:"schemaVersion"`
+	Title         string       `json:"title"`
+	ExportedAt    time.Time    `json:"exportedAt"`
+	Config        *BoardConfig `json:"config"`
+	Lists         []ExportList `json:"lists"`
+	Icons         []ExportIcon `json:"icons"`
 }
 
-// BuildExportBoard walks the board state and builds an ExportBoard with full card bodies and icons.
-// iconsDir is the path to the board's _assets/icons/ directory.
+// BuildExportBoard walks the board state and builds an ExportBoard with full card bodies and
+// icons. iconsDir is the path to the board's _assets/icons/ directory. If any card body or
+// icon fails to read, the archive is still built best-effort and a non-nil *ExportError is
+// returned describing every item that was skipped.
 func BuildExportBoard(state *BoardState, iconsDir string) (ExportBoard, error) {
 	board := ExportBoard{
-		Title:      state.Config.Title,
-		ExportedAt: time.Now(),
-		Config:     state.Config,
+		SchemaVersion: CurrentSchemaVersion,
+		Title:         state.Config.Title,
+		ExportedAt:    time.Now(),
+		Config:        state.Config,
 	}
+	exportErr := &ExportError{}
 
 	// Build ordered list of lists from config (preserves display order).
 	for _, entry := range state.Config.Lists {
@@ -65,9 +122,10 @@ func BuildExportBoard(state *BoardState, iconsDir string) (ExportBoard, error) {
 			el.Title = entry.Dir
 		}
 		for _, card := range cards {
-			body, err := ReadCardContent(card.FilePath)
+			body, err := ReadCardContent(context.Background(), card.FilePath)
 			if err != nil {
 				slog.Warn("export: failed to read card body", "path", card.FilePath, "error", err)
+				exportErr.add(ExportErrorCard, card.FilePath, err)
 				body = ""
 			}
 			el.Cards = append(el.Cards, ExportCard{
@@ -81,14 +139,15 @@ func BuildExportBoard(state *BoardState, iconsDir string) (ExportBoard, error) {
 	}
 
 	// Collect icons from the icons directory.
-	board.Icons = readExportIcons(iconsDir)
+	board.Icons = readExportIcons(iconsDir, exportErr)
 
-	return board, nil
+	return board, exportErr.orNil()
 }
 
-// readExportIcons reads icon files from iconsDir and returns them as ExportIcon slices.
-// Returns nil if the directory doesn't exist.
-func readExportIcons(iconsDir string) []ExportIcon {
+// readExportIcons reads icon files from iconsDir and returns them as ExportIcon slices,
+// recording any per-icon read failures onto exportErr. Returns nil if the directory
+// doesn't exist.
+func readExportIcons(iconsDir string, exportErr *ExportError) []ExportIcon {
 	entries, err := os.ReadDir(iconsDir)
 	if err != nil {
 		return nil
@@ -107,6 +166,7 @@ func readExportIcons(iconsDir string) []ExportIcon {
 		content, err := readIconContent(filepath.Join(iconsDir, name))
 		if err != nil {
 			slog.Warn("export: failed to read icon", "name", name, "error", err)
+			exportErr.add(ExportErrorIcon, name, err)
 			continue
 		}
 		icons = append(icons, ExportIcon{Name: name, Content: content})
@@ -134,6 +194,86 @@ func readIconContent(path string) (string, error) {
 	}
 }
 
+// ExportJSONCtx builds and writes a JSON export like BuildExportBoard+WriteExportJSON, but
+// reports per-item progress and aborts early if ctx is canceled. total is counted up front
+// from state and iconsDir so progress reports a meaningful percentage before any I/O starts.
+func ExportJSONCtx(ctx context.Context, state *BoardState, iconsDir string, path string, progress Progress) error {
+	if progress == nil {
+		progress = NoopProgress{}
+	}
+	progress.Start(countExportItems(state, iconsDir), "export-json")
+
+	board := ExportBoard{
+		SchemaVersion: CurrentSchemaVersion,
+		Title:         state.Config.Title,
+		ExportedAt:    time.Now(),
+		Config:        state.Config,
+	}
+	exportErr := &ExportError{}
+	progress.Advance(1, "board.yaml")
+
+	for _, entry := range state.Config.Lists {
+		cards := state.Lists[entry.Dir]
+		el := ExportList{Dir: entry.Dir, Title: entry.Title}
+		if el.Title == "" {
+			el.Title = entry.Dir
+		}
+		for _, card := range cards {
+			if err := ctx.Err(); err != nil {
+				progress.Done(err)
+				return err
+			}
+			body, err := ReadCardContent(context.Background(), card.FilePath)
+			if err != nil {
+				slog.Warn("export: failed to read card body", "path", card.FilePath, "error", err)
+				exportErr.add(ExportErrorCard, card.FilePath, err)
+				body = ""
+			}
+			el.Cards = append(el.Cards, ExportCard{
+				ID:       card.Metadata.ID,
+				Title:    card.Metadata.Title,
+				Metadata: card.Metadata,
+				Body:     body,
+			})
+			progress.Advance(1, card.FilePath)
+		}
+		board.Lists = append(board.Lists, el)
+	}
+
+	if entries, err := os.ReadDir(iconsDir); err == nil {
+		var names []string
+		for _, entry := range entries {
+			if !entry.IsDir() && IsIconExt(entry.Name()) {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := ctx.Err(); err != nil {
+				progress.Done(err)
+				return err
+			}
+			content, err := readIconContent(filepath.Join(iconsDir, name))
+			if err != nil {
+				slog.Warn("export: failed to read icon", "name", name, "error", err)
+				exportErr.add(ExportErrorIcon, name, err)
+				progress.Advance(1, name)
+				continue
+			}
+			board.Icons = append(board.Icons, ExportIcon{Name: name, Content: content})
+			progress.Advance(1, name)
+		}
+	}
+
+	writeErr := WriteExportJSON(board, path)
+	if writeErr != nil {
+		progress.Done(writeErr)
+		return writeErr
+	}
+	progress.Done(exportErr.orNil())
+	return exportErr.orNil()
+}
+
 // WriteExportJSON marshals an ExportBoard to indented JSON and writes it to a file.
 func WriteExportJSON(board ExportBoard, path string) error {
 	data, err := json.MarshalIndent(board, "", "  ")
@@ -146,66 +286,393 @@ func WriteExportJSON(board ExportBoard, path string) error {
 	return nil
 }
 
-// WriteExportZip creates a zip archive containing board.yaml, all card files, and icons.
-func WriteExportZip(rootPath string, state *BoardState, iconsDir string, path string) error {
+// ExportZipCtx is WriteExportZip with per-item progress reporting and cooperative
+// cancellation via ctx. total is counted up front from state and iconsDir.
+func ExportZipCtx(ctx context.Context, rootPath string, state *BoardState, iconsDir string, path string, progress Progress) error {
+	if progress == nil {
+		progress = NoopProgress{}
+	}
+	progress.Start(countExportItems(state, iconsDir), "export-zip")
+
 	outFile, err := os.Create(path)
 	if err != nil {
+		progress.Done(err)
 		return fmt.Errorf("creating zip file: %w", err)
 	}
 
 	zw := zip.NewWriter(outFile)
 
-	// Add board.yaml.
+	header := archiveHeader{SchemaVersion: CurrentSchemaVersion, ExportedAt: time.Now()}
+	headerData, err := json.MarshalIndent(header, "", "  ")
+	if err != nil {
+		outFile.Close()
+		progress.Done(err)
+		return fmt.Errorf("marshaling archive header: %w", err)
+	}
+	if w, err := zw.Create("archive.json"); err != nil {
+		outFile.Close()
+		progress.Done(err)
+		return fmt.Errorf("adding archive.json: %w", err)
+	} else if _, err := w.Write(headerData); err != nil {
+		outFile.Close()
+		progress.Done(err)
+		return fmt.Errorf("writing archive.json: %w", err)
+	}
+
 	if err := addFileToZip(zw, filepath.Join(rootPath, "board.yaml"), "board.yaml"); err != nil {
 		zw.Close()
 		outFile.Close()
+		progress.Done(err)
 		return fmt.Errorf("adding board.yaml: %w", err)
 	}
+	progress.Advance(1, "board.yaml")
 
-	// Add card files from each list directory.
+	exportErr := &ExportError{}
 	for _, entry := range state.Config.Lists {
 		for _, card := range state.Lists[entry.Dir] {
+			if err := ctx.Err(); err != nil {
+				zw.Close()
+				outFile.Close()
+				progress.Done(err)
+				return err
+			}
 			relPath := entry.Dir + "/" + filepath.Base(card.FilePath)
 			if err := addFileToZip(zw, card.FilePath, relPath); err != nil {
 				slog.Warn("export: failed to add card to zip", "path", card.FilePath, "error", err)
+				exportErr.add(ExportErrorCard, card.FilePath, err)
 			}
+			progress.Advance(1, relPath)
 		}
 	}
 
-	// Add icons from iconsDir if present.
 	if entries, err := os.ReadDir(iconsDir); err == nil {
 		for _, entry := range entries {
 			if entry.IsDir() || !IsIconExt(entry.Name()) {
 				continue
 			}
+			if err := ctx.Err(); err != nil {
+				zw.Close()
+				outFile.Close()
+				progress.Done(err)
+				return err
+			}
 			srcPath := filepath.Join(iconsDir, entry.Name())
 			relPath := "_assets/icons/" + entry.Name()
 			if err := addFileToZip(zw, srcPath, relPath); err != nil {
 				slog.Warn("export: failed to add icon to zip", "name", entry.Name(), "error", err)
+				exportErr.add(ExportErrorIcon, entry.Name(), err)
 			}
+			progress.Advance(1, relPath)
 		}
 	}
 
 	if err := zw.Close(); err != nil {
 		outFile.Close()
+		progress.Done(err)
 		return fmt.Errorf("finalizing zip: %w", err)
 	}
 	if err := outFile.Close(); err != nil {
+		progress.Done(err)
 		return fmt.Errorf("closing zip file: %w", err)
 	}
-	return nil
+	progress.Done(exportErr.orNil())
+	return exportErr.orNil()
+}
+
+// WriteExportZip creates a zip archive containing board.yaml, all card files, and icons. If
+// any card or icon fails to read, the archive is still written best-effort and a non-nil
+// *ExportError is returned describing every item that was skipped. It writes archive.json
+// itself (the zip format's schema-version header) and then delegates the rest of the walk to
+// WriteExportSink over a zipExportSink, the same path WriteExportDir and WriteExportTarGz use.
+func WriteExportZip(rootPath string, state *BoardState, iconsDir string, path string) error {
+	sink, err := NewZipExportSink(path)
+	if err != nil {
+		return err
+	}
+	zipSink := sink.(*zipExportSink)
+
+	// Add archive.json, a small header recording the schema version this archive was
+	// written with (borrowed from Focalboard's archive format), so ImportZip can refuse
+	// or migrate archives written by a different version of this binary.
+	header := archiveHeader{SchemaVersion: CurrentSchemaVersion, ExportedAt: time.Now()}
+	headerData, err := json.MarshalIndent(header, "", "  ")
+	if err != nil {
+		zipSink.file.Close()
+		return fmt.Errorf("marshaling archive header: %w", err)
+	}
+	if err := zipSink.PutFile("archive.json", bytes.NewReader(headerData)); err != nil {
+		zipSink.file.Close()
+		return fmt.Errorf("adding archive.json: %w", err)
+	}
+
+	return WriteExportSink(rootPath, state, iconsDir, sink)
 }
 
-// addFileToZip reads a file from disk and writes it into a zip archive at the given path.
+// addFileToZip copies a file from disk into a zip archive at the given path, streaming it
+// through io.Copy rather than reading it fully into memory first. Used by ExportZipCtx, which
+// reports per-item progress as it goes rather than delegating the whole walk to WriteExportSink.
 func addFileToZip(zw *zip.Writer, srcPath string, zipPath string) error {
-	data, err := os.ReadFile(srcPath)
+	f, err := os.Open(srcPath)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 	w, err := zw.Create(zipPath)
 	if err != nil {
 		return err
 	}
-	_, err = w.Write(data)
+	_, err = io.Copy(w, f)
 	return err
 }
+
+// ExportProgressFunc reports streaming export progress: done is the cumulative number of items
+// (config, cards, icons) written so far, total is the count StreamExportJSON computed up front
+// via countExportItems. Called after every item, so it should be cheap -- a Wails EventsEmit
+// call or a channel send, not blocking work.
+type ExportProgressFunc func(done, total int)
+
+// jsonStringWriter JSON-escapes bytes written to it and forwards the result to w, so a caller can
+// io.Copy an arbitrarily large value (a card body, a base64-encoded icon) into a JSON string
+// without first buffering the whole thing in memory to escape it in one pass. The caller is
+// responsible for writing the surrounding quotes. Escaping byte-by-byte is safe for UTF-8 text:
+// every byte JSON requires an escape for is single-byte ASCII, and UTF-8 continuation bytes are
+// always >= 0x80, so they pass through untouched regardless of which multi-byte rune they belong to.
+type jsonStringWriter struct {
+	w io.Writer
+}
+
+func (jw *jsonStringWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		var err error
+		switch {
+		case b == '"':
+			_, err = jw.w.Write([]byte(`\"`))
+		case b == '\\':
+			_, err = jw.w.Write([]byte(`\\`))
+		case b == '\n':
+			_, err = jw.w.Write([]byte(`\n`))
+		case b == '\r':
+			_, err = jw.w.Write([]byte(`\r`))
+		case b == '\t':
+			_, err = jw.w.Write([]byte(`\t`))
+		case b < 0x20:
+			_, err = fmt.Fprintf(jw.w, `\u%04x`, b)
+		default:
+			_, err = jw.w.Write([]byte{b})
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// streamIconContent copies an icon file into w as the unquoted body of a JSON string value --
+// raw text for SVG, a base64 data URI for PNG -- without reading the whole file into memory
+// first like readIconContent does. PNG bytes are streamed through a chunked base64.Encoder
+// straight from disk, so peak memory for a large icon is a small constant instead of the whole
+// file plus its base64 encoding.
+func streamIconContent(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	jw := &jsonStringWriter{w: w}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".svg":
+		_, err := io.Copy(jw, f)
+		return err
+	case ".png":
+		if _, err := jw.Write([]byte("data:image/png;base64,")); err != nil {
+			return err
+		}
+		enc := base64.NewEncoder(base64.StdEncoding, jw)
+		if _, err := io.Copy(enc, f); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unsupported icon type: %s", ext)
+	}
+}
+
+// StreamExportJSON writes the same JSON shape as BuildExportBoard+WriteExportJSON directly to w,
+// without ever holding the whole board in memory: BuildExportBoard reads every card body and
+// icon into an ExportBoard first and WriteExportJSON then json.MarshalIndents the entire tree in
+// one shot, which peaks at hundreds of MB on a board with thousands of cards. This instead emits
+// the JSON object field by field, json.Marshaling small self-contained values (config, metadata)
+// and streaming each card body and icon straight from disk through a jsonStringWriter,
+// closing one before opening the next. onProgress, if non-nil, is called after every card and
+// icon with (done, total) so a caller such as the desktop app can show a progress bar; total is
+// counted up front via countExportItems, the same helper Progress-based exports use.
+func StreamExportJSON(w io.Writer, state *BoardState, iconsDir string, onProgress ExportProgressFunc) error {
+	if onProgress == nil {
+		onProgress = func(done, total int) {}
+	}
+	total := countExportItems(state, iconsDir)
+	done := 0
+
+	bw := bufio.NewWriter(w)
+
+	writeRaw := func(s string) error {
+		_, err := bw.WriteString(s)
+		return err
+	}
+	// writeValue marshals v on its own and writes the result, so the caller can keep
+	// composing the surrounding object by hand around values too small to be worth streaming.
+	writeValue := func(v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = bw.Write(data)
+		return err
+	}
+
+	if err := writeRaw(`{"schemaVersion":`); err != nil {
+		return err
+	}
+	if err := writeValue(CurrentSchemaVersion); err != nil {
+		return err
+	}
+	if err := writeRaw(`,"title":`); err != nil {
+		return err
+	}
+	if err := writeValue(state.Config.Title); err != nil {
+		return err
+	}
+	if err := writeRaw(`,"exportedAt":`); err != nil {
+		return err
+	}
+	if err := writeValue(time.Now()); err != nil {
+		return err
+	}
+	if err := writeRaw(`,"config":`); err != nil {
+		return err
+	}
+	if err := writeValue(state.Config); err != nil {
+		return err
+	}
+	done++
+	onProgress(done, total)
+
+	if err := writeRaw(`,"lists":[`); err != nil {
+		return err
+	}
+	for li, entry := range state.Config.Lists {
+		if li > 0 {
+			if err := writeRaw(","); err != nil {
+				return err
+			}
+		}
+		title := entry.Title
+		if title == "" {
+			title = entry.Dir
+		}
+		if err := writeRaw(`{"dir":`); err != nil {
+			return err
+		}
+		if err := writeValue(entry.Dir); err != nil {
+			return err
+		}
+		if err := writeRaw(`,"title":`); err != nil {
+			return err
+		}
+		if err := writeValue(title); err != nil {
+			return err
+		}
+		if err := writeRaw(`,"cards":[`); err != nil {
+			return err
+		}
+		for ci, card := range state.Lists[entry.Dir] {
+			if ci > 0 {
+				if err := writeRaw(","); err != nil {
+					return err
+				}
+			}
+			if err := writeRaw(`{"id":`); err != nil {
+				return err
+			}
+			if err := writeValue(card.Metadata.ID); err != nil {
+				return err
+			}
+			if err := writeRaw(`,"title":`); err != nil {
+				return err
+			}
+			if err := writeValue(card.Metadata.Title); err != nil {
+				return err
+			}
+			if err := writeRaw(`,"metadata":`); err != nil {
+				return err
+			}
+			if err := writeValue(card.Metadata); err != nil {
+				return err
+			}
+			if err := writeRaw(`,"body":"`); err != nil {
+				return err
+			}
+			body, err := ReadCardContent(context.Background(), card.FilePath)
+			if err != nil {
+				return fmt.Errorf("reading card body %s: %w", card.FilePath, err)
+			}
+			if _, err := io.Copy(&jsonStringWriter{w: bw}, strings.NewReader(body)); err != nil {
+				return err
+			}
+			if err := writeRaw(`"}`); err != nil {
+				return err
+			}
+			done++
+			onProgress(done, total)
+		}
+		if err := writeRaw("]}"); err != nil {
+			return err
+		}
+	}
+	if err := writeRaw("]"); err != nil {
+		return err
+	}
+
+	if err := writeRaw(`,"icons":[`); err != nil {
+		return err
+	}
+	var names []string
+	if entries, err := os.ReadDir(iconsDir); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && IsIconExt(entry.Name()) {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+	}
+	for ii, name := range names {
+		if ii > 0 {
+			if err := writeRaw(","); err != nil {
+				return err
+			}
+		}
+		if err := writeRaw(`{"name":`); err != nil {
+			return err
+		}
+		if err := writeValue(name); err != nil {
+			return err
+		}
+		if err := writeRaw(`,"content":"`); err != nil {
+			return err
+		}
+		if err := streamIconContent(bw, filepath.Join(iconsDir, name)); err != nil {
+			return fmt.Errorf("streaming icon %s: %w", name, err)
+		}
+		if err := writeRaw(`"}`); err != nil {
+			return err
+		}
+		done++
+		onProgress(done, total)
+	}
+	if err := writeRaw("]}"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,109 @@
+package daedalus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// A card file deleted out from under the scan between ScanBoard and BuildExportBoard should
+// surface as a real *ExportError instead of silently producing an empty body.
+func TestBuildExportBoard_MissingCardSurfacesError(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cardPath := filepath.Join(dir, "todo", "1.md")
+	if err := WriteCardFile(context.Background(), cardPath, CardMetadata{ID: 1, Title: "Card One"}, "body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), dir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	if err := os.Remove(cardPath); err != nil {
+		t.Fatalf("remove card: %v", err)
+	}
+
+	board, err := BuildExportBoard(state, filepath.Join(dir, "_assets", "icons"))
+	if err == nil {
+		t.Fatalf("expected an ExportError for the missing card")
+	}
+	exportErr, ok := err.(*ExportError)
+	if !ok {
+		t.Fatalf("expected *ExportError, got %T", err)
+	}
+	if len(exportErr.Items) != 1 || exportErr.Items[0].Kind != ExportErrorCard {
+		t.Fatalf("expected 1 card error, got %+v", exportErr.Items)
+	}
+	// The board is still produced best-effort, with an empty body for the missing card.
+	if len(board.Lists) != 1 || len(board.Lists[0].Cards) != 1 {
+		t.Fatalf("expected best-effort board with 1 card entry, got %+v", board.Lists)
+	}
+}
+
+// StreamExportJSON should produce a document that unmarshals into the same shape
+// BuildExportBoard+WriteExportJSON would have produced, with card bodies and icon content
+// correctly escaped even when they contain quotes, backslashes, and newlines.
+func TestStreamExportJSON_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	body := "line one\nhas \"quotes\" and a \\backslash\\\nline three\n"
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card One"}, body); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), dir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	iconsDir := filepath.Join(dir, "_assets", "icons")
+	if err := os.MkdirAll(iconsDir, 0755); err != nil {
+		t.Fatalf("mkdir icons: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(iconsDir, "star.svg"), []byte(`<svg><path d="M0 0"/></svg>`), 0644); err != nil {
+		t.Fatalf("write icon: %v", err)
+	}
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	var progressCalls []int
+	var buf bytes.Buffer
+	if err := StreamExportJSON(&buf, state, iconsDir, func(done, total int) {
+		progressCalls = append(progressCalls, done)
+		if done > total {
+			t.Fatalf("done %d exceeds total %d", done, total)
+		}
+	}); err != nil {
+		t.Fatalf("stream export: %v", err)
+	}
+
+	var board ExportBoard
+	if err := json.Unmarshal(buf.Bytes(), &board); err != nil {
+		t.Fatalf("unmarshal streamed export: %v\n%s", err, buf.String())
+	}
+	if board.SchemaVersion != CurrentSchemaVersion {
+		t.Fatalf("expected schema version %d, got %d", CurrentSchemaVersion, board.SchemaVersion)
+	}
+	if len(board.Lists) != 1 || len(board.Lists[0].Cards) != 1 {
+		t.Fatalf("expected 1 list with 1 card, got %+v", board.Lists)
+	}
+	if board.Lists[0].Cards[0].Body != body {
+		t.Fatalf("expected body %q, got %q", body, board.Lists[0].Cards[0].Body)
+	}
+	if len(board.Icons) != 1 || board.Icons[0].Name != "star.svg" {
+		t.Fatalf("expected 1 icon named star.svg, got %+v", board.Icons)
+	}
+	if len(progressCalls) == 0 || progressCalls[len(progressCalls)-1] != countExportItems(state, iconsDir) {
+		t.Fatalf("expected progress to finish at total item count, got %+v", progressCalls)
+	}
+}
@@ -0,0 +1,233 @@
+package daedalus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// exportBlobsDir is the subdirectory of a CAR export holding content-addressed blobs.
+const exportBlobsDir = "blobs"
+
+// ExportManifestEntry is one card's metadata and content digest in a CAR export's manifest.json.
+type ExportManifestEntry struct {
+	ID       int          `json:"id"`
+	Title    string       `json:"title"`
+	Metadata CardMetadata `json:"metadata"`
+	BodyCID  string       `json:"bodyCID"`
+	Size     int          `json:"size"`
+}
+
+// ExportManifestList is a list directory's cards in a CAR export's manifest.json.
+type ExportManifestList struct {
+	Dir   string                `json:"dir"`
+	Title string                `json:"title"`
+	Cards []ExportManifestEntry `json:"cards"`
+}
+
+// ExportManifestIcon is one icon's content digest in a CAR export's manifest.json.
+type ExportManifestIcon struct {
+	Name string `json:"name"`
+	CID  string `json:"cid"`
+	Size int    `json:"size"`
+}
+
+// ExportManifest is the top-level, content-addressed counterpart to ExportBoard: card and icon
+// bodies live once in blobs/ keyed by digest, and the manifest references them by CID instead
+// of embedding them inline.
+type ExportManifest struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	Title         string               `json:"title"`
+	ExportedAt    time.Time            `json:"exportedAt"`
+	Lists         []ExportManifestList `json:"lists"`
+	Icons         []ExportManifestIcon `json:"icons"`
+}
+
+// ExportCAR writes a content-addressed export of state to the directory at path: every unique
+// card body and icon is hashed with SHA-256 and written once to blobs/<hex digest>, referenced
+// from a top-level manifest.json, with the manifest's own digest written to root.txt so
+// VerifyExport can detect tampering or corruption in either. Identical bodies (duplicated or
+// templated cards) dedupe automatically since they hash to the same CID. It builds on
+// BuildExportBoard, the same in-memory walk ExportDelta uses, rather than re-reading the
+// board from disk.
+func ExportCAR(state *BoardState, iconsDir string, path string) error {
+	board, exportErr := BuildExportBoard(state, iconsDir)
+
+	if err := os.MkdirAll(filepath.Join(path, exportBlobsDir), 0755); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+
+	manifest := ExportManifest{
+		SchemaVersion: board.SchemaVersion,
+		Title:         board.Title,
+		ExportedAt:    board.ExportedAt,
+	}
+
+	for _, list := range board.Lists {
+		ml := ExportManifestList{Dir: list.Dir, Title: list.Title}
+		for _, card := range list.Cards {
+			cid, err := writeExportBlob(path, []byte(card.Body))
+			if err != nil {
+				return fmt.Errorf("writing blob for card %d: %w", card.ID, err)
+			}
+			ml.Cards = append(ml.Cards, ExportManifestEntry{
+				ID:       card.ID,
+				Title:    card.Title,
+				Metadata: card.Metadata,
+				BodyCID:  cid,
+				Size:     len(card.Body),
+			})
+		}
+		manifest.Lists = append(manifest.Lists, ml)
+	}
+
+	for _, icon := range board.Icons {
+		cid, err := writeExportBlob(path, []byte(icon.Content))
+		if err != nil {
+			return fmt.Errorf("writing blob for icon %s: %w", icon.Name, err)
+		}
+		manifest.Icons = append(manifest.Icons, ExportManifestIcon{Name: icon.Name, CID: cid, Size: len(icon.Content)})
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "manifest.json"), manifestData, 0644); err != nil {
+		return fmt.Errorf("writing manifest.json: %w", err)
+	}
+
+	root := sha256.Sum256(manifestData)
+	rootCID := hex.EncodeToString(root[:])
+	if err := os.WriteFile(filepath.Join(path, "root.txt"), []byte(rootCID+"\n"), 0644); err != nil {
+		return fmt.Errorf("writing root.txt: %w", err)
+	}
+
+	return exportErr
+}
+
+// writeExportBlob hashes data and writes it to blobs/<hex digest> under dir, unless a blob with
+// that digest is already on disk, and returns the digest ("CID" in the manifest). Skipping
+// existing blobs is what makes duplicate card bodies dedupe automatically.
+func writeExportBlob(dir string, data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	cid := hex.EncodeToString(sum[:])
+	blobPath := filepath.Join(dir, exportBlobsDir, cid)
+	if _, err := os.Stat(blobPath); err == nil {
+		return cid, nil
+	}
+	if err := os.WriteFile(blobPath, data, 0644); err != nil {
+		return "", err
+	}
+	return cid, nil
+}
+
+// VerifyExport re-hashes every blob referenced by a CAR export's manifest.json, and the
+// manifest itself against root.txt, returning an error describing the first mismatch or missing
+// file it finds.
+func VerifyExport(path string) error {
+	rootData, err := os.ReadFile(filepath.Join(path, "root.txt"))
+	if err != nil {
+		return fmt.Errorf("reading root.txt: %w", err)
+	}
+	wantRoot := strings.TrimSpace(string(rootData))
+
+	manifestData, err := os.ReadFile(filepath.Join(path, "manifest.json"))
+	if err != nil {
+		return fmt.Errorf("reading manifest.json: %w", err)
+	}
+	gotRoot := sha256.Sum256(manifestData)
+	if hex.EncodeToString(gotRoot[:]) != wantRoot {
+		return fmt.Errorf("manifest.json does not match root.txt (tampered or corrupt)")
+	}
+
+	var manifest ExportManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return fmt.Errorf("parsing manifest.json: %w", err)
+	}
+
+	for _, list := range manifest.Lists {
+		for _, card := range list.Cards {
+			if err := verifyExportBlob(path, card.BodyCID); err != nil {
+				return fmt.Errorf("card %d (%s): %w", card.ID, card.Title, err)
+			}
+		}
+	}
+	for _, icon := range manifest.Icons {
+		if err := verifyExportBlob(path, icon.CID); err != nil {
+			return fmt.Errorf("icon %s: %w", icon.Name, err)
+		}
+	}
+	return nil
+}
+
+// verifyExportBlob re-reads the blob named cid under dir/blobs and confirms it still hashes to
+// cid.
+func verifyExportBlob(dir, cid string) error {
+	data, err := os.ReadFile(filepath.Join(dir, exportBlobsDir, cid))
+	if err != nil {
+		return fmt.Errorf("reading blob %s: %w", cid, err)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != cid {
+		return fmt.Errorf("blob %s failed hash verification", cid)
+	}
+	return nil
+}
+
+// ImportCAR reads a CAR export written by ExportCAR, resolves every card and icon body back out
+// of blobs/ by its digest, and recreates board.yaml, list directories, card files, and icons
+// under destDir, reconciled according to strategy. It builds an ExportBoard in memory from the
+// manifest and hands off to importExportBoard, the same reconciliation path ImportJSON and
+// ImportZip use, so a CAR export merges identically to any other archive format. Because the CAR
+// manifest has no Config section (see ExportManifest), the imported board's config carries only
+// each list's Dir and Title.
+func ImportCAR(path, destDir string, strategy MergeStrategy) error {
+	manifest, err := readExportManifest(filepath.Join(path, "manifest.json"))
+	if err != nil {
+		return err
+	}
+
+	board := ExportBoard{
+		SchemaVersion: manifest.SchemaVersion,
+		Title:         manifest.Title,
+		ExportedAt:    manifest.ExportedAt,
+		Config:        &BoardConfig{Title: manifest.Title},
+	}
+
+	for _, list := range manifest.Lists {
+		el := ExportList{Dir: list.Dir, Title: list.Title}
+		board.Config.Lists = append(board.Config.Lists, ListEntry{Dir: list.Dir, Title: list.Title})
+		for _, card := range list.Cards {
+			body, err := readExportBlob(path, card.BodyCID)
+			if err != nil {
+				return fmt.Errorf("reading body for card %d: %w", card.ID, err)
+			}
+			el.Cards = append(el.Cards, ExportCard{ID: card.ID, Title: card.Title, Metadata: card.Metadata, Body: string(body)})
+		}
+		board.Lists = append(board.Lists, el)
+	}
+
+	for _, icon := range manifest.Icons {
+		content, err := readExportBlob(path, icon.CID)
+		if err != nil {
+			return fmt.Errorf("reading icon %s: %w", icon.Name, err)
+		}
+		board.Icons = append(board.Icons, ExportIcon{Name: icon.Name, Content: string(content)})
+	}
+
+	if err := checkSchemaVersion(board.SchemaVersion); err != nil {
+		return err
+	}
+	return importExportBoard(board, destDir, strategy)
+}
+
+// readExportBlob reads the blob named cid under dir/blobs.
+func readExportBlob(dir, cid string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(dir, exportBlobsDir, cid))
+}
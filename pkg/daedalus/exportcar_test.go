@@ -0,0 +1,95 @@
+package daedalus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ImportCAR should round-trip a board through ExportCAR: cards, their bodies, and metadata all
+// come back out unchanged, reconciled into destDir via the same importExportBoard path ImportJSON
+// and ImportZip use.
+func TestImportCAR_RoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(srcDir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(srcDir, "todo", "1.md"), CardMetadata{ID: 1, Title: "First card", Labels: []string{"bug"}}, "card body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), srcDir, &BoardConfig{Title: "Test Board", Lists: []ListEntry{{Dir: "todo", Title: "To Do"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	state, err := ScanBoard(context.Background(), srcDir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	carDir := t.TempDir()
+	if err := ExportCAR(state, filepath.Join(srcDir, "_assets", "icons"), carDir); err != nil {
+		t.Fatalf("export car: %v", err)
+	}
+
+	destDir := t.TempDir()
+	if err := ImportCAR(carDir, destDir, MergeReplace); err != nil {
+		t.Fatalf("import car: %v", err)
+	}
+
+	got, err := ScanBoard(context.Background(), destDir)
+	if err != nil {
+		t.Fatalf("scan dest: %v", err)
+	}
+	cards, ok := got.Lists["todo"]
+	if !ok || len(cards) != 1 {
+		t.Fatalf("expected 1 card in todo, got %v", got.Lists)
+	}
+	if cards[0].Metadata.Title != "First card" || len(cards[0].Metadata.Labels) != 1 || cards[0].Metadata.Labels[0] != "bug" {
+		t.Errorf("unexpected metadata after round-trip: %+v", cards[0].Metadata)
+	}
+	body, err := ReadCardContent(context.Background(), cards[0].FilePath)
+	if err != nil {
+		t.Fatalf("read card body: %v", err)
+	}
+	if body != "card body\n" {
+		t.Errorf("expected body %q, got %q", "card body\n", body)
+	}
+}
+
+// VerifyExport should fail with a clear error when a blob has been tampered with after export.
+func TestImportCAR_VerifyDetectsTamperedBlob(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(srcDir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(srcDir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card"}, "original\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), srcDir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	state, err := ScanBoard(context.Background(), srcDir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	carDir := t.TempDir()
+	if err := ExportCAR(state, filepath.Join(srcDir, "_assets", "icons"), carDir); err != nil {
+		t.Fatalf("export car: %v", err)
+	}
+	if err := VerifyExport(carDir); err != nil {
+		t.Fatalf("expected untampered export to verify, got %v", err)
+	}
+
+	blobsDir := filepath.Join(carDir, exportBlobsDir)
+	entries, err := os.ReadDir(blobsDir)
+	if err != nil || len(entries) == 0 {
+		t.Fatalf("expected at least one blob, err=%v entries=%v", err, entries)
+	}
+	blobPath := filepath.Join(blobsDir, entries[0].Name())
+	if err := os.WriteFile(blobPath, []byte("tampered"), 0644); err != nil {
+		t.Fatalf("tamper with blob: %v", err)
+	}
+	if err := VerifyExport(carDir); err == nil {
+		t.Error("expected VerifyExport to fail on a tampered blob")
+	}
+}
@@ -0,0 +1,237 @@
+package daedalus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DeltaChangeEntry is one added, modified, or deleted card in a delta export's changes.json --
+// enough to locate its file within the delta directory (for added/modified) or within the base
+// export (for deleted) without re-reading the whole board.
+type DeltaChangeEntry struct {
+	ID  int    `json:"id"`
+	Dir string `json:"dir"`
+}
+
+// DeltaRename records a card that moved from one list to another since the prior snapshot. A
+// card that was also edited appears in Modified as well, with its file written to the new list.
+type DeltaRename struct {
+	ID       int    `json:"id"`
+	FromList string `json:"fromList"`
+	ToList   string `json:"toList"`
+}
+
+// ExportDeltaManifest is changes.json: everything that changed between a prior CAR export's
+// manifest.json (see ExportCAR) and the board's current state.
+type ExportDeltaManifest struct {
+	Added    []DeltaChangeEntry `json:"added"`
+	Modified []DeltaChangeEntry `json:"modified"`
+	Deleted  []DeltaChangeEntry `json:"deleted"`
+	Renamed  []DeltaRename      `json:"renamed"`
+}
+
+// metadataHash returns a stable SHA-256 digest of a card's metadata, so ExportDelta can detect a
+// metadata-only edit (e.g. a label or due date change) even when the body hasn't changed.
+func metadataHash(meta CardMetadata) (string, error) {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return "", fmt.Errorf("marshaling metadata: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ExportDelta compares the board's current state against a prior CAR export's manifest.json and
+// writes only what changed into outPath: added and modified cards as plain card files (named
+// "<id>.md" within their list's directory, the convention WriteCardFile uses), plus changes.json
+// describing every addition, modification, deletion, and cross-list rename. A card counts as
+// modified if its body's SHA-256 or metadataHash differs from the prior manifest entry; a rename
+// is detected independently, by a surviving card's list directory changing. This lets a caller
+// back up or sync a large board incrementally instead of re-exporting everything every time --
+// ApplyDelta is its replay counterpart.
+func ExportDelta(state *BoardState, iconsDir string, prevManifestPath, outPath string) error {
+	prevManifest, err := readExportManifest(prevManifestPath)
+	if err != nil {
+		return fmt.Errorf("reading prior manifest: %w", err)
+	}
+
+	type prevCardInfo struct {
+		dir      string
+		bodyCID  string
+		metaHash string
+	}
+	prevByID := make(map[int]prevCardInfo, len(prevManifest.Lists))
+	for _, list := range prevManifest.Lists {
+		for _, card := range list.Cards {
+			metaHash, err := metadataHash(card.Metadata)
+			if err != nil {
+				return fmt.Errorf("hashing prior metadata for card %d: %w", card.ID, err)
+			}
+			prevByID[card.ID] = prevCardInfo{dir: list.Dir, bodyCID: card.BodyCID, metaHash: metaHash}
+		}
+	}
+
+	board, exportErr := BuildExportBoard(state, iconsDir)
+
+	delta := ExportDeltaManifest{}
+	seen := make(map[int]bool, len(prevByID))
+
+	for _, list := range board.Lists {
+		for _, card := range list.Cards {
+			seen[card.ID] = true
+
+			sum := sha256.Sum256([]byte(card.Body))
+			bodyCID := hex.EncodeToString(sum[:])
+			metaHash, err := metadataHash(card.Metadata)
+			if err != nil {
+				return fmt.Errorf("hashing metadata for card %d: %w", card.ID, err)
+			}
+
+			prev, existed := prevByID[card.ID]
+			changed := !existed || prev.bodyCID != bodyCID || prev.metaHash != metaHash
+			switch {
+			case !existed:
+				delta.Added = append(delta.Added, DeltaChangeEntry{ID: card.ID, Dir: list.Dir})
+			case changed:
+				delta.Modified = append(delta.Modified, DeltaChangeEntry{ID: card.ID, Dir: list.Dir})
+			}
+			if existed && prev.dir != list.Dir {
+				delta.Renamed = append(delta.Renamed, DeltaRename{ID: card.ID, FromList: prev.dir, ToList: list.Dir})
+			}
+
+			if changed {
+				if err := writeDeltaCardFile(outPath, list.Dir, card); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for id, prev := range prevByID {
+		if !seen[id] {
+			delta.Deleted = append(delta.Deleted, DeltaChangeEntry{ID: id, Dir: prev.dir})
+		}
+	}
+	sort.Slice(delta.Deleted, func(i, j int) bool { return delta.Deleted[i].ID < delta.Deleted[j].ID })
+
+	if err := os.MkdirAll(outPath, 0755); err != nil {
+		return fmt.Errorf("creating delta directory: %w", err)
+	}
+	changesData, err := json.MarshalIndent(delta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling changes.json: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outPath, "changes.json"), changesData, 0644); err != nil {
+		return fmt.Errorf("writing changes.json: %w", err)
+	}
+
+	return exportErr
+}
+
+// writeDeltaCardFile writes an added or modified card's metadata and body into outPath/dir,
+// named "<id>.md" per WriteCardFile's convention.
+func writeDeltaCardFile(outPath, dir string, card ExportCard) error {
+	if err := os.MkdirAll(filepath.Join(outPath, dir), 0755); err != nil {
+		return fmt.Errorf("creating delta list directory: %w", err)
+	}
+	dest := filepath.Join(outPath, dir, fmt.Sprintf("%d.md", card.ID))
+	return WriteCardFile(context.Background(), dest, card.Metadata, card.Body)
+}
+
+// readExportManifest reads and parses a CAR export's manifest.json.
+func readExportManifest(path string) (ExportManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ExportManifest{}, err
+	}
+	var manifest ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return ExportManifest{}, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// ApplyDelta replays a delta produced by ExportDelta against basePath, an existing export
+// directory laid out the same way (one subdirectory per list, cards named "<id>.md"): added and
+// modified cards are copied in from deltaPath, deleted cards are removed, and renamed cards are
+// moved to their new list's directory.
+func ApplyDelta(basePath, deltaPath string) error {
+	changesData, err := os.ReadFile(filepath.Join(deltaPath, "changes.json"))
+	if err != nil {
+		return fmt.Errorf("reading changes.json: %w", err)
+	}
+	var delta ExportDeltaManifest
+	if err := json.Unmarshal(changesData, &delta); err != nil {
+		return fmt.Errorf("parsing changes.json: %w", err)
+	}
+
+	for _, entry := range append(append([]DeltaChangeEntry{}, delta.Added...), delta.Modified...) {
+		if err := applyDeltaCardFile(basePath, deltaPath, entry); err != nil {
+			return err
+		}
+	}
+
+	for _, rename := range delta.Renamed {
+		if err := applyDeltaRename(basePath, rename); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range delta.Deleted {
+		path := filepath.Join(basePath, entry.Dir, fmt.Sprintf("%d.md", entry.ID))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing deleted card %d: %w", entry.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// applyDeltaCardFile copies one added or modified card file from deltaPath into basePath.
+func applyDeltaCardFile(basePath, deltaPath string, entry DeltaChangeEntry) error {
+	name := fmt.Sprintf("%d.md", entry.ID)
+	data, err := os.ReadFile(filepath.Join(deltaPath, entry.Dir, name))
+	if err != nil {
+		return fmt.Errorf("reading delta card %d: %w", entry.ID, err)
+	}
+	if err := os.MkdirAll(filepath.Join(basePath, entry.Dir), 0755); err != nil {
+		return fmt.Errorf("creating list directory for card %d: %w", entry.ID, err)
+	}
+	if err := os.WriteFile(filepath.Join(basePath, entry.Dir, name), data, 0644); err != nil {
+		return fmt.Errorf("writing card %d: %w", entry.ID, err)
+	}
+	return nil
+}
+
+// applyDeltaRename moves a card's file from its old list directory to its new one within
+// basePath. If the card was also modified, applyDeltaCardFile has already written it at the new
+// location, so this just removes the stale copy left behind at the old one.
+func applyDeltaRename(basePath string, rename DeltaRename) error {
+	name := fmt.Sprintf("%d.md", rename.ID)
+	oldPath := filepath.Join(basePath, rename.FromList, name)
+	newPath := filepath.Join(basePath, rename.ToList, name)
+
+	if _, err := os.Stat(newPath); err == nil {
+		if oldPath == newPath {
+			return nil
+		}
+		if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing stale copy of renamed card %d: %w", rename.ID, err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(basePath, rename.ToList), 0755); err != nil {
+		return fmt.Errorf("creating list directory for renamed card %d: %w", rename.ID, err)
+	}
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("moving renamed card %d: %w", rename.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package daedalus
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setupDeltaBoard(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "done"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Stays the same"}, "unchanged\n"); err != nil {
+		t.Fatalf("write card 1: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "2.md"), CardMetadata{ID: 2, Title: "Will be modified"}, "old body\n"); err != nil {
+		t.Fatalf("write card 2: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "3.md"), CardMetadata{ID: 3, Title: "Will be deleted"}, "gone soon\n"); err != nil {
+		t.Fatalf("write card 3: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "4.md"), CardMetadata{ID: 4, Title: "Will be renamed"}, "moves lists\n"); err != nil {
+		t.Fatalf("write card 4: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), dir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}, {Dir: "done"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	manifestDir := t.TempDir()
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if err := ExportCAR(state, filepath.Join(dir, "_assets", "icons"), manifestDir); err != nil {
+		t.Fatalf("export car: %v", err)
+	}
+	return dir, filepath.Join(manifestDir, "manifest.json")
+}
+
+// ExportDelta should classify each card correctly against the prior manifest: unchanged cards
+// produce no output, a modified body/metadata lands in Modified, a removed card lands in
+// Deleted, and a card moved to a different list lands in Renamed -- and a brand new card lands
+// in Added.
+func TestExportDelta_ClassifiesChanges(t *testing.T) {
+	dir, manifestPath := setupDeltaBoard(t)
+
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "2.md"), CardMetadata{ID: 2, Title: "Will be modified"}, "new body\n"); err != nil {
+		t.Fatalf("modify card 2: %v", err)
+	}
+	if err := os.Remove(filepath.Join(dir, "todo", "3.md")); err != nil {
+		t.Fatalf("delete card 3: %v", err)
+	}
+	if err := os.Rename(filepath.Join(dir, "todo", "4.md"), filepath.Join(dir, "done", "4.md")); err != nil {
+		t.Fatalf("rename card 4: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "5.md"), CardMetadata{ID: 5, Title: "Brand new"}, "new card\n"); err != nil {
+		t.Fatalf("write card 5: %v", err)
+	}
+
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	deltaDir := t.TempDir()
+	if err := ExportDelta(state, filepath.Join(dir, "_assets", "icons"), manifestPath, deltaDir); err != nil {
+		t.Fatalf("export delta: %v", err)
+	}
+
+	changesData, err := os.ReadFile(filepath.Join(deltaDir, "changes.json"))
+	if err != nil {
+		t.Fatalf("read changes.json: %v", err)
+	}
+	var delta ExportDeltaManifest
+	if err := json.Unmarshal(changesData, &delta); err != nil {
+		t.Fatalf("unmarshal changes.json: %v", err)
+	}
+
+	if len(delta.Added) != 1 || delta.Added[0].ID != 5 {
+		t.Fatalf("expected card 5 added, got %+v", delta.Added)
+	}
+	if len(delta.Modified) != 1 || delta.Modified[0].ID != 2 {
+		t.Fatalf("expected card 2 modified, got %+v", delta.Modified)
+	}
+	if len(delta.Deleted) != 1 || delta.Deleted[0].ID != 3 {
+		t.Fatalf("expected card 3 deleted, got %+v", delta.Deleted)
+	}
+	if len(delta.Renamed) != 1 || delta.Renamed[0].ID != 4 || delta.Renamed[0].FromList != "todo" || delta.Renamed[0].ToList != "done" {
+		t.Fatalf("expected card 4 renamed todo->done, got %+v", delta.Renamed)
+	}
+
+	if _, err := os.Stat(filepath.Join(deltaDir, "todo", "2.md")); err != nil {
+		t.Fatalf("expected delta to contain modified card 2's file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(deltaDir, "todo", "5.md")); err != nil {
+		t.Fatalf("expected delta to contain added card 5's file: %v", err)
+	}
+}
+
+// ApplyDelta should replay added/modified/deleted/renamed changes against a plain directory
+// export of the prior snapshot, bringing it in sync with the board's current state.
+func TestApplyDelta_ReplaysChanges(t *testing.T) {
+	dir, manifestPath := setupDeltaBoard(t)
+
+	baseDir := t.TempDir()
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if err := WriteExportDir(dir, state, filepath.Join(dir, "_assets", "icons"), baseDir); err != nil {
+		t.Fatalf("write base export: %v", err)
+	}
+
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "2.md"), CardMetadata{ID: 2, Title: "Will be modified"}, "new body\n"); err != nil {
+		t.Fatalf("modify card 2: %v", err)
+	}
+	if err := os.Remove(filepath.Join(dir, "todo", "3.md")); err != nil {
+		t.Fatalf("delete card 3: %v", err)
+	}
+	if err := os.Rename(filepath.Join(dir, "todo", "4.md"), filepath.Join(dir, "done", "4.md")); err != nil {
+		t.Fatalf("rename card 4: %v", err)
+	}
+
+	state, err = ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	deltaDir := t.TempDir()
+	if err := ExportDelta(state, filepath.Join(dir, "_assets", "icons"), manifestPath, deltaDir); err != nil {
+		t.Fatalf("export delta: %v", err)
+	}
+
+	if err := ApplyDelta(baseDir, deltaDir); err != nil {
+		t.Fatalf("apply delta: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(baseDir, "todo", "3.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected card 3 removed from base, err=%v", err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "todo", "4.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected card 4 no longer under todo/, err=%v", err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "done", "4.md")); err != nil {
+		t.Fatalf("expected card 4 moved to done/: %v", err)
+	}
+
+	_, body, err := parseFileHeader(filepath.Join(baseDir, "todo", "2.md"))
+	if err != nil {
+		t.Fatalf("parse applied card 2: %v", err)
+	}
+	if body != "new body\n" {
+		t.Fatalf("expected card 2's new body to apply, got %q", body)
+	}
+}
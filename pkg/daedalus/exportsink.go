@@ -0,0 +1,228 @@
+package daedalus
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ExportSink abstracts where WriteExportSink's files actually land, so the export walk itself
+// doesn't need to know whether it's producing a zip, a tar.gz, or a plain directory -- it just
+// names each file and hands PutFile a reader. This mirrors the Storage revamp (see
+// storage_iface.go): one small interface, one exported constructor per backend.
+type ExportSink interface {
+	// PutFile writes name (a slash-separated path relative to the archive root, e.g.
+	// "todo/1.md" or "_assets/icons/foo.png") with the content read from r.
+	PutFile(name string, r io.Reader) error
+	// Close finalizes the sink, flushing and closing whatever underlies it.
+	Close() error
+}
+
+// dirExportSink implements ExportSink by writing files directly under a directory on disk.
+type dirExportSink struct {
+	root string
+}
+
+// NewDirExportSink returns an ExportSink that writes files under root, creating root (and any
+// list subdirectories PutFile names) as needed.
+func NewDirExportSink(root string) (ExportSink, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("creating export directory: %w", err)
+	}
+	return &dirExportSink{root: root}, nil
+}
+
+func (s *dirExportSink) PutFile(name string, r io.Reader) error {
+	dest := filepath.Join(s.root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", name, err)
+	}
+	f, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", name, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *dirExportSink) Close() error {
+	return nil
+}
+
+// zipExportSink implements ExportSink by writing files into a zip archive, the same layout
+// WriteExportZip wrote by hand before this type existed.
+type zipExportSink struct {
+	file *os.File
+	zw   *zip.Writer
+}
+
+// NewZipExportSink returns an ExportSink that writes a zip archive to path.
+func NewZipExportSink(path string) (ExportSink, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating zip file: %w", err)
+	}
+	return &zipExportSink{file: f, zw: zip.NewWriter(f)}, nil
+}
+
+func (s *zipExportSink) PutFile(name string, r io.Reader) error {
+	w, err := s.zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("adding %s to zip: %w", name, err)
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *zipExportSink) Close() error {
+	if err := s.zw.Close(); err != nil {
+		s.file.Close()
+		return fmt.Errorf("finalizing zip: %w", err)
+	}
+	return s.file.Close()
+}
+
+// targzExportSink implements ExportSink by writing files into a gzip-compressed tar archive.
+// Unlike WriteExportTar (plain, uncompressed, streamed straight from state), this is built on
+// the same ExportSink path as the zip and directory sinks.
+type targzExportSink struct {
+	file *os.File
+	gw   *gzip.Writer
+	tw   *tar.Writer
+}
+
+// NewTarGzExportSink returns an ExportSink that writes a gzip-compressed tar archive to path.
+func NewTarGzExportSink(path string) (ExportSink, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating tar.gz file: %w", err)
+	}
+	gw := gzip.NewWriter(f)
+	return &targzExportSink{file: f, gw: gw, tw: tar.NewWriter(gw)}, nil
+}
+
+func (s *targzExportSink) PutFile(name string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}
+	if err := s.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing tar header for %s: %w", name, err)
+	}
+	if _, err := s.tw.Write(data); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *targzExportSink) Close() error {
+	if err := s.tw.Close(); err != nil {
+		s.gw.Close()
+		s.file.Close()
+		return fmt.Errorf("finalizing tar: %w", err)
+	}
+	if err := s.gw.Close(); err != nil {
+		s.file.Close()
+		return fmt.Errorf("finalizing gzip: %w", err)
+	}
+	return s.file.Close()
+}
+
+// WriteExportSink writes board.yaml, every card file, and _assets/icons through sink, then
+// closes it. rootPath is the board's root (for locating board.yaml on disk) and iconsDir its
+// icons directory; state supplies the list/card walk order, the same one WriteExportTar
+// reimplements -- this is that walk done once (WriteExportZip and WriteExportDir go through it),
+// with the sink deciding whether the result is a directory, a zip, or a tar.gz.
+func WriteExportSink(rootPath string, state *BoardState, iconsDir string, sink ExportSink) error {
+	if data, err := os.ReadFile(filepath.Join(rootPath, "board.yaml")); err == nil {
+		if err := sink.PutFile("board.yaml", bytes.NewReader(data)); err != nil {
+			sink.Close()
+			return fmt.Errorf("adding board.yaml: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		sink.Close()
+		return fmt.Errorf("reading board.yaml: %w", err)
+	}
+
+	exportErr := &ExportError{}
+	for _, entry := range state.Config.Lists {
+		for _, card := range state.Lists[entry.Dir] {
+			f, err := os.Open(card.FilePath)
+			if err != nil {
+				slog.Warn("export: failed to open card", "path", card.FilePath, "error", err)
+				exportErr.add(ExportErrorCard, card.FilePath, err)
+				continue
+			}
+			name := entry.Dir + "/" + filepath.Base(card.FilePath)
+			err = sink.PutFile(name, f)
+			f.Close()
+			if err != nil {
+				sink.Close()
+				return fmt.Errorf("adding %s: %w", name, err)
+			}
+		}
+	}
+
+	if entries, err := os.ReadDir(iconsDir); err == nil {
+		var names []string
+		for _, e := range entries {
+			if !e.IsDir() && IsIconExt(e.Name()) {
+				names = append(names, e.Name())
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			srcPath := filepath.Join(iconsDir, name)
+			f, err := os.Open(srcPath)
+			if err != nil {
+				slog.Warn("export: failed to open icon", "name", name, "error", err)
+				exportErr.add(ExportErrorIcon, name, err)
+				continue
+			}
+			relPath := "_assets/icons/" + name
+			err = sink.PutFile(relPath, f)
+			f.Close()
+			if err != nil {
+				sink.Close()
+				return fmt.Errorf("adding %s: %w", relPath, err)
+			}
+		}
+	}
+
+	if err := sink.Close(); err != nil {
+		return err
+	}
+	return exportErr.orNil()
+}
+
+// WriteExportDir writes the board at rootPath to a plain directory at path, suitable for
+// syncing to another location or reading straight back with ScanBoard.
+func WriteExportDir(rootPath string, state *BoardState, iconsDir string, path string) error {
+	sink, err := NewDirExportSink(path)
+	if err != nil {
+		return err
+	}
+	return WriteExportSink(rootPath, state, iconsDir, sink)
+}
+
+// WriteExportTarGz writes the board at rootPath to a gzip-compressed tar archive at path.
+func WriteExportTarGz(rootPath string, state *BoardState, iconsDir string, path string) error {
+	sink, err := NewTarGzExportSink(path)
+	if err != nil {
+		return err
+	}
+	return WriteExportSink(rootPath, state, iconsDir, sink)
+}
@@ -0,0 +1,113 @@
+package daedalus
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setupExportSinkBoard(t *testing.T) (*BoardState, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card One"}, "# Card One\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), dir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	return state, dir
+}
+
+// WriteExportDir should lay the board out as a plain directory that ScanBoard can read straight
+// back in, round-tripping through the same WriteExportSink walk zip/tar.gz use.
+func TestWriteExportDir_RoundTrip(t *testing.T) {
+	state, dir := setupExportSinkBoard(t)
+	outDir := filepath.Join(t.TempDir(), "out")
+
+	if err := WriteExportDir(dir, state, filepath.Join(dir, "_assets", "icons"), outDir); err != nil {
+		t.Fatalf("write export dir: %v", err)
+	}
+
+	imported, err := ScanBoard(context.Background(), outDir)
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	cards := imported.Lists["todo"]
+	if len(cards) != 1 || cards[0].Metadata.Title != "Card One" {
+		t.Fatalf("expected exported card 'Card One', got %+v", cards)
+	}
+}
+
+// WriteExportTarGz should produce a gzip-compressed tar archive whose entries match the board's
+// board.yaml and card files.
+func TestWriteExportTarGz_ContainsEntries(t *testing.T) {
+	state, dir := setupExportSinkBoard(t)
+	outPath := filepath.Join(t.TempDir(), "export.tar.gz")
+
+	if err := WriteExportTarGz(dir, state, filepath.Join(dir, "_assets", "icons"), outPath); err != nil {
+		t.Fatalf("write export tar.gz: %v", err)
+	}
+
+	f, err := os.Open(outPath)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	defer gr.Close()
+
+	names := make(map[string]bool)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading tar entry: %v", err)
+		}
+		names[hdr.Name] = true
+	}
+	if !names["board.yaml"] || !names["todo/1.md"] {
+		t.Fatalf("expected board.yaml and todo/1.md in tar.gz, got %+v", names)
+	}
+}
+
+// WriteExportZip, now built on WriteExportSink/zipExportSink, should still round-trip through
+// ImportZip exactly as it did as a hand-rolled zip.Writer loop.
+func TestWriteExportZip_RoundTrip(t *testing.T) {
+	state, dir := setupExportSinkBoard(t)
+	exportPath := filepath.Join(t.TempDir(), "export.zip")
+
+	if err := WriteExportZip(dir, state, filepath.Join(dir, "_assets", "icons"), exportPath); err != nil {
+		t.Fatalf("write export zip: %v", err)
+	}
+
+	destDir := t.TempDir()
+	if err := ImportZip(exportPath, destDir, false); err != nil {
+		t.Fatalf("import zip: %v", err)
+	}
+
+	imported, err := ScanBoard(context.Background(), destDir)
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	cards := imported.Lists["todo"]
+	if len(cards) != 1 || cards[0].Metadata.Title != "Card One" {
+		t.Fatalf("expected imported card 'Card One', got %+v", cards)
+	}
+}
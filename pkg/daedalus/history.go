@@ -0,0 +1,221 @@
+package daedalus
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// historyHashLen is the number of hex characters kept from a blob's SHA-256 digest,
+// matching the truncated content hashes concards uses for its review file.
+const historyHashLen = 16
+
+// HistoryEntry is one recorded version of a card file.
+type HistoryEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Hash      string    `json:"hash"`
+	Operation string    `json:"operation"`
+}
+
+// objectsDir returns the content-addressable blob store for a board.
+func objectsDir(rootPath string) string {
+	return filepath.Join(rootPath, ".daedalus", "objects")
+}
+
+// historyLogPath returns the append-only history log for a single card.
+func historyLogPath(rootPath, listName string, cardID int) string {
+	return filepath.Join(rootPath, ".daedalus", "history", listName, fmt.Sprintf("%d.log", cardID))
+}
+
+// hashBlob returns the truncated hex SHA-256 digest of data.
+func hashBlob(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:historyHashLen]
+}
+
+// writeHistoryBlob deduplicates and stores data under objects/<prefix>/<hash>, returning the hash.
+func writeHistoryBlob(fs afero.Fs, rootPath string, data []byte) (string, error) {
+	hash := hashBlob(data)
+	blobPath := filepath.Join(objectsDir(rootPath), hash[:2], hash)
+
+	if exists, err := afero.Exists(fs, blobPath); err == nil && exists {
+		return hash, nil
+	}
+	if err := fs.MkdirAll(filepath.Dir(blobPath), 0755); err != nil {
+		return "", fmt.Errorf("creating objects dir: %w", err)
+	}
+	if err := afero.WriteFile(fs, blobPath, data, 0644); err != nil {
+		return "", fmt.Errorf("writing history blob: %w", err)
+	}
+	return hash, nil
+}
+
+// appendHistoryLog appends a timestamp\thash\toperation line to a card's history log.
+func appendHistoryLog(fs afero.Fs, rootPath, listName string, cardID int, hash, operation string, at time.Time) error {
+	logPath := historyLogPath(rootPath, listName, cardID)
+	if err := fs.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return fmt.Errorf("creating history dir: %w", err)
+	}
+
+	line := fmt.Sprintf("%s\t%s\t%s\n", at.Format(time.RFC3339Nano), hash, operation)
+
+	existing, err := afero.ReadFile(fs, logPath)
+	if err != nil && !isNotExist(fs, logPath) {
+		return fmt.Errorf("reading history log: %w", err)
+	}
+	return afero.WriteFile(fs, logPath, append(existing, []byte(line)...), 0644)
+}
+
+// isNotExist reports whether path is absent on fs.
+func isNotExist(fs afero.Fs, path string) bool {
+	exists, err := afero.Exists(fs, path)
+	return err == nil && !exists
+}
+
+// WriteCardFileTrackedFs snapshots the pre-write bytes of path (if any) into the board's
+// content-addressable history store, appends a history log entry, and then writes the new
+// content via WriteCardFileFs. Use this instead of WriteCardFileFs/WriteCardFile when a
+// board wants an undo trail; ordinary writes are unaffected.
+func WriteCardFileTrackedFs(fs afero.Fs, rootPath, path string, meta CardMetadata, body string, listName string, cardID int, operation string) error {
+	if existing, err := afero.ReadFile(fs, path); err == nil {
+		hash, err := writeHistoryBlob(fs, rootPath, existing)
+		if err != nil {
+			return fmt.Errorf("snapshotting previous version: %w", err)
+		}
+		if err := appendHistoryLog(fs, rootPath, listName, cardID, hash, operation, time.Now()); err != nil {
+			return fmt.Errorf("recording history entry: %w", err)
+		}
+	}
+	return WriteCardFileFs(context.Background(), fs, path, meta, body)
+}
+
+// ListCardHistory returns every recorded version of a card, oldest first.
+func ListCardHistory(state *BoardState, listName string, cardID int) ([]HistoryEntry, error) {
+	fs := state.Fs
+	logPath := historyLogPath(state.RootPath, listName, cardID)
+
+	file, err := fs.Open(logPath)
+	if isNotExist(fs, logPath) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening history log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	s := bufio.NewScanner(file)
+	for s.Scan() {
+		fields := strings.SplitN(s.Text(), "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			continue
+		}
+		entries = append(entries, HistoryEntry{Timestamp: ts, Hash: fields[1], Operation: fields[2]})
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("reading history log: %w", err)
+	}
+	return entries, nil
+}
+
+// RestoreCardVersion rewrites a card's current file from a historical blob, going through
+// WriteCardFileTrackedFs so the restore itself is recorded and ordered YAML/merge behavior
+// is preserved.
+func RestoreCardVersion(state *BoardState, listName string, cardID int, hash string) error {
+	fs := state.Fs
+	blobPath := filepath.Join(objectsDir(state.RootPath), hash[:2], hash)
+
+	data, err := afero.ReadFile(fs, blobPath)
+	if err != nil {
+		return fmt.Errorf("reading history blob %s: %w", hash, err)
+	}
+
+	meta, body, err := parseCardBytes(data)
+	if err != nil {
+		return fmt.Errorf("parsing historical version: %w", err)
+	}
+
+	path := filepath.Join(state.RootPath, listName, strconv.Itoa(cardID)+".md")
+	return WriteCardFileTrackedFs(fs, state.RootPath, path, meta, body, listName, cardID, "restore")
+}
+
+// GCHistory removes blobs no longer referenced by any history log entry younger than keep.
+// Entries (and their blobs) older than keep are pruned from consideration entirely.
+func GCHistory(state *BoardState, keep time.Duration) error {
+	fs := state.Fs
+	cutoff := time.Now().Add(-keep)
+	referenced := make(map[string]bool)
+
+	historyRoot := filepath.Join(state.RootPath, ".daedalus", "history")
+	listDirs, err := afero.ReadDir(fs, historyRoot)
+	if err != nil {
+		if isNotExist(fs, historyRoot) {
+			return nil
+		}
+		return fmt.Errorf("reading history dir: %w", err)
+	}
+
+	for _, listDir := range listDirs {
+		if !listDir.IsDir() {
+			continue
+		}
+		logFiles, err := afero.ReadDir(fs, filepath.Join(historyRoot, listDir.Name()))
+		if err != nil {
+			continue
+		}
+		for _, logFile := range logFiles {
+			cardID, err := strconv.Atoi(strings.TrimSuffix(logFile.Name(), ".log"))
+			if err != nil {
+				continue
+			}
+			entries, err := ListCardHistory(state, listDir.Name(), cardID)
+			if err != nil {
+				continue
+			}
+			for _, e := range entries {
+				if e.Timestamp.After(cutoff) {
+					referenced[e.Hash] = true
+				}
+			}
+		}
+	}
+
+	prefixDirs, err := afero.ReadDir(fs, objectsDir(state.RootPath))
+	if err != nil {
+		if isNotExist(fs, objectsDir(state.RootPath)) {
+			return nil
+		}
+		return fmt.Errorf("reading objects dir: %w", err)
+	}
+	for _, prefixDir := range prefixDirs {
+		if !prefixDir.IsDir() {
+			continue
+		}
+		prefixPath := filepath.Join(objectsDir(state.RootPath), prefixDir.Name())
+		blobs, err := afero.ReadDir(fs, prefixPath)
+		if err != nil {
+			continue
+		}
+		for _, blob := range blobs {
+			if referenced[blob.Name()] {
+				continue
+			}
+			if err := fs.Remove(filepath.Join(prefixPath, blob.Name())); err != nil {
+				return fmt.Errorf("removing unreferenced blob %s: %w", blob.Name(), err)
+			}
+		}
+	}
+	return nil
+}
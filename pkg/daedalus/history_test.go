@@ -0,0 +1,105 @@
+package daedalus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// Writing a tracked card twice should snapshot the first version and record it in the log.
+func TestWriteCardFileTrackedFs_RecordsHistory(t *testing.T) {
+	state := NewBoardInMemory()
+	fs := state.Fs
+	if err := fs.MkdirAll("/board/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	path := "/board/todo/1.md"
+
+	if err := WriteCardFileTrackedFs(fs, "/board", path, CardMetadata{ID: 1, Title: "v1"}, "first\n", "todo", 1, "create"); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	if err := WriteCardFileTrackedFs(fs, "/board", path, CardMetadata{ID: 1, Title: "v2"}, "second\n", "todo", 1, "update"); err != nil {
+		t.Fatalf("second write: %v", err)
+	}
+
+	state.RootPath = "/board"
+	entries, err := ListCardHistory(state, "todo", 1)
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 history entry (from the second write snapshotting v1), got %d", len(entries))
+	}
+	if entries[0].Operation != "update" {
+		t.Fatalf("expected operation %q, got %q", "update", entries[0].Operation)
+	}
+}
+
+// Restoring a historical version should bring back its title and body while recording
+// the restore itself as a new history entry.
+func TestRestoreCardVersion(t *testing.T) {
+	state := NewBoardInMemory()
+	state.RootPath = "/board"
+	fs := state.Fs
+	if err := fs.MkdirAll("/board/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	path := "/board/todo/1.md"
+
+	if err := WriteCardFileTrackedFs(fs, "/board", path, CardMetadata{ID: 1, Title: "v1"}, "first\n", "todo", 1, "create"); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	if err := WriteCardFileTrackedFs(fs, "/board", path, CardMetadata{ID: 1, Title: "v2"}, "second\n", "todo", 1, "update"); err != nil {
+		t.Fatalf("second write: %v", err)
+	}
+
+	entries, err := ListCardHistory(state, "todo", 1)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected 1 history entry, got %d (err %v)", len(entries), err)
+	}
+
+	if err := RestoreCardVersion(state, "todo", 1, entries[0].Hash); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+
+	meta, body, err := parseFileHeaderFs(fs, path)
+	if err != nil {
+		t.Fatalf("read restored card: %v", err)
+	}
+	if meta.Title != "v1" || body != "first\n" {
+		t.Fatalf("expected restored v1/first, got title=%q body=%q", meta.Title, body)
+	}
+
+	entries, err = ListCardHistory(state, "todo", 1)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 history entries after restore, got %d (err %v)", len(entries), err)
+	}
+}
+
+// GCHistory should drop blobs that are only referenced by entries older than keep.
+func TestGCHistory_PrunesStaleBlobs(t *testing.T) {
+	state := NewBoardInMemory()
+	state.RootPath = "/board"
+	fs := state.Fs
+	if err := fs.MkdirAll("/board/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	hash, err := writeHistoryBlob(fs, "/board", []byte("stale content"))
+	if err != nil {
+		t.Fatalf("write blob: %v", err)
+	}
+	if err := appendHistoryLog(fs, "/board", "todo", 1, hash, "update", time.Now().Add(-48*time.Hour)); err != nil {
+		t.Fatalf("append log: %v", err)
+	}
+
+	if err := GCHistory(state, time.Hour); err != nil {
+		t.Fatalf("gc: %v", err)
+	}
+
+	blobPath := objectsDir("/board") + "/" + hash[:2] + "/" + hash
+	if exists, _ := afero.Exists(fs, blobPath); exists {
+		t.Fatalf("expected stale blob to be pruned")
+	}
+}
@@ -0,0 +1,104 @@
+package daedalus
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/netip"
+	"testing"
+)
+
+func validPNGBytes(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateIconData_AcceptsValidPNG(t *testing.T) {
+	if err := ValidateIconData(validPNGBytes(t), ".png"); err != nil {
+		t.Fatalf("expected valid png to pass, got %v", err)
+	}
+}
+
+func TestValidateIconData_RejectsNonPNGBytes(t *testing.T) {
+	err := ValidateIconData([]byte("not a png"), ".png")
+	if err == nil {
+		t.Fatal("expected non-PNG bytes saved as .png to be rejected")
+	}
+}
+
+func TestValidateIconData_AcceptsPlainSVG(t *testing.T) {
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><circle cx="5" cy="5" r="4"/></svg>`)
+	if err := ValidateIconData(svg, ".svg"); err != nil {
+		t.Fatalf("expected plain svg to pass, got %v", err)
+	}
+}
+
+func TestValidateIconData_RejectsSVGWithScript(t *testing.T) {
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)
+	if err := ValidateIconData(svg, ".svg"); err == nil {
+		t.Fatal("expected svg with <script> to be rejected")
+	}
+}
+
+func TestValidateIconData_RejectsSVGWithForeignObject(t *testing.T) {
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><foreignObject><p>hi</p></foreignObject></svg>`)
+	if err := ValidateIconData(svg, ".svg"); err == nil {
+		t.Fatal("expected svg with <foreignObject> to be rejected")
+	}
+}
+
+func TestValidateIconData_RejectsSVGWithJavascriptHref(t *testing.T) {
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><a xlink:href="javascript:alert(1)"><rect/></a></svg>`)
+	if err := ValidateIconData(svg, ".svg"); err == nil {
+		t.Fatal("expected svg with javascript: href to be rejected")
+	}
+}
+
+func TestValidateIconData_RejectsSVGWithExternalHref(t *testing.T) {
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><image href="http://evil.example/x.png"/></svg>`)
+	if err := ValidateIconData(svg, ".svg"); err == nil {
+		t.Fatal("expected svg with external href to be rejected")
+	}
+}
+
+func TestValidateIconData_AcceptsSVGWithDataHref(t *testing.T) {
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><image href="data:image/png;base64,aGk="/></svg>`)
+	if err := ValidateIconData(svg, ".svg"); err != nil {
+		t.Fatalf("expected svg with data: href to pass, got %v", err)
+	}
+}
+
+func TestValidateIconData_RejectsMismatchedContentType(t *testing.T) {
+	if err := ValidateIconData(validPNGBytes(t), ".svg"); err == nil {
+		t.Fatal("expected a PNG saved as .svg to be rejected")
+	}
+}
+
+func TestIsPrivateOrReservedIP(t *testing.T) {
+	cases := map[string]bool{
+		"127.0.0.1":     true,
+		"10.0.0.5":      true,
+		"192.168.1.1":   true,
+		"169.254.1.1":   true,
+		"fc00::1":       true,
+		"8.8.8.8":       false,
+		"93.184.216.34": false,
+		"2001:4860::1":  false,
+	}
+	for addr, want := range cases {
+		ip, err := netip.ParseAddr(addr)
+		if err != nil {
+			t.Fatalf("parse %s: %v", addr, err)
+		}
+		if got := isPrivateOrReservedIP(ip); got != want {
+			t.Errorf("isPrivateOrReservedIP(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package daedalus
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// archiveHeader is the small JSON header embedded at archive.json in a zip export,
+// recording the schema version the archive was written with.
+type archiveHeader struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	ExportedAt    time.Time `json:"exportedAt"`
+}
+
+// checkSchemaVersion refuses archives newer than this binary understands, and is the hook
+// point for migrating older ones forward as the schema evolves.
+func checkSchemaVersion(version int) error {
+	if version > CurrentSchemaVersion {
+		return fmt.Errorf("archive schema version %d is newer than supported version %d; upgrade daedalus to import it", version, CurrentSchemaVersion)
+	}
+	// No prior schema versions exist yet, so there is nothing to migrate.
+	return nil
+}
+
+// MergeStrategy controls how importExportBoard reconciles an imported card whose ID already
+// exists at destDir.
+type MergeStrategy int
+
+const (
+	// MergeReplace overwrites the on-disk card with the imported one. Also skips reconciling
+	// destDir's existing list entries into the imported config (see ImportJSON/ImportZip).
+	MergeReplace MergeStrategy = iota
+	// MergeSkipExisting leaves the on-disk card untouched and drops the imported one.
+	MergeSkipExisting
+	// MergeRenumberIDs assigns the imported card a new ID and appends it, so both the
+	// existing and imported card survive under distinct IDs.
+	MergeRenumberIDs
+)
+
+// ImportJSON reads an archive produced by WriteExportJSON and recreates board.yaml, list
+// directories, card files, and icons under destDir. If merge is true, existing list entries
+// and cards at destDir are preserved and reconciled (MergeRenumberIDs: a colliding card gets the
+// next free ID and a bottom-of-list position from ComputeInsertPosition rather than overwriting
+// the existing one). If merge is false, the
+// imported config replaces destDir's board.yaml outright and colliding cards are overwritten
+// (MergeReplace). See MergeImport for the other reconciliation strategies.
+func ImportJSON(path, destDir string, merge bool) error {
+	board, err := readJSONExportBoard(path)
+	if err != nil {
+		return err
+	}
+	return importExportBoard(board, destDir, mergeBoolToStrategy(merge))
+}
+
+// readJSONExportBoard reads and schema-checks an archive produced by WriteExportJSON.
+func readJSONExportBoard(path string) (ExportBoard, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ExportBoard{}, fmt.Errorf("reading export file: %w", err)
+	}
+
+	var board ExportBoard
+	if err := json.Unmarshal(data, &board); err != nil {
+		return ExportBoard{}, fmt.Errorf("parsing export file: %w", err)
+	}
+	if err := checkSchemaVersion(board.SchemaVersion); err != nil {
+		return ExportBoard{}, err
+	}
+	return board, nil
+}
+
+// mergeBoolToStrategy maps ImportJSON/ImportZip's legacy bool merge flag onto MergeStrategy.
+func mergeBoolToStrategy(merge bool) MergeStrategy {
+	if merge {
+		return MergeRenumberIDs
+	}
+	return MergeReplace
+}
+
+// ImportZip reads an archive produced by WriteExportZip and recreates board.yaml, list
+// directories, card files, and icons under destDir, with the same merge semantics as
+// ImportJSON.
+func ImportZip(path, destDir string, merge bool) error {
+	board, err := readZipExportBoard(path)
+	if err != nil {
+		return err
+	}
+	return importExportBoard(board, destDir, mergeBoolToStrategy(merge))
+}
+
+// readZipExportBoard reads and schema-checks an archive produced by WriteExportZip.
+func readZipExportBoard(path string) (ExportBoard, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return ExportBoard{}, fmt.Errorf("opening zip archive: %w", err)
+	}
+	defer zr.Close()
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	if hf, ok := files["archive.json"]; ok {
+		data, err := readZipFile(hf)
+		if err != nil {
+			return ExportBoard{}, fmt.Errorf("reading archive.json: %w", err)
+		}
+		var header archiveHeader
+		if err := json.Unmarshal(data, &header); err != nil {
+			return ExportBoard{}, fmt.Errorf("parsing archive.json: %w", err)
+		}
+		if err := checkSchemaVersion(header.SchemaVersion); err != nil {
+			return ExportBoard{}, err
+		}
+	} else {
+		slog.Warn("import: zip archive has no archive.json header, assuming current schema version", "path", path)
+	}
+
+	configData, ok := files["board.yaml"]
+	if !ok {
+		return ExportBoard{}, fmt.Errorf("zip archive is missing board.yaml")
+	}
+	configBytes, err := readZipFile(configData)
+	if err != nil {
+		return ExportBoard{}, fmt.Errorf("reading board.yaml: %w", err)
+	}
+	var config BoardConfig
+	if err := yaml.Unmarshal(configBytes, &config); err != nil {
+		return ExportBoard{}, fmt.Errorf("parsing board.yaml: %w", err)
+	}
+
+	board := ExportBoard{Config: &config}
+	entriesByDir := groupZipEntriesByDir(files)
+	for dirName := range entriesByDir {
+		board.Lists = append(board.Lists, ExportList{Dir: dirName})
+	}
+	for i := range board.Lists {
+		dir := board.Lists[i].Dir
+		for _, f := range entriesByDir[dir] {
+			data, err := readZipFile(f)
+			if err != nil {
+				return ExportBoard{}, fmt.Errorf("reading %s: %w", f.Name, err)
+			}
+			meta, body, err := parseCardBytes(data)
+			if err != nil {
+				slog.Warn("import: skipping unreadable card", "name", f.Name, "error", err)
+				continue
+			}
+			board.Lists[i].Cards = append(board.Lists[i].Cards, ExportCard{
+				ID:       meta.ID,
+				Title:    meta.Title,
+				Metadata: meta,
+				Body:     body,
+			})
+		}
+	}
+
+	for name, f := range files {
+		if !strings.HasPrefix(name, "_assets/icons/") {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return ExportBoard{}, fmt.Errorf("reading icon %s: %w", name, err)
+		}
+		board.Icons = append(board.Icons, ExportIcon{
+			Name:    strings.TrimPrefix(name, "_assets/icons/"),
+			Content: encodeIconContent(name, data),
+		})
+	}
+
+	return board, nil
+}
+
+// groupZipEntriesByDir buckets card entries ("<listDir>/<file>.md") by their list directory.
+func groupZipEntriesByDir(files map[string]*zip.File) map[string][]*zip.File {
+	result := make(map[string][]*zip.File)
+	for name, f := range files {
+		if name == "board.yaml" || name == "archive.json" || strings.HasPrefix(name, "_assets/") {
+			continue
+		}
+		parts := strings.SplitN(name, "/", 2)
+		if len(parts) != 2 || !strings.HasSuffix(parts[1], ".md") {
+			continue
+		}
+		result[parts[0]] = append(result[parts[0]], f)
+	}
+	return result
+}
+
+// readZipFile reads the full, uncompressed contents of a zip entry.
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// encodeIconContent mirrors readIconContent's encoding: raw text for SVG, base64 data
+// URI for PNG.
+func encodeIconContent(name string, data []byte) string {
+	if strings.HasSuffix(strings.ToLower(name), ".svg") {
+		return string(data)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// decodeIconContent reverses encodeIconContent/readIconContent, extracting raw bytes to
+// write back to disk.
+func decodeIconContent(content string) ([]byte, error) {
+	if strings.HasPrefix(content, "data:image/png;base64,") {
+		return base64.StdEncoding.DecodeString(strings.TrimPrefix(content, "data:image/png;base64,"))
+	}
+	return []byte(content), nil
+}
+
+// sanitizeExportLists validates each list's Dir via ValidateListName, dropping (with a
+// warning) any list whose directory name doesn't survive sanitization -- e.g. one containing
+// ".." or an absolute path, the zip-slip shape an attacker-supplied archive could carry.
+func sanitizeExportLists(lists []ExportList) []ExportList {
+	sanitized := make([]ExportList, 0, len(lists))
+	for _, list := range lists {
+		dir, err := ValidateListName(list.Dir)
+		if err != nil {
+			slog.Warn("import: skipping list with unsafe directory name", "dir", list.Dir, "error", err)
+			continue
+		}
+		list.Dir = dir
+		sanitized = append(sanitized, list)
+	}
+	return sanitized
+}
+
+// sanitizeExportIcons collapses each icon's Name down to its final path element via
+// filepath.Base, the same treatment FetchIconWithProgress gives a URL-derived filename, so a
+// traversal-laden icon name from an imported archive can't escape the icons directory.
+func sanitizeExportIcons(icons []ExportIcon) []ExportIcon {
+	sanitized := make([]ExportIcon, 0, len(icons))
+	for _, icon := range icons {
+		name := filepath.Base(icon.Name)
+		if name == "" || name == "." || name == string(filepath.Separator) {
+			slog.Warn("import: skipping icon with unsafe name", "name", icon.Name)
+			continue
+		}
+		icon.Name = name
+		sanitized = append(sanitized, icon)
+	}
+	return sanitized
+}
+
+// importExportBoard writes an ExportBoard's config, cards, and icons to destDir, reconciling
+// card ID collisions with an existing board there per strategy. board.Lists[].Dir and
+// board.Icons[].Name come straight from an imported archive (a zip/JSON export or, via
+// ImportCAR, a manifest.json), so both are sanitized here before anything is joined onto
+// destDir -- this is the single choke point ImportJSON, ImportZip, MergeImport, and ImportCAR
+// all funnel through, so fixing it here covers all four.
+func importExportBoard(board ExportBoard, destDir string, strategy MergeStrategy) error {
+	board.Lists = sanitizeExportLists(board.Lists)
+	board.Icons = sanitizeExportIcons(board.Icons)
+
+	existing, err := ScanBoard(context.Background(), destDir)
+	if err != nil {
+		existing = &BoardState{Lists: make(map[string][]KanbanCard), RootPath: destDir, Config: &BoardConfig{}}
+	}
+	maxID := existing.MaxID
+	knownIDs := make(map[int]bool)
+	for _, cards := range existing.Lists {
+		for _, c := range cards {
+			knownIDs[c.Metadata.ID] = true
+		}
+	}
+
+	config := board.Config
+	if config == nil {
+		config = &BoardConfig{}
+	}
+	if strategy != MergeReplace {
+		diskDirs := make(map[string]bool, len(board.Lists))
+		for _, l := range board.Lists {
+			diskDirs[l.Dir] = true
+		}
+		for _, entry := range existing.Config.Lists {
+			if FindListEntry(context.Background(), config.Lists, entry.Dir) < 0 {
+				config.Lists = append(config.Lists, entry)
+			}
+		}
+		MergeListEntries(config, diskDirs)
+	}
+	if err := SaveBoardConfig(context.Background(), destDir, config); err != nil {
+		return fmt.Errorf("writing board.yaml: %w", err)
+	}
+
+	for _, list := range board.Lists {
+		listDir := filepath.Join(destDir, list.Dir)
+		if err := os.MkdirAll(listDir, 0755); err != nil {
+			return fmt.Errorf("creating list dir %s: %w", list.Dir, err)
+		}
+		for _, card := range list.Cards {
+			meta := card.Metadata
+			if knownIDs[meta.ID] {
+				switch strategy {
+				case MergeSkipExisting:
+					continue
+				case MergeRenumberIDs:
+					maxID++
+					meta.ID = maxID
+					meta.ListOrder, _ = ComputeInsertPosition(existing.Lists[list.Dir], "bottom")
+				case MergeReplace:
+					// Overwrite the on-disk card in place below.
+				}
+			}
+			knownIDs[meta.ID] = true
+			if meta.ID > maxID {
+				maxID = meta.ID
+			}
+
+			dest := filepath.Join(listDir, fmt.Sprintf("%d.md", meta.ID))
+			if !pathWithinRoot(destDir, dest) {
+				slog.Warn("import: skipping card with unsafe path", "list", list.Dir, "id", meta.ID)
+				continue
+			}
+			if err := WriteCardFile(context.Background(), dest, meta, card.Body); err != nil {
+				return fmt.Errorf("writing card %s: %w", dest, err)
+			}
+			existing.Lists[list.Dir] = append(existing.Lists[list.Dir], KanbanCard{
+				FilePath: dest,
+				ListName: list.Dir,
+				Metadata: meta,
+			})
+		}
+	}
+
+	if len(board.Icons) > 0 {
+		iconsDir := filepath.Join(destDir, "_assets", "icons")
+		if err := os.MkdirAll(iconsDir, 0755); err != nil {
+			return fmt.Errorf("creating icons dir: %w", err)
+		}
+		for _, icon := range board.Icons {
+			dest := filepath.Join(iconsDir, icon.Name)
+			if !pathWithinRoot(destDir, dest) {
+				slog.Warn("import: skipping icon with unsafe path", "name", icon.Name)
+				continue
+			}
+			if strategy == MergeSkipExisting {
+				if _, err := os.Stat(dest); err == nil {
+					continue
+				}
+			}
+			data, err := decodeIconContent(icon.Content)
+			if err != nil {
+				slog.Warn("import: skipping icon with invalid content", "name", icon.Name, "error", err)
+				continue
+			}
+			if err := os.WriteFile(dest, data, 0644); err != nil {
+				return fmt.Errorf("writing icon %s: %w", icon.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MergeImport reads an archive (JSON or zip, detected from path's extension) and imports it into
+// destDir per strategy -- MergeReplace, MergeSkipExisting, or MergeRenumberIDs. It is the general
+// entry point behind ImportJSON/ImportZip's legacy bool merge flag, for callers that need
+// MergeSkipExisting rather than just "merge or replace".
+func MergeImport(path, destDir string, strategy MergeStrategy) error {
+	var board ExportBoard
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".zip":
+		board, err = readZipExportBoard(path)
+	case ".json":
+		board, err = readJSONExportBoard(path)
+	default:
+		return fmt.Errorf("unrecognized import format %q (expected .json or .zip)", path)
+	}
+	if err != nil {
+		return err
+	}
+	return importExportBoard(board, destDir, strategy)
+}
@@ -0,0 +1,143 @@
+package daedalus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Exporting then importing a board as JSON should round-trip its cards into a fresh directory.
+func TestImportJSON_RoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(srcDir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(srcDir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card One"}, "# Card One\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), srcDir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	state, err := ScanBoard(context.Background(), srcDir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	board, err := BuildExportBoard(state, filepath.Join(srcDir, "_assets", "icons"))
+	if err != nil {
+		t.Fatalf("build export: %v", err)
+	}
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	if err := WriteExportJSON(board, exportPath); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+
+	destDir := t.TempDir()
+	if err := ImportJSON(exportPath, destDir, false); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	imported, err := ScanBoard(context.Background(), destDir)
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	cards := imported.Lists["todo"]
+	if len(cards) != 1 || cards[0].Metadata.Title != "Card One" {
+		t.Fatalf("expected imported card 'Card One', got %+v", cards)
+	}
+}
+
+// ImportJSON should refuse an archive whose schema version is newer than this binary supports.
+func TestImportJSON_RefusesNewerSchema(t *testing.T) {
+	board := ExportBoard{SchemaVersion: CurrentSchemaVersion + 1, Config: &BoardConfig{}}
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	if err := WriteExportJSON(board, exportPath); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+
+	if err := ImportJSON(exportPath, t.TempDir(), false); err == nil {
+		t.Fatalf("expected import of a newer schema version to be refused")
+	}
+}
+
+// Importing with merge=true should keep an existing card alongside the imported one.
+func TestImportJSON_Merge(t *testing.T) {
+	destDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(destDir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(destDir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Existing"}, "body\n"); err != nil {
+		t.Fatalf("write existing: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), destDir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	board := ExportBoard{
+		SchemaVersion: CurrentSchemaVersion,
+		Config:        &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}},
+		Lists: []ExportList{{
+			Dir:   "todo",
+			Cards: []ExportCard{{ID: 1, Title: "Incoming", Metadata: CardMetadata{ID: 1, Title: "Incoming"}, Body: "body\n"}},
+		}},
+	}
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	if err := WriteExportJSON(board, exportPath); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+
+	if err := ImportJSON(exportPath, destDir, true); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	result, err := ScanBoard(context.Background(), destDir)
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	cards := result.Lists["todo"]
+	if len(cards) != 2 {
+		t.Fatalf("expected 2 cards after merge import, got %d", len(cards))
+	}
+}
+
+// MergeImport with MergeSkipExisting should leave a colliding on-disk card untouched and drop
+// the incoming one, rather than renumbering or overwriting it.
+func TestMergeImport_SkipExisting(t *testing.T) {
+	destDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(destDir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(destDir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Existing"}, "body\n"); err != nil {
+		t.Fatalf("write existing: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), destDir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	board := ExportBoard{
+		SchemaVersion: CurrentSchemaVersion,
+		Config:        &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}},
+		Lists: []ExportList{{
+			Dir:   "todo",
+			Cards: []ExportCard{{ID: 1, Title: "Incoming", Metadata: CardMetadata{ID: 1, Title: "Incoming"}, Body: "body\n"}},
+		}},
+	}
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	if err := WriteExportJSON(board, exportPath); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+
+	if err := MergeImport(exportPath, destDir, MergeSkipExisting); err != nil {
+		t.Fatalf("merge import: %v", err)
+	}
+
+	result, err := ScanBoard(context.Background(), destDir)
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	cards := result.Lists["todo"]
+	if len(cards) != 1 || cards[0].Metadata.Title != "Existing" {
+		t.Fatalf("expected the existing card to survive untouched, got %+v", cards)
+	}
+}
@@ -0,0 +1,46 @@
+package daedalus
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// ContentHash returns the truncated hex SHA-256 digest of a card body, stored as
+// CardMetadata.ContentSHA256 so a later scan can tell whether the body was edited outside
+// Daedalus since the last confirmed save.
+func ContentHash(body string) string {
+	return hashBlob([]byte(body))
+}
+
+// backupPath returns where cardID's last confirmed-save snapshot is kept, written by
+// WriteCardBackupFs after every successful SaveCard so an out-of-band edit can be reverted via
+// RestoreCardBackupFs.
+func backupPath(rootPath string, cardID int) string {
+	return filepath.Join(rootPath, ".daedalus", "backups", fmt.Sprintf("%d.md.bak", cardID))
+}
+
+// WriteCardBackupFs snapshots a card file's full raw contents (frontmatter and body) right
+// after a confirmed save.
+func WriteCardBackupFs(fs afero.Fs, rootPath string, cardID int, data []byte) error {
+	path := backupPath(rootPath, cardID)
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating backups dir: %w", err)
+	}
+	return afero.WriteFile(fs, path, data, 0644)
+}
+
+// RestoreCardBackupFs overwrites path with cardID's last backup snapshot, discarding whatever
+// out-of-band edit is currently on disk, and returns the restored bytes so the caller can
+// re-derive in-memory metadata from them without a second read.
+func RestoreCardBackupFs(fs afero.Fs, rootPath, path string, cardID int) ([]byte, error) {
+	data, err := afero.ReadFile(fs, backupPath(rootPath, cardID))
+	if err != nil {
+		return nil, fmt.Errorf("reading backup for card %d: %w", cardID, err)
+	}
+	if err := writeFileAtomicFs(fs, path, data, 0644); err != nil {
+		return nil, fmt.Errorf("restoring backup for card %d: %w", cardID, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,156 @@
+package daedalus
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// archivedListsDirName is the top-level directory under a board's root that holds
+// soft-deleted lists (see ArchiveListFs).
+const archivedListsDirName = ".archive"
+
+// archivedListTimeFormat is the UTC timestamp prefix used in archived list dir names, chosen
+// so lexical sort order matches chronological order (same convention as snapshotTimeFormat).
+const archivedListTimeFormat = "20060102T150405Z"
+
+// CreateListOnDisk creates a new empty list directory on the real OS filesystem, adds it to
+// config, and persists config to rootPath. See CreateListOnDiskFs.
+func CreateListOnDisk(rootPath, name string, config *BoardConfig) error {
+	return CreateListOnDiskFs(afero.NewOsFs(), rootPath, name, config)
+}
+
+// CreateListOnDiskFs creates a new empty list directory on fs, adds it to config, and
+// persists config to rootPath.
+func CreateListOnDiskFs(fs afero.Fs, rootPath, name string, config *BoardConfig) error {
+	if err := fs.MkdirAll(filepath.Join(rootPath, name), 0755); err != nil {
+		return fmt.Errorf("creating list directory: %w", err)
+	}
+	config.Lists = append(config.Lists, ListEntry{Dir: name})
+	return SaveBoardConfigFs(context.Background(), fs, rootPath, config)
+}
+
+// DeleteListOnDisk removes a list on the real OS filesystem, archiving it by default (see
+// ArchiveListFs) rather than deleting it outright. See DeleteListOnDiskFs.
+func DeleteListOnDisk(rootPath, name string, config *BoardConfig) error {
+	return DeleteListOnDiskFs(afero.NewOsFs(), rootPath, name, config, false)
+}
+
+// DeleteListOnDiskFs removes a list on fs: by default it's archived (moved under
+// <rootPath>/.archive, recoverable via UnarchiveListFs), and only hard-deleted when permanent
+// is true. Either way config is updated and persisted to rootPath.
+func DeleteListOnDiskFs(fs afero.Fs, rootPath, name string, config *BoardConfig, permanent bool) error {
+	if !permanent {
+		cards, _, bytes := scanListFs(fs, filepath.Join(rootPath, name), name)
+		_, err := ArchiveListFs(fs, rootPath, name, config, len(cards), bytes)
+		return err
+	}
+
+	if err := fs.RemoveAll(filepath.Join(rootPath, name)); err != nil {
+		return fmt.Errorf("removing list directory: %w", err)
+	}
+	if idx := FindListEntry(context.Background(), config.Lists, name); idx >= 0 {
+		config.Lists = append(config.Lists[:idx], config.Lists[idx+1:]...)
+	}
+	return SaveBoardConfigFs(context.Background(), fs, rootPath, config)
+}
+
+// ArchiveListFs moves list name's directory to <rootPath>/.archive/<timestamp>-<name>,
+// removes it from config.Lists, records an ArchivedList entry (with the card count and byte
+// total the caller already knows from its in-memory state), and persists config. Use
+// UnarchiveListFs to reverse this.
+func ArchiveListFs(fs afero.Fs, rootPath, name string, config *BoardConfig, cardCount int, bytes int64) (ArchivedList, error) {
+	if err := fs.MkdirAll(filepath.Join(rootPath, archivedListsDirName), 0755); err != nil {
+		return ArchivedList{}, fmt.Errorf("creating archive directory: %w", err)
+	}
+
+	now := time.Now().UTC()
+	archiveDir := fmt.Sprintf("%s-%s", now.Format(archivedListTimeFormat), name)
+	src := filepath.Join(rootPath, name)
+	dst := filepath.Join(rootPath, archivedListsDirName, archiveDir)
+	if err := fs.Rename(src, dst); err != nil {
+		return ArchivedList{}, fmt.Errorf("moving list to archive: %w", err)
+	}
+
+	entry := ArchivedList{
+		Dir:          archiveDir,
+		OriginalName: name,
+		ArchivedAt:   now,
+		CardCount:    cardCount,
+		Bytes:        bytes,
+	}
+	config.Archived = append(config.Archived, entry)
+	if idx := FindListEntry(context.Background(), config.Lists, name); idx >= 0 {
+		config.Lists = append(config.Lists[:idx], config.Lists[idx+1:]...)
+	}
+	if err := SaveBoardConfigFs(context.Background(), fs, rootPath, config); err != nil {
+		return ArchivedList{}, err
+	}
+	return entry, nil
+}
+
+// UnarchiveListFs moves an archived list (identified by its ArchivedList.Dir) back to its
+// OriginalName at the board root, re-adds it to config.Lists, removes its ArchivedList entry,
+// persists config, and rescans the restored directory so the caller can merge its cards back
+// into in-memory state.
+func UnarchiveListFs(fs afero.Fs, rootPath, archiveDir string, config *BoardConfig) (cards []KanbanCard, maxID int, bytes int64, err error) {
+	idx := -1
+	for i, a := range config.Archived {
+		if a.Dir == archiveDir {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, 0, 0, fmt.Errorf("archived list %q not found", archiveDir)
+	}
+	entry := config.Archived[idx]
+
+	if FindListEntry(context.Background(), config.Lists, entry.OriginalName) >= 0 {
+		return nil, 0, 0, fmt.Errorf("a list named %q already exists", entry.OriginalName)
+	}
+
+	src := filepath.Join(rootPath, archivedListsDirName, archiveDir)
+	dst := filepath.Join(rootPath, entry.OriginalName)
+	if err := fs.Rename(src, dst); err != nil {
+		return nil, 0, 0, fmt.Errorf("restoring list from archive: %w", err)
+	}
+
+	config.Archived = append(config.Archived[:idx], config.Archived[idx+1:]...)
+	config.Lists = append(config.Lists, ListEntry{Dir: entry.OriginalName})
+	if err := SaveBoardConfigFs(context.Background(), fs, rootPath, config); err != nil {
+		return nil, 0, 0, err
+	}
+
+	cards, maxID, bytes = scanListFs(fs, dst, entry.OriginalName)
+	return cards, maxID, bytes, nil
+}
+
+// PurgeArchivedFs permanently deletes archived lists older than olderThan, removing their
+// directories and ArchivedList entries. Returns the total bytes reclaimed.
+func PurgeArchivedFs(fs afero.Fs, rootPath string, config *BoardConfig, olderThan time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-olderThan)
+	var freed int64
+	var kept []ArchivedList
+
+	for _, entry := range config.Archived {
+		if entry.ArchivedAt.After(cutoff) {
+			kept = append(kept, entry)
+			continue
+		}
+		path := filepath.Join(rootPath, archivedListsDirName, entry.Dir)
+		if err := fs.RemoveAll(path); err != nil {
+			return freed, fmt.Errorf("purging archived list %q: %w", entry.Dir, err)
+		}
+		freed += entry.Bytes
+	}
+
+	config.Archived = kept
+	if err := SaveBoardConfigFs(context.Background(), fs, rootPath, config); err != nil {
+		return freed, err
+	}
+	return freed, nil
+}
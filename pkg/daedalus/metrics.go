@@ -1,6 +1,47 @@
 package daedalus
 
+import "sync"
+
 // ClockTicksPerSec is the assumed clock tick rate for CPU time calculations.
 // On Linux this is USER_HZ (100). On other platforms the CPU metrics return 0,
 // so the value is unused but must exist for compilation.
 const ClockTicksPerSec = 100
+
+// Histogram is a minimal Prometheus-compatible cumulative histogram: bucket i counts every
+// observation less than or equal to its upper bound, matching client_golang's HistogramVec
+// semantics without pulling in that dependency.
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+// NewHistogram returns a Histogram with the given bucket upper bounds, which must be sorted
+// ascending.
+func NewHistogram(buckets []float64) *Histogram {
+	return &Histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+// Observe records v into every bucket whose upper bound is >= v, and into the running sum/count.
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sum += v
+	h.count++
+	for i, bound := range h.buckets {
+		if v <= bound {
+			h.counts[i]++
+		}
+	}
+}
+
+// Snapshot returns a consistent copy of the histogram's bucket bounds, per-bucket counts, sum,
+// and total count, for a caller (e.g. a Prometheus text-format renderer) to format without
+// holding the lock itself.
+func (h *Histogram) Snapshot() (buckets []float64, counts []uint64, sum float64, count uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]float64(nil), h.buckets...), append([]uint64(nil), h.counts...), h.sum, h.count
+}
@@ -0,0 +1,41 @@
+package daedalus
+
+import "os"
+
+// Progress receives updates about a long-running, item-by-item operation such as an export,
+// import, or snapshot. Callers that don't care about progress reporting can pass NoopProgress.
+type Progress interface {
+	// Start is called once with the total number of items the operation expects to process
+	// and a short name for the operation (e.g. "export-zip").
+	Start(total int, op string)
+	// Advance is called after each item (or small batch of items) completes, with n items
+	// processed and a short description of what was just processed.
+	Advance(n int, detail string)
+	// Done is called once the operation finishes, with a non-nil err if it failed.
+	Done(err error)
+}
+
+// NoopProgress implements Progress by doing nothing. It is the default when a caller doesn't
+// supply its own Progress.
+type NoopProgress struct{}
+
+func (NoopProgress) Start(total int, op string)   {}
+func (NoopProgress) Advance(n int, detail string) {}
+func (NoopProgress) Done(err error)               {}
+
+// countExportItems returns the number of items (config + cards + icons) an export of state
+// will process, for Progress.Start's total.
+func countExportItems(state *BoardState, iconsDir string) int {
+	total := 1 // board.yaml / config
+	for _, cards := range state.Lists {
+		total += len(cards)
+	}
+	if entries, err := os.ReadDir(iconsDir); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && IsIconExt(entry.Name()) {
+				total++
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,101 @@
+package daedalus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeProgress records every call made to it so tests can assert Start/Advance/Done were
+// driven correctly by ExportJSONCtx/ExportZipCtx.
+type fakeProgress struct {
+	total     int
+	op        string
+	advances  int
+	doneCalls int
+	doneErr   error
+}
+
+func (f *fakeProgress) Start(total int, op string) {
+	f.total = total
+	f.op = op
+}
+
+func (f *fakeProgress) Advance(n int, detail string) {
+	f.advances += n
+}
+
+func (f *fakeProgress) Done(err error) {
+	f.doneCalls++
+	f.doneErr = err
+}
+
+func setupExportBoard(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card One"}, "body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), dir, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	return dir
+}
+
+// ExportJSONCtx should report one Start with the total item count, one Advance per item
+// (board.yaml plus each card), and exactly one terminal Done(nil) on success.
+func TestExportJSONCtx_ReportsProgress(t *testing.T) {
+	dir := setupExportBoard(t)
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	iconsDir := filepath.Join(dir, "_assets", "icons")
+
+	progress := &fakeProgress{}
+	outPath := filepath.Join(dir, "export.json")
+	if err := ExportJSONCtx(context.Background(), state, iconsDir, outPath, progress); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if progress.op != "export-json" {
+		t.Fatalf("expected op %q, got %q", "export-json", progress.op)
+	}
+	if progress.total != 2 { // board.yaml + 1 card
+		t.Fatalf("expected total 2, got %d", progress.total)
+	}
+	if progress.advances != progress.total {
+		t.Fatalf("expected %d advances, got %d", progress.total, progress.advances)
+	}
+	if progress.doneCalls != 1 || progress.doneErr != nil {
+		t.Fatalf("expected exactly one Done(nil), got %d calls with err %v", progress.doneCalls, progress.doneErr)
+	}
+}
+
+// A canceled context should stop ExportZipCtx partway through and report the cancellation
+// error via Done rather than completing the archive.
+func TestExportZipCtx_AbortsOnCanceledContext(t *testing.T) {
+	dir := setupExportBoard(t)
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	iconsDir := filepath.Join(dir, "_assets", "icons")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	progress := &fakeProgress{}
+	outPath := filepath.Join(dir, "export.zip")
+	err = ExportZipCtx(ctx, dir, state, iconsDir, outPath, progress)
+	if err == nil {
+		t.Fatalf("expected an error from a canceled context")
+	}
+	if progress.doneCalls != 1 || progress.doneErr != err {
+		t.Fatalf("expected Done to be called once with the cancellation error, got %d calls with err %v", progress.doneCalls, progress.doneErr)
+	}
+}
@@ -0,0 +1,156 @@
+package daedalus
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// AlgorithmSM2 identifies the SM-2 spaced-repetition algorithm in CardMetadata.Review.Algorithm.
+const AlgorithmSM2 = "sm2"
+
+// minEase is the floor SM-2 allows the ease factor to decay to.
+const minEase = 1.3
+
+// scheduleSM2 applies the SM-2 algorithm to an existing (or zero-value) review
+// state given a review quality score in 0..5, returning the updated state.
+// A quality below 3 is a lapse: the streak resets and the card is due again
+// in a day. Otherwise the streak grows, the ease factor is adjusted, and the
+// interval is computed from the classic SM-2 table (1 day, 6 days, then
+// previous interval * ease).
+func scheduleSM2(prev ReviewState, quality int, now time.Time) ReviewState {
+	next := prev
+	next.Algorithm = AlgorithmSM2
+	next.LastReviewed = now
+
+	if next.Ease == 0 {
+		next.Ease = 2.5
+	}
+
+	if quality < 3 {
+		next.Streak = 0
+		next.NextReview = now.Add(24 * time.Hour)
+		return next
+	}
+
+	prevInterval := 0.0
+	if !prev.LastReviewed.IsZero() && !prev.NextReview.IsZero() {
+		prevInterval = prev.NextReview.Sub(prev.LastReviewed).Hours() / 24
+	}
+
+	next.Streak = prev.Streak + 1
+	next.Ease = math.Max(minEase, next.Ease+0.1-float64(5-quality)*(0.08+float64(5-quality)*0.02))
+
+	var intervalDays float64
+	switch next.Streak {
+	case 1:
+		intervalDays = 1
+	case 2:
+		intervalDays = 6
+	default:
+		if prevInterval <= 0 {
+			prevInterval = 6
+		}
+		intervalDays = math.Round(prevInterval * next.Ease)
+	}
+	next.NextReview = now.Add(time.Duration(intervalDays*24) * time.Hour)
+	return next
+}
+
+// DueCards returns every card across the board whose review is due at or before now.
+// Cards without a Review entry are not considered part of the review deck.
+func DueCards(state *BoardState, now time.Time) []KanbanCard {
+	var due []KanbanCard
+	for _, cards := range state.Lists {
+		for _, card := range cards {
+			if card.Metadata.Review == nil {
+				continue
+			}
+			if !card.Metadata.Review.NextReview.After(now) {
+				due = append(due, card)
+			}
+		}
+	}
+	return due
+}
+
+// DueCardsInList returns every card in listDir whose review is due at or before before. Cards
+// without a Review entry are not considered part of the review deck.
+func DueCardsInList(state *BoardState, listDir string, before time.Time) []KanbanCard {
+	var due []KanbanCard
+	for _, card := range state.Lists[listDir] {
+		if card.Metadata.Review == nil {
+			continue
+		}
+		if !card.Metadata.Review.NextReview.After(before) {
+			due = append(due, card)
+		}
+	}
+	return due
+}
+
+// ScanReviewQueue scans the board at root and returns every due card (see DueCards), soonest-due
+// first, for callers such as the CLI that want today's review queue without loading the board
+// through the App/Wails layer (see App.ListDueCards).
+func ScanReviewQueue(root string) ([]KanbanCard, error) {
+	state, err := ScanBoard(context.Background(), root)
+	if err != nil {
+		return nil, fmt.Errorf("scanning board: %w", err)
+	}
+	due := DueCards(state, time.Now())
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].Metadata.Review.NextReview.Before(due[j].Metadata.Review.NextReview)
+	})
+	return due, nil
+}
+
+// GradeReview grades quality (0..5) for the card file at cardPath directly, without a loaded
+// BoardState -- for callers such as the CLI that operate on a single card file rather than
+// through App.ReviewCard. It's RecordReview with the card's metadata read from disk first.
+func GradeReview(cardPath string, quality int) error {
+	return GradeReviewFs(afero.NewOsFs(), cardPath, quality, time.Now())
+}
+
+// GradeReviewFs is GradeReview against fs instead of the real OS filesystem.
+func GradeReviewFs(fs afero.Fs, cardPath string, quality int, now time.Time) error {
+	meta, _, err := readCardFileFs(fs, cardPath)
+	if err != nil {
+		return fmt.Errorf("reading card: %w", err)
+	}
+	card := KanbanCard{FilePath: cardPath, Metadata: meta}
+	return RecordReviewFs(fs, &card, quality, now)
+}
+
+// RecordReview scores a review of quality (0..5) against card, runs the SM-2
+// scheduler, and persists the updated review state via WriteCardFile.
+func RecordReview(card *KanbanCard, quality int, now time.Time) error {
+	return RecordReviewFs(afero.NewOsFs(), card, quality, now)
+}
+
+// RecordReviewFs is RecordReview against fs instead of the real OS filesystem.
+func RecordReviewFs(fs afero.Fs, card *KanbanCard, quality int, now time.Time) error {
+	if quality < 0 || quality > 5 {
+		return fmt.Errorf("review quality must be in 0..5, got %d", quality)
+	}
+
+	prev := ReviewState{}
+	if card.Metadata.Review != nil {
+		prev = *card.Metadata.Review
+	}
+	updated := scheduleSM2(prev, quality, now)
+	card.Metadata.Review = &updated
+	card.Metadata.Updated = &now
+
+	body, err := ReadCardContentFs(context.Background(), fs, card.FilePath)
+	if err != nil {
+		return fmt.Errorf("reading card body: %w", err)
+	}
+	if err := WriteCardFileFs(context.Background(), fs, card.FilePath, card.Metadata, body); err != nil {
+		return fmt.Errorf("writing reviewed card: %w", err)
+	}
+	return nil
+}
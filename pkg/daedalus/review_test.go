@@ -0,0 +1,175 @@
+package daedalus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// A lapse (quality < 3) should reset the streak and schedule a 1-day review.
+func TestScheduleSM2_Lapse(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := ReviewState{Streak: 4, Ease: 2.2}
+
+	next := scheduleSM2(prev, 1, now)
+
+	if next.Streak != 0 {
+		t.Errorf("expected streak reset to 0, got %d", next.Streak)
+	}
+	if !next.NextReview.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("expected next review 1 day out, got %v", next.NextReview)
+	}
+}
+
+// The first two successful reviews follow the fixed SM-2 intervals of 1 and 6 days.
+func TestScheduleSM2_FirstIntervals(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := scheduleSM2(ReviewState{}, 4, now)
+	if first.Streak != 1 || !first.NextReview.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("expected streak=1, next in 1 day; got streak=%d next=%v", first.Streak, first.NextReview)
+	}
+
+	second := scheduleSM2(first, 4, first.NextReview)
+	if second.Streak != 2 || !second.NextReview.Equal(first.NextReview.Add(6*24*time.Hour)) {
+		t.Errorf("expected streak=2, next in 6 days; got streak=%d next=%v", second.Streak, second.NextReview)
+	}
+}
+
+// Ease should never drop below the SM-2 floor of 1.3, even with repeated low-quality reviews.
+func TestScheduleSM2_EaseFloor(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := ReviewState{Streak: 2, Ease: 1.3}
+
+	for i := 0; i < 5; i++ {
+		state = scheduleSM2(state, 3, now)
+		now = state.NextReview
+	}
+
+	if state.Ease < minEase {
+		t.Errorf("ease fell below floor: %v", state.Ease)
+	}
+}
+
+// DueCards should only return cards with a Review set whose NextReview has arrived.
+func TestDueCards(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := &BoardState{
+		Lists: map[string][]KanbanCard{
+			"deck": {
+				{Metadata: CardMetadata{ID: 1, Review: &ReviewState{NextReview: now.Add(-time.Hour)}}},
+				{Metadata: CardMetadata{ID: 2, Review: &ReviewState{NextReview: now.Add(time.Hour)}}},
+				{Metadata: CardMetadata{ID: 3}},
+			},
+		},
+	}
+
+	due := DueCards(state, now)
+	if len(due) != 1 || due[0].Metadata.ID != 1 {
+		t.Fatalf("expected only card 1 due, got %+v", due)
+	}
+}
+
+// DueCardsInList should scope DueCards' "is it due" check to a single list.
+func TestDueCardsInList(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := &BoardState{
+		Lists: map[string][]KanbanCard{
+			"deck":  {{Metadata: CardMetadata{ID: 1, Review: &ReviewState{NextReview: now.Add(-time.Hour)}}}},
+			"other": {{Metadata: CardMetadata{ID: 2, Review: &ReviewState{NextReview: now.Add(-time.Hour)}}}},
+		},
+	}
+
+	due := DueCardsInList(state, "deck", now)
+	if len(due) != 1 || due[0].Metadata.ID != 1 {
+		t.Fatalf("expected only card 1 due in deck, got %+v", due)
+	}
+}
+
+// RecordReview rejects out-of-range quality scores without touching the card.
+func TestRecordReview_InvalidQuality(t *testing.T) {
+	card := &KanbanCard{Metadata: CardMetadata{ID: 1}}
+	if err := RecordReview(card, 6, time.Now()); err == nil {
+		t.Fatal("expected error for out-of-range quality")
+	}
+}
+
+// GradeReviewFs should round-trip review state through the card file and follow the same
+// 1-day-then-6-day SM-2 progression as TestScheduleSM2_FirstIntervals for two quality-5 reviews.
+func TestGradeReviewFs_RoundTrip(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := "/board/deck/1.md"
+	meta := CardMetadata{ID: 1, Title: "Flashcard"}
+	if err := WriteCardFileFs(context.Background(), fs, path, meta, "# Flashcard\n\nWhat is SM-2?\n"); err != nil {
+		t.Fatalf("WriteCardFileFs: %v", err)
+	}
+
+	first := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := GradeReviewFs(fs, path, 5, first); err != nil {
+		t.Fatalf("GradeReviewFs (first): %v", err)
+	}
+	gotMeta, body, err := readCardFileFs(fs, path)
+	if err != nil {
+		t.Fatalf("readCardFileFs: %v", err)
+	}
+	if body != "# Flashcard\n\nWhat is SM-2?\n" {
+		t.Errorf("body should round-trip unchanged, got %q", body)
+	}
+	if gotMeta.Review == nil || gotMeta.Review.Streak != 1 || !gotMeta.Review.NextReview.Equal(first.Add(24*time.Hour)) {
+		t.Fatalf("expected streak=1, next review in 1 day; got %+v", gotMeta.Review)
+	}
+	if gotMeta.Review.Algorithm != AlgorithmSM2 {
+		t.Errorf("Algorithm: got %q, want %q", gotMeta.Review.Algorithm, AlgorithmSM2)
+	}
+
+	second := gotMeta.Review.NextReview
+	if err := GradeReviewFs(fs, path, 5, second); err != nil {
+		t.Fatalf("GradeReviewFs (second): %v", err)
+	}
+	gotMeta, _, err = readCardFileFs(fs, path)
+	if err != nil {
+		t.Fatalf("readCardFileFs: %v", err)
+	}
+	if gotMeta.Review.Streak != 2 || !gotMeta.Review.NextReview.Equal(second.Add(6*24*time.Hour)) {
+		t.Fatalf("expected streak=2, next review 6 days later; got %+v", gotMeta.Review)
+	}
+}
+
+// ScanReviewQueue should return only due cards, soonest-due first.
+func TestScanReviewQueue_SortedByDueDate(t *testing.T) {
+	root := t.TempDir()
+	deck := filepath.Join(root, "00___deck")
+	if err := os.Mkdir(deck, 0755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	now := time.Now().UTC()
+	soon := now.Add(-2 * time.Hour)
+	soonest := now.Add(-5 * time.Hour)
+	notYet := now.Add(5 * time.Hour)
+
+	write := func(name string, id int, next time.Time) {
+		meta := CardMetadata{ID: id, Title: name, Review: &ReviewState{Algorithm: AlgorithmSM2, NextReview: next}}
+		if err := WriteCardFile(context.Background(), filepath.Join(deck, name+".md"), meta, "# "+name+"\n"); err != nil {
+			t.Fatalf("WriteCardFile: %v", err)
+		}
+	}
+	write("b", 2, soon)
+	write("a", 1, soonest)
+	write("c", 3, notYet)
+
+	due, err := ScanReviewQueue(root)
+	if err != nil {
+		t.Fatalf("ScanReviewQueue: %v", err)
+	}
+	if len(due) != 2 {
+		t.Fatalf("expected 2 due cards, got %d", len(due))
+	}
+	if due[0].Metadata.ID != 1 || due[1].Metadata.ID != 2 {
+		t.Errorf("expected soonest-due first: IDs %d, %d", due[0].Metadata.ID, due[1].Metadata.ID)
+	}
+}
@@ -3,6 +3,7 @@ package daedalus
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -14,13 +15,40 @@ import (
 	"sync"
 	"time"
 
+	"github.com/spf13/afero"
 	"gopkg.in/yaml.v3"
 )
 
 const bodyPreviewMaxLines = 20
 
-// ScanBoard scans directory and builds in-memory state
-func ScanBoard(rootPath string) (*BoardState, error) {
+// ScanBoard scans directory and builds in-memory state, dispatching to the afero.Fs backend
+// selected by the board's storage config (see ScanBoardWithStorage) -- the OS filesystem for
+// the default local driver, or a remote one such as S3 if board.yaml configures it.
+func ScanBoard(ctx context.Context, rootPath string) (*BoardState, error) {
+	return ScanBoardWithStorage(ctx, rootPath)
+}
+
+// NewBoardOnDisk scans rootPath on the real OS filesystem and returns the resulting
+// state with its Fs set, so later mutations (WriteCardFileFs, etc.) reuse the same backend.
+func NewBoardOnDisk(rootPath string) (*BoardState, error) {
+	return ScanBoardFs(context.Background(), afero.NewOsFs(), rootPath)
+}
+
+// NewBoardInMemory returns an empty board backed by an in-memory filesystem, rooted at "/".
+// Intended for tests and embedded demo boards that should never touch real disk.
+func NewBoardInMemory() *BoardState {
+	return &BoardState{
+		Lists:    make(map[string][]KanbanCard),
+		RootPath: "/",
+		Config:   &BoardConfig{},
+		Fs:       afero.NewMemMapFs(),
+	}
+}
+
+// ScanBoardFs scans a board rooted at rootPath on the given filesystem and builds in-memory state.
+// ctx is checked between cards as the walk progresses (see scanListFs), so a caller such as
+// App.LoadBoard can abort a scan of a very large board rather than blocking until it finishes.
+func ScanBoardFs(ctx context.Context, fs afero.Fs, rootPath string) (*BoardState, error) {
 	absRoot, err := filepath.Abs(rootPath)
 	if err != nil {
 		return nil, fmt.Errorf("resolving root path: %w", err)
@@ -30,15 +58,16 @@ func ScanBoard(rootPath string) (*BoardState, error) {
 		Lists:    make(map[string][]KanbanCard),
 		RootPath: absRoot,
 		MaxID:    0,
+		Fs:       fs,
 	}
 
-	entries, err := os.ReadDir(absRoot)
+	entries, err := afero.ReadDir(fs, absRoot)
 	if err != nil {
 		return nil, err
 	}
 
 	configStart := time.Now()
-	config, err := LoadBoardConfig(absRoot)
+	config, err := LoadBoardConfigFs(fs, absRoot)
 	if err != nil {
 		return nil, fmt.Errorf("loading board config: %w", err)
 	}
@@ -58,7 +87,7 @@ func ScanBoard(rootPath string) (*BoardState, error) {
 			wg.Add(1)
 			go func(path, name string) {
 				defer wg.Done()
-				cards, localMaxID, localBytes := scanList(path, name)
+				cards, localMaxID, localBytes := scanListFs(ctx, fs, path, name)
 
 				mutex.Lock()
 				state.Lists[name] = cards
@@ -76,9 +105,16 @@ func ScanBoard(rootPath string) (*BoardState, error) {
 	return state, nil
 }
 
-// scanList iterates over a directory (list) of markdown files (cards)
+// scanList iterates over a directory (list) of markdown files (cards) on the real OS filesystem.
 func scanList(listPath, listName string) ([]KanbanCard, int, int64) {
-	files, err := os.ReadDir(listPath)
+	return scanListFs(context.Background(), afero.NewOsFs(), listPath, listName)
+}
+
+// scanListFs iterates over a directory (list) of markdown files (cards) on the given filesystem,
+// checking ctx between files so a cancelled or timed-out scan stops picking up new cards instead
+// of running to completion.
+func scanListFs(ctx context.Context, fs afero.Fs, listPath, listName string) ([]KanbanCard, int, int64) {
+	files, err := afero.ReadDir(fs, listPath)
 	if err != nil {
 		slog.Error("failed to read list directory", "list", listName, "path", listPath, "error", err)
 		return nil, 0, 0
@@ -89,13 +125,17 @@ func scanList(listPath, listName string) ([]KanbanCard, int, int64) {
 	var localBytes int64
 
 	for _, file := range files {
+		if ctx.Err() != nil {
+			slog.Warn("scan cancelled mid-list", "list", listName, "error", ctx.Err())
+			break
+		}
 
 		if !file.IsDir() && strings.HasSuffix(file.Name(), ".md") {
 			fileBase := strings.TrimSuffix(file.Name(), ".md")
 			idFromFileName, _ := strconv.Atoi(fileBase)
 			fullPath := filepath.Join(listPath, file.Name())
 
-			meta, preview, err := parseFileHeader(fullPath)
+			meta, preview, err := parseFileHeaderFs(fs, fullPath)
 			if err != nil {
 				slog.Warn("skipping invalid card file", "file", file.Name(), "list", listName, "error", err)
 				continue
@@ -110,10 +150,18 @@ func scanList(listPath, listName string) ([]KanbanCard, int, int64) {
 				localMaxID = meta.ID
 			}
 
-			if info, err := file.Info(); err == nil {
-				localBytes += info.Size()
-			} else {
-				slog.Warn("failed to stat card file", "file", file.Name(), "error", err)
+			localBytes += file.Size()
+
+			// Cards saved before ContentSHA256 existed have nothing to check against, so
+			// they're never flagged dirty and never cost the extra full read below.
+			dirty := false
+			if meta.ContentSHA256 != "" {
+				if fullBody, err := ReadCardContentFs(ctx, fs, fullPath); err == nil {
+					dirty = ContentHash(fullBody) != meta.ContentSHA256
+					if dirty {
+						slog.Warn("card content hash mismatch -- edited outside Daedalus", "file", file.Name(), "list", listName, "id", meta.ID)
+					}
+				}
 			}
 
 			cards = append(cards, KanbanCard{
@@ -121,6 +169,7 @@ func scanList(listPath, listName string) ([]KanbanCard, int, int64) {
 				ListName:    listName,
 				Metadata:    meta,
 				PreviewText: preview,
+				Dirty:       dirty,
 			})
 		}
 	}
@@ -167,9 +216,14 @@ func scanCardFile(s *bufio.Scanner, onFrontmatter, onBody func(line string) bool
 	}
 }
 
-// parseFileHeader reads frontmatter and first few lines of card body
+// parseFileHeader reads frontmatter and first few lines of card body from the real OS filesystem.
 func parseFileHeader(path string) (CardMetadata, string, error) {
-	file, err := os.Open(path)
+	return parseFileHeaderFs(afero.NewOsFs(), path)
+}
+
+// parseFileHeaderFs reads frontmatter and first few lines of card body from the given filesystem.
+func parseFileHeaderFs(fs afero.Fs, path string) (CardMetadata, string, error) {
+	file, err := fs.Open(path)
 	if err != nil {
 		return CardMetadata{}, "", err
 	}
@@ -211,10 +265,48 @@ func parseFileHeader(path string) (CardMetadata, string, error) {
 	return meta, bodyPreviewBuf.String(), nil
 }
 
-// readRawFrontmatter reads an existing file and parses the YAML between --- delimiters into a raw map.
-// Uses scanCardFile for robust line-by-line delimiter matching.
+// ParseCardBytes parses raw card file content (YAML frontmatter between --- delimiters,
+// followed by a markdown body) the same way ScanBoard does internally. It's exported for
+// callers outside this package, such as pkg/daedalus/webdav, that receive a full card file's
+// bytes from somewhere other than a local path and need to interpret them.
+func ParseCardBytes(data []byte) (CardMetadata, string, error) {
+	var frontmatterBuf bytes.Buffer
+	var bodyBuf bytes.Buffer
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	scanCardFile(s,
+		func(line string) bool {
+			frontmatterBuf.WriteString(line + "\n")
+			return true
+		},
+		func(line string) bool {
+			bodyBuf.WriteString(line + "\n")
+			return true
+		},
+	)
+	if err := s.Err(); err != nil {
+		return CardMetadata{}, "", fmt.Errorf("reading card content: %w", err)
+	}
+
+	var meta CardMetadata
+	if frontmatterBuf.Len() > 0 {
+		if err := yaml.Unmarshal(frontmatterBuf.Bytes(), &meta); err != nil {
+			return CardMetadata{}, "", fmt.Errorf("yaml parse error: %w", err)
+		}
+	}
+	return meta, bodyBuf.String(), nil
+}
+
+// readRawFrontmatter reads an existing file from the real OS filesystem and parses the YAML
+// between --- delimiters into a raw map.
 func readRawFrontmatter(path string) (map[string]any, error) {
-	file, err := os.Open(path)
+	return readRawFrontmatterFs(afero.NewOsFs(), path)
+}
+
+// readRawFrontmatterFs reads an existing file from the given filesystem and parses the YAML
+// between --- delimiters into a raw map. Uses scanCardFile for robust line-by-line delimiter matching.
+func readRawFrontmatterFs(fs afero.Fs, path string) (map[string]any, error) {
+	file, err := fs.Open(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -289,7 +381,7 @@ func mergeUnknownFields(metaRaw, existingRaw map[string]any) map[string]any {
 func marshalOrderedYAML(merged map[string]any) ([]byte, error) {
 	priorityKeys := []string{
 		"id", "title", "list_order", "created", "updated",
-		"due", "range", "labels", "icon", "url", "estimate",
+		"due", "range", "labels", "icon", "url", "estimate", "review",
 	}
 	added := make(map[string]bool)
 	var yamlBuf bytes.Buffer
@@ -327,9 +419,34 @@ func marshalOrderedYAML(merged map[string]any) ([]byte, error) {
 	return yamlBuf.Bytes(), nil
 }
 
-// WriteCardFile writes a card's metadata and body to a markdown file, preserving unknown YAML fields.
-func WriteCardFile(path string, meta CardMetadata, body string) error {
-	existingRaw, err := readRawFrontmatter(path)
+// WriteCardFile writes a card's metadata and body to a markdown file on the real OS filesystem,
+// preserving unknown YAML fields.
+func WriteCardFile(ctx context.Context, path string, meta CardMetadata, body string) error {
+	return WriteCardFileFs(ctx, afero.NewOsFs(), path, meta, body)
+}
+
+// WriteCardFileFs writes a card's metadata and body to a markdown file on the given filesystem,
+// preserving unknown YAML fields. If the board's config has encrypt: <key-id> set, body is
+// encrypted in place as an armored OpenPGP message before it's written. If config has
+// sign: true, a detached signature sidecar (path+".sig") is written alongside it, covering
+// the full file content -- see maybeSignCardFs.
+func WriteCardFileFs(ctx context.Context, fs afero.Fs, path string, meta CardMetadata, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	rootPath := filepath.Dir(filepath.Dir(path))
+	config, err := LoadBoardConfigFs(fs, rootPath)
+	if err != nil {
+		return fmt.Errorf("loading board config: %w", err)
+	}
+	if config.Encrypt != "" {
+		body, err = encryptCardBody(fs, rootPath, config.Encrypt, body)
+		if err != nil {
+			return fmt.Errorf("encrypting card body: %w", err)
+		}
+	}
+
+	existingRaw, err := readRawFrontmatterFs(fs, path)
 	if err != nil {
 		return fmt.Errorf("reading existing frontmatter: %w", err)
 	}
@@ -367,16 +484,30 @@ func WriteCardFile(path string, meta CardMetadata, body string) error {
 	buf.WriteString("---\n")
 	buf.WriteString(body)
 
-	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+	if err := writeFileAtomicFs(fs, path, buf.Bytes(), 0644); err != nil {
 		slog.Error("failed to write card file", "path", path, "error", err)
 		return err
 	}
+	if err := maybeSignCardFs(fs, rootPath, path, buf.Bytes()); err != nil {
+		slog.Warn("failed to sign card", "path", path, "error", err)
+	}
 	return nil
 }
 
-// ReadCardContent reads a card file and returns the full markdown body (after frontmatter)
-func ReadCardContent(path string) (string, error) {
-	file, err := os.Open(path)
+// ReadCardContent reads a card file from the real OS filesystem and returns the full
+// markdown body (after frontmatter).
+func ReadCardContent(ctx context.Context, path string) (string, error) {
+	return ReadCardContentFs(ctx, afero.NewOsFs(), path)
+}
+
+// ReadCardContentFs reads a card file from the given filesystem and returns the full
+// markdown body (after frontmatter), transparently decrypting it first if the board's config
+// has encrypt: <key-id> set and the board's keyring holds that key's private half.
+func ReadCardContentFs(ctx context.Context, fs afero.Fs, path string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	file, err := fs.Open(path)
 	if err != nil {
 		slog.Error("failed to open card file", "path", path, "error", err)
 		return "", err
@@ -395,5 +526,14 @@ func ReadCardContent(path string) (string, error) {
 		slog.Error("error reading card file", "path", path, "error", err)
 		return "", err
 	}
-	return bodyBuf.String(), nil
+
+	rootPath := filepath.Dir(filepath.Dir(path))
+	config, err := LoadBoardConfigFs(fs, rootPath)
+	if err != nil {
+		return "", fmt.Errorf("loading board config: %w", err)
+	}
+	if config.Encrypt == "" {
+		return bodyBuf.String(), nil
+	}
+	return decryptCardBody(fs, rootPath, bodyBuf.String())
 }
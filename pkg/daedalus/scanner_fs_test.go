@@ -0,0 +1,169 @@
+package daedalus
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+// A board created purely in memory should scan and round-trip cards without touching disk.
+func TestNewBoardInMemory_WriteAndScan(t *testing.T) {
+	state := NewBoardInMemory()
+	if err := state.Fs.MkdirAll("/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	meta := CardMetadata{ID: 1, Title: "First"}
+	if err := WriteCardFileFs(context.Background(), state.Fs, "/todo/1.md", meta, "# First\n"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	scanned, err := ScanBoardFs(context.Background(), state.Fs, "/")
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	cards := scanned.Lists["todo"]
+	if len(cards) != 1 || cards[0].Metadata.Title != "First" {
+		t.Fatalf("expected one card titled First, got %+v", cards)
+	}
+}
+
+// ScanBoard (the OS-backed wrapper) should behave the same as ScanBoardFs with an OsFs.
+func TestScanBoard_MatchesScanBoardFs(t *testing.T) {
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+	if err := fs.MkdirAll(dir+"/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), dir+"/todo/1.md", CardMetadata{ID: 1, Title: "Disk Card"}, "body\n"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	viaWrapper, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("ScanBoard: %v", err)
+	}
+	viaFs, err := ScanBoardFs(context.Background(), fs, dir)
+	if err != nil {
+		t.Fatalf("ScanBoardFs: %v", err)
+	}
+	if len(viaWrapper.Lists["todo"]) != len(viaFs.Lists["todo"]) {
+		t.Fatalf("expected matching card counts, got %d vs %d",
+			len(viaWrapper.Lists["todo"]), len(viaFs.Lists["todo"]))
+	}
+}
+
+// A card written with ContentSHA256 set should scan back with Dirty false as long as its body
+// is untouched.
+func TestScanBoardFs_ContentHashRoundTrip(t *testing.T) {
+	state := NewBoardInMemory()
+	if err := state.Fs.MkdirAll("/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	body := "# First\n\nBody.\n"
+	meta := CardMetadata{ID: 1, Title: "First", ContentSHA256: ContentHash(body)}
+	if err := WriteCardFileFs(context.Background(), state.Fs, "/todo/1.md", meta, body); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	scanned, err := ScanBoardFs(context.Background(), state.Fs, "/")
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	cards := scanned.Lists["todo"]
+	if len(cards) != 1 {
+		t.Fatalf("expected 1 card, got %d", len(cards))
+	}
+	if cards[0].Dirty {
+		t.Error("expected untouched card to not be flagged dirty")
+	}
+	if cards[0].Metadata.ContentSHA256 != meta.ContentSHA256 {
+		t.Errorf("ContentSHA256: got %q, want %q", cards[0].Metadata.ContentSHA256, meta.ContentSHA256)
+	}
+}
+
+// A card whose body was edited outside Daedalus (content no longer matches its stored hash)
+// should scan back with Dirty true.
+func TestScanBoardFs_DetectsTamperedContent(t *testing.T) {
+	state := NewBoardInMemory()
+	if err := state.Fs.MkdirAll("/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	original := "# First\n\nOriginal body.\n"
+	meta := CardMetadata{ID: 1, Title: "First", ContentSHA256: ContentHash(original)}
+	if err := WriteCardFileFs(context.Background(), state.Fs, "/todo/1.md", meta, original); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// Simulate an out-of-band edit: rewrite the body directly, bypassing WriteCardFileFs so
+	// ContentSHA256 in the frontmatter is left stale.
+	tampered := "---\nid: 1\ntitle: First\ncontent_sha256: " + meta.ContentSHA256 + "\n---\nTampered body.\n"
+	if err := afero.WriteFile(state.Fs, "/todo/1.md", []byte(tampered), 0644); err != nil {
+		t.Fatalf("tampering: %v", err)
+	}
+
+	scanned, err := ScanBoardFs(context.Background(), state.Fs, "/")
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	cards := scanned.Lists["todo"]
+	if len(cards) != 1 || !cards[0].Dirty {
+		t.Fatalf("expected tampered card to be flagged dirty, got %+v", cards)
+	}
+}
+
+// A context cancelled before the scan starts should stop scanListFs from picking up any
+// cards, instead of running the walk to completion.
+func TestScanBoardFs_CancelledContextStopsMidScan(t *testing.T) {
+	state := NewBoardInMemory()
+	if err := state.Fs.MkdirAll("/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		meta := CardMetadata{ID: i, Title: "Card"}
+		path := "/todo/" + strconv.Itoa(i) + ".md"
+		if err := WriteCardFileFs(context.Background(), state.Fs, path, meta, "# Card\n"); err != nil {
+			t.Fatalf("write card %d: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	scanned, err := ScanBoardFs(ctx, state.Fs, "/")
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(scanned.Lists["todo"]) != 0 {
+		t.Fatalf("expected a cancelled scan to pick up no cards, got %d", len(scanned.Lists["todo"]))
+	}
+}
+
+// WriteCardFileFs and SaveBoardConfigFs should refuse to write anything once their context is
+// already done, so a cancelled label-rename or list-save leaves no partial state on disk.
+func TestWriteCardFileFs_CancelledContextWritesNothing(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := fs.MkdirAll("/board/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	path := "/board/todo/1.md"
+	if err := WriteCardFileFs(ctx, fs, path, CardMetadata{ID: 1, Title: "Card"}, "body\n"); err == nil {
+		t.Fatalf("expected a cancelled write to fail")
+	}
+	if exists, _ := afero.Exists(fs, path); exists {
+		t.Fatalf("expected no card file to be written for a cancelled context")
+	}
+
+	if err := SaveBoardConfigFs(ctx, fs, "/board", &BoardConfig{Title: "New"}); err == nil {
+		t.Fatalf("expected a cancelled config save to fail")
+	}
+	if exists, _ := afero.Exists(fs, "/board/board.yaml"); exists {
+		t.Fatalf("expected no board.yaml to be written for a cancelled context")
+	}
+}
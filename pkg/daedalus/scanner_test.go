@@ -1,6 +1,7 @@
 package daedalus
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -30,7 +31,7 @@ id: 1
 Some body content here.
 Second line.
 `)
-	body, err := ReadCardContent(path)
+	body, err := ReadCardContent(context.Background(), path)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -48,7 +49,7 @@ title: "Empty Body"
 id: 2
 ---
 `)
-	body, err := ReadCardContent(path)
+	body, err := ReadCardContent(context.Background(), path)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -65,7 +66,7 @@ func TestReadCardContent_NoFrontmatter(t *testing.T) {
 
 No frontmatter here.
 `)
-	body, err := ReadCardContent(path)
+	body, err := ReadCardContent(context.Background(), path)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -80,7 +81,7 @@ func TestReadCardContent_MultilineBody(t *testing.T) {
 	content := "---\ntitle: \"Multi\"\nid: 4\n---\nLine 1\nLine 2\nLine 3\n"
 	path := writeTestCard(t, dir, "4.md", content)
 
-	body, err := ReadCardContent(path)
+	body, err := ReadCardContent(context.Background(), path)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -92,7 +93,7 @@ func TestReadCardContent_MultilineBody(t *testing.T) {
 
 // Reading a nonexistent file should return an error.
 func TestReadCardContent_FileNotFound(t *testing.T) {
-	_, err := ReadCardContent("/nonexistent/path/card.md")
+	_, err := ReadCardContent(context.Background(), "/nonexistent/path/card.md")
 	if err == nil {
 		t.Fatal("expected error for nonexistent file")
 	}
@@ -227,7 +228,7 @@ func TestScanBoard_ListDiscovery(t *testing.T) {
 	writeTestCard(t, list1, "2.md", "---\ntitle: \"Card B\"\nid: 2\nlist_order: 2\n---\nBody B\n")
 	writeTestCard(t, list2, "3.md", "---\ntitle: \"Card C\"\nid: 3\nlist_order: 1\n---\nBody C\n")
 
-	state, err := ScanBoard(root)
+	state, err := ScanBoard(context.Background(), root)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -265,7 +266,7 @@ func TestScanBoard_CardSortOrder(t *testing.T) {
 	writeTestCard(t, list, "2.md", "---\ntitle: \"First\"\nid: 2\nlist_order: 10\n---\n")
 	writeTestCard(t, list, "3.md", "---\ntitle: \"Second\"\nid: 3\nlist_order: 20\n---\n")
 
-	state, err := ScanBoard(root)
+	state, err := ScanBoard(context.Background(), root)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -295,7 +296,7 @@ func TestScanBoard_HiddenDirsIgnored(t *testing.T) {
 	writeTestCard(t, filepath.Join(root, ".hidden"), "1.md", "---\ntitle: \"Hidden\"\nid: 1\n---\n")
 	writeTestCard(t, filepath.Join(root, "visible"), "2.md", "---\ntitle: \"Visible\"\nid: 2\n---\n")
 
-	state, err := ScanBoard(root)
+	state, err := ScanBoard(context.Background(), root)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -316,7 +317,7 @@ func TestScanBoard_IDFromFilename(t *testing.T) {
 
 	writeTestCard(t, list, "42.md", "---\ntitle: \"No ID\"\nlist_order: 1\n---\n")
 
-	state, err := ScanBoard(root)
+	state, err := ScanBoard(context.Background(), root)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -340,7 +341,7 @@ func TestScanBoard_NonMdFilesIgnored(t *testing.T) {
 	writeTestCard(t, list, "notes.txt", "not a card")
 	writeTestCard(t, list, "data.json", "{}")
 
-	state, err := ScanBoard(root)
+	state, err := ScanBoard(context.Background(), root)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -355,7 +356,7 @@ func TestScanBoard_NonMdFilesIgnored(t *testing.T) {
 func TestScanBoard_EmptyBoard(t *testing.T) {
 	root := t.TempDir()
 
-	state, err := ScanBoard(root)
+	state, err := ScanBoard(context.Background(), root)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -374,7 +375,7 @@ func TestScanBoard_FilePaths(t *testing.T) {
 	os.Mkdir(list, 0755)
 	writeTestCard(t, list, "7.md", "---\ntitle: \"Path Test\"\nid: 7\n---\nBody\n")
 
-	state, err := ScanBoard(root)
+	state, err := ScanBoard(context.Background(), root)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -407,7 +408,7 @@ func TestWriteCardFile_RoundTrip(t *testing.T) {
 	}
 	body := "# Round Trip\n\nSome description.\n"
 
-	if err := WriteCardFile(path, meta, body); err != nil {
+	if err := WriteCardFile(context.Background(), path, meta, body); err != nil {
 		t.Fatalf("WriteCardFile error: %v", err)
 	}
 
@@ -436,7 +437,7 @@ func TestWriteCardFile_RoundTrip(t *testing.T) {
 	}
 
 	// Read back body
-	readBody, err := ReadCardContent(path)
+	readBody, err := ReadCardContent(context.Background(), path)
 	if err != nil {
 		t.Fatalf("ReadCardContent error: %v", err)
 	}
@@ -462,7 +463,7 @@ func TestWriteCardFile_PreservesUnknownFields(t *testing.T) {
 		Title:     "Updated Trello Card",
 		ListOrder: 1,
 	}
-	if err := WriteCardFile(path, meta, "# Updated Trello Card\n\nNew body.\n"); err != nil {
+	if err := WriteCardFile(context.Background(), path, meta, "# Updated Trello Card\n\nNew body.\n"); err != nil {
 		t.Fatalf("WriteCardFile error: %v", err)
 	}
 
@@ -509,7 +510,7 @@ func TestWriteCardFile_TimeSeries(t *testing.T) {
 	}
 	body := "# TS Card\n\nTracking weight.\n"
 
-	if err := WriteCardFile(path, meta, body); err != nil {
+	if err := WriteCardFile(context.Background(), path, meta, body); err != nil {
 		t.Fatalf("WriteCardFile error: %v", err)
 	}
 
@@ -561,7 +562,7 @@ func TestWriteCardFile_TimeSeriesEmpty(t *testing.T) {
 		TimeSeries: &TimeSeries{Label: "", Entries: []TimeSeriesEntry{}},
 	}
 
-	if err := WriteCardFile(path, meta, "# Empty TS\n"); err != nil {
+	if err := WriteCardFile(context.Background(), path, meta, "# Empty TS\n"); err != nil {
 		t.Fatalf("WriteCardFile error: %v", err)
 	}
 
@@ -642,7 +643,7 @@ func TestWriteCardFile_ClearsOmitemptyFields(t *testing.T) {
 		ListOrder: 1,
 		Due:       &due,
 	}
-	if err := WriteCardFile(path, meta, "# Due Card\n"); err != nil {
+	if err := WriteCardFile(context.Background(), path, meta, "# Due Card\n"); err != nil {
 		t.Fatalf("first WriteCardFile error: %v", err)
 	}
 
@@ -654,7 +655,7 @@ func TestWriteCardFile_ClearsOmitemptyFields(t *testing.T) {
 
 	// Second write with nil due date
 	meta.Due = nil
-	if err := WriteCardFile(path, meta, "# Due Card\n"); err != nil {
+	if err := WriteCardFile(context.Background(), path, meta, "# Due Card\n"); err != nil {
 		t.Fatalf("second WriteCardFile error: %v", err)
 	}
 
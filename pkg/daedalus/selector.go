@@ -0,0 +1,210 @@
+package daedalus
+
+import (
+	"fmt"
+	"strings"
+)
+
+// selectorOp is the comparison a single label requirement applies.
+type selectorOp int
+
+const (
+	selectorEquals selectorOp = iota
+	selectorNotEquals
+	selectorIn
+	selectorNotIn
+)
+
+// LabelSelectorError reports a malformed label selector expression, identifying the offending
+// clause so callers (CLI flag parsing, HTTP query params) can surface a precise error back to
+// the user rather than a generic parse failure.
+type LabelSelectorError struct {
+	Expr   string
+	Reason string
+}
+
+func (e *LabelSelectorError) Error() string {
+	return fmt.Sprintf("invalid label selector %q: %s", e.Expr, e.Reason)
+}
+
+// labelRequirement is a single comma-separated clause of a LabelSelector, e.g. "priority!=low"
+// or "group in (foo, bar)". values holds the literal "key=value" label strings the requirement
+// compares against -- Labels is a flat []string, not a key/value map, so "group=foo" is only
+// satisfied by a card carrying the literal label "group=foo".
+type labelRequirement struct {
+	op     selectorOp
+	values map[string]bool
+}
+
+// LabelSelector is a parsed Kubernetes-style label selector: a comma-separated, implicitly
+// AND'd list of requirements evaluated against a card's Metadata.Labels. Supported syntax:
+//
+//	bug              a bare label exists (useful since Labels is a flat tag list, not a map)
+//	key=value        equality
+//	key!=value       inequality
+//	key in (a, b)    set membership
+//	key notin (a, b) negated set membership
+//
+// Labels is a flat []string rather than a key/value map, so every requirement above is matched
+// against a literal label string -- "priority=low" matches a card carrying the literal label
+// "priority=low", not a card with some separately-keyed "priority" field set to "low".
+type LabelSelector struct {
+	requirements []labelRequirement
+}
+
+// ParseLabelSelector parses expr into a LabelSelector. An empty or all-whitespace expr yields a
+// selector that matches every card. Malformed clauses return a *LabelSelectorError.
+func ParseLabelSelector(expr string) (*LabelSelector, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return &LabelSelector{}, nil
+	}
+
+	clauses, err := splitSelectorClauses(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	sel := &LabelSelector{}
+	for _, clause := range clauses {
+		req, err := parseSelectorClause(clause)
+		if err != nil {
+			return nil, err
+		}
+		sel.requirements = append(sel.requirements, req)
+	}
+	return sel, nil
+}
+
+// splitSelectorClauses splits expr on top-level commas, treating commas inside a "(...)" set as
+// part of the enclosing "in"/"notin" clause rather than a new requirement.
+func splitSelectorClauses(expr string) ([]string, error) {
+	var clauses []string
+	depth := 0
+	start := 0
+	for i, r := range expr {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, &LabelSelectorError{Expr: expr, Reason: "unmatched ')'"}
+			}
+		case ',':
+			if depth == 0 {
+				clauses = append(clauses, strings.TrimSpace(expr[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, &LabelSelectorError{Expr: expr, Reason: "unmatched '('"}
+	}
+	clauses = append(clauses, strings.TrimSpace(expr[start:]))
+
+	for _, c := range clauses {
+		if c == "" {
+			return nil, &LabelSelectorError{Expr: expr, Reason: "empty clause"}
+		}
+	}
+	return clauses, nil
+}
+
+// parseSelectorClause parses a single requirement out of one comma-separated clause.
+func parseSelectorClause(clause string) (labelRequirement, error) {
+	if idx := strings.Index(clause, "!="); idx >= 0 {
+		key := strings.TrimSpace(clause[:idx])
+		value := strings.TrimSpace(clause[idx+2:])
+		if key == "" || value == "" {
+			return labelRequirement{}, &LabelSelectorError{Expr: clause, Reason: "expected key!=value"}
+		}
+		return labelRequirement{op: selectorNotEquals, values: map[string]bool{key + "=" + value: true}}, nil
+	}
+	if idx := strings.Index(clause, "="); idx >= 0 {
+		key := strings.TrimSpace(clause[:idx])
+		value := strings.TrimSpace(clause[idx+1:])
+		if key == "" || value == "" {
+			return labelRequirement{}, &LabelSelectorError{Expr: clause, Reason: "expected key=value"}
+		}
+		return labelRequirement{op: selectorEquals, values: map[string]bool{key + "=" + value: true}}, nil
+	}
+
+	fields := strings.Fields(clause)
+	if len(fields) >= 2 && (fields[1] == "in" || fields[1] == "notin") {
+		key := fields[0]
+		op := selectorIn
+		if fields[1] == "notin" {
+			op = selectorNotIn
+		}
+		setExpr := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(clause), key)), fields[1]))
+		values, err := parseSelectorSet(clause, key, setExpr)
+		if err != nil {
+			return labelRequirement{}, err
+		}
+		return labelRequirement{op: op, values: values}, nil
+	}
+
+	if len(fields) == 1 {
+		return labelRequirement{op: selectorEquals, values: map[string]bool{fields[0]: true}}, nil
+	}
+
+	return labelRequirement{}, &LabelSelectorError{Expr: clause, Reason: "expected a bare label, key=value, key!=value, key in (...), or key notin (...)"}
+}
+
+// parseSelectorSet parses the "(a, b, c)" portion of an in/notin clause into the set of literal
+// "key=value" label strings it matches.
+func parseSelectorSet(clause, key, setExpr string) (map[string]bool, error) {
+	if !strings.HasPrefix(setExpr, "(") || !strings.HasSuffix(setExpr, ")") {
+		return nil, &LabelSelectorError{Expr: clause, Reason: "expected a parenthesized value set, e.g. (a, b)"}
+	}
+	inner := strings.TrimSuffix(strings.TrimPrefix(setExpr, "("), ")")
+	values := make(map[string]bool)
+	for _, v := range strings.Split(inner, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, &LabelSelectorError{Expr: clause, Reason: "empty value in set"}
+		}
+		values[key+"="+v] = true
+	}
+	if len(values) == 0 {
+		return nil, &LabelSelectorError{Expr: clause, Reason: "value set must not be empty"}
+	}
+	return values, nil
+}
+
+// Matches reports whether labels satisfies every requirement in the selector. A selector parsed
+// from an empty expression matches any labels, including none.
+func (s *LabelSelector) Matches(labels []string) bool {
+	if s == nil || len(s.requirements) == 0 {
+		return true
+	}
+	for _, req := range s.requirements {
+		if !req.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches evaluates a single requirement against labels.
+func (r *labelRequirement) matches(labels []string) bool {
+	switch r.op {
+	case selectorEquals, selectorIn:
+		for _, l := range labels {
+			if r.values[l] {
+				return true
+			}
+		}
+		return false
+	case selectorNotEquals, selectorNotIn:
+		for _, l := range labels {
+			if r.values[l] {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
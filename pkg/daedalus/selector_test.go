@@ -0,0 +1,95 @@
+package daedalus
+
+import "testing"
+
+// An empty selector expression should match any set of labels, including no labels at all.
+func TestParseLabelSelector_Empty(t *testing.T) {
+	sel, err := ParseLabelSelector("")
+	if err != nil {
+		t.Fatalf("ParseLabelSelector: %v", err)
+	}
+	if !sel.Matches(nil) || !sel.Matches([]string{"bug"}) {
+		t.Error("expected empty selector to match any labels")
+	}
+}
+
+// Equality, inequality, and their combination via comma-separated AND.
+func TestParseLabelSelector_EqualityAndInequality(t *testing.T) {
+	sel, err := ParseLabelSelector("group=foo,priority!=low")
+	if err != nil {
+		t.Fatalf("ParseLabelSelector: %v", err)
+	}
+
+	cases := []struct {
+		labels []string
+		want   bool
+	}{
+		{[]string{"group=foo", "priority=high"}, true},
+		{[]string{"group=foo", "priority=low"}, false},
+		{[]string{"group=bar", "priority=high"}, false},
+		{[]string{"group=foo"}, true},
+	}
+	for _, c := range cases {
+		if got := sel.Matches(c.labels); got != c.want {
+			t.Errorf("Matches(%v) = %v, want %v", c.labels, got, c.want)
+		}
+	}
+}
+
+// A bare label (no operator) matches a card carrying that literal label.
+func TestParseLabelSelector_BareLabel(t *testing.T) {
+	sel, err := ParseLabelSelector("bug")
+	if err != nil {
+		t.Fatalf("ParseLabelSelector: %v", err)
+	}
+	if !sel.Matches([]string{"bug", "urgent"}) {
+		t.Error("expected match on a card carrying the 'bug' label")
+	}
+	if sel.Matches([]string{"urgent"}) {
+		t.Error("expected no match on a card without the 'bug' label")
+	}
+}
+
+// in/notin set-membership operators.
+func TestParseLabelSelector_SetMembership(t *testing.T) {
+	sel, err := ParseLabelSelector("group in (foo, bar), priority notin (low, medium)")
+	if err != nil {
+		t.Fatalf("ParseLabelSelector: %v", err)
+	}
+
+	cases := []struct {
+		labels []string
+		want   bool
+	}{
+		{[]string{"group=foo", "priority=high"}, true},
+		{[]string{"group=bar", "priority=high"}, true},
+		{[]string{"group=baz", "priority=high"}, false},
+		{[]string{"group=foo", "priority=low"}, false},
+	}
+	for _, c := range cases {
+		if got := sel.Matches(c.labels); got != c.want {
+			t.Errorf("Matches(%v) = %v, want %v", c.labels, got, c.want)
+		}
+	}
+}
+
+// Malformed expressions return a *LabelSelectorError rather than a generic error.
+func TestParseLabelSelector_MalformedExpressions(t *testing.T) {
+	for _, expr := range []string{
+		"key=",
+		"=value",
+		"key in foo)",
+		"key in (foo",
+		"key in ()",
+		"a=b,,c=d",
+	} {
+		_, err := ParseLabelSelector(expr)
+		if err == nil {
+			t.Errorf("expected error for expression %q", expr)
+			continue
+		}
+		if _, ok := err.(*LabelSelectorError); !ok {
+			t.Errorf("expected *LabelSelectorError for %q, got %T", expr, err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package daedalus
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/crypto/openpgp"
+
+	"daedalus/pkg/daedalus/crypto"
+)
+
+// keyringDir returns the <root>/_assets/keyring directory a board's signing/encryption keys
+// live in. It is never created implicitly -- see checkDirFs.
+func keyringDir(rootPath string) string {
+	return filepath.Join(rootPath, "_assets", "keyring")
+}
+
+// checkDirFs reports whether dir exists, without creating it -- the counterpart to the usual
+// os.MkdirAll/ensureDir pattern used elsewhere in this package, needed here so `daedalus key
+// list` on a board with no keyring returns an empty slice instead of silently creating one.
+func checkDirFs(fs afero.Fs, dir string) bool {
+	info, err := fs.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// LoadKeyringFs loads every armored key under a board's keyring directory. A missing keyring
+// directory is not an error -- it yields an empty keyring, since signing/verification/
+// encryption are all opt-in and most boards will never have one.
+func LoadKeyringFs(fs afero.Fs, rootPath string) (openpgp.EntityList, error) {
+	dir := keyringDir(rootPath)
+	if !checkDirFs(fs, dir) {
+		return openpgp.EntityList{}, nil
+	}
+	entries, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading keyring dir: %w", err)
+	}
+
+	var keyring openpgp.EntityList
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".asc") {
+			continue
+		}
+		data, err := afero.ReadFile(fs, filepath.Join(dir, entry.Name()))
+		if err != nil {
+			slog.Warn("failed to read keyring entry", "name", entry.Name(), "error", err)
+			continue
+		}
+		entity, err := crypto.ParseArmoredKey(data)
+		if err != nil {
+			slog.Warn("failed to parse keyring entry", "name", entry.Name(), "error", err)
+			continue
+		}
+		keyring = append(keyring, entity)
+	}
+	return keyring, nil
+}
+
+// AddKeyFs parses an armored key and writes it to the board's keyring directory as
+// <keyID>.asc, creating the directory only now that there is actually a key to store.
+func AddKeyFs(fs afero.Fs, rootPath string, armoredKey []byte) (crypto.KeyInfo, error) {
+	entity, err := crypto.ParseArmoredKey(armoredKey)
+	if err != nil {
+		return crypto.KeyInfo{}, err
+	}
+	info := crypto.Info(entity)
+
+	dir := keyringDir(rootPath)
+	if err := fs.MkdirAll(dir, 0755); err != nil {
+		return crypto.KeyInfo{}, fmt.Errorf("creating keyring dir: %w", err)
+	}
+	if err := afero.WriteFile(fs, filepath.Join(dir, info.ID+".asc"), armoredKey, 0644); err != nil {
+		return crypto.KeyInfo{}, fmt.Errorf("writing key: %w", err)
+	}
+	return info, nil
+}
+
+// ListKeysFs returns every key in the board's keyring, or an empty (not nil-error) slice if
+// the keyring directory doesn't exist.
+func ListKeysFs(fs afero.Fs, rootPath string) ([]crypto.KeyInfo, error) {
+	keyring, err := LoadKeyringFs(fs, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	keys := []crypto.KeyInfo{}
+	for _, entity := range keyring {
+		keys = append(keys, crypto.Info(entity))
+	}
+	return keys, nil
+}
+
+// RemoveKeyFs removes <keyID>.asc from the board's keyring directory.
+func RemoveKeyFs(fs afero.Fs, rootPath, keyID string) error {
+	dir := keyringDir(rootPath)
+	if !checkDirFs(fs, dir) {
+		return fmt.Errorf("board has no keyring")
+	}
+	path := filepath.Join(dir, keyID+".asc")
+	if exists, _ := afero.Exists(fs, path); !exists {
+		return fmt.Errorf("key %q not found", keyID)
+	}
+	return fs.Remove(path)
+}
+
+// maybeSignCardFs writes a detached-signature sidecar (path+".sig") for data if rootPath's
+// board.yaml has sign: true and its keyring has a private key to sign with. It is a no-op,
+// not an error, when signing isn't configured or no private key is available yet, so a board
+// can add `sign: true` before it has generated a key without every write failing.
+func maybeSignCardFs(fs afero.Fs, rootPath, path string, data []byte) error {
+	config, err := LoadBoardConfigFs(fs, rootPath)
+	if err != nil {
+		return fmt.Errorf("loading board config: %w", err)
+	}
+	if config.Sign == nil || !*config.Sign {
+		return nil
+	}
+
+	keyring, err := LoadKeyringFs(fs, rootPath)
+	if err != nil {
+		return err
+	}
+	signer := firstPrivateKey(keyring)
+	if signer == nil {
+		slog.Warn("sign: true but the keyring has no private key to sign with", "root", rootPath)
+		return nil
+	}
+
+	sig, err := crypto.SignDetached(signer, data)
+	if err != nil {
+		return fmt.Errorf("signing card: %w", err)
+	}
+	return writeFileAtomicFs(fs, path+".sig", []byte(sig), 0644)
+}
+
+// VerifyCard reports the signature status of a card file on the real OS filesystem, reading
+// its current content itself -- see VerifyCardFs.
+func VerifyCard(rootPath, path string) (string, error) {
+	data, err := afero.ReadFile(afero.NewOsFs(), path)
+	if err != nil {
+		return "", fmt.Errorf("reading card file: %w", err)
+	}
+	return VerifyCardFs(afero.NewOsFs(), rootPath, path, data)
+}
+
+// VerifyCardFs reports the signature status of a card file's current content against its
+// .sig sidecar: "missing" if there's no sidecar, "valid" or "invalid" otherwise.
+func VerifyCardFs(fs afero.Fs, rootPath, path string, data []byte) (string, error) {
+	sigPath := path + ".sig"
+	if exists, _ := afero.Exists(fs, sigPath); !exists {
+		return "missing", nil
+	}
+	sig, err := afero.ReadFile(fs, sigPath)
+	if err != nil {
+		return "", fmt.Errorf("reading signature sidecar: %w", err)
+	}
+	keyring, err := LoadKeyringFs(fs, rootPath)
+	if err != nil {
+		return "", err
+	}
+	if err := crypto.VerifyDetached(keyring, data, string(sig)); err != nil {
+		return "invalid", nil
+	}
+	return "valid", nil
+}
+
+// encryptCardBody encrypts body to keyID's public key for storage in place of the plaintext
+// body. It is an error for keyID to not be in the board's keyring -- unlike signing, there is
+// no sensible way to write a card whose encrypt: key can't actually be found.
+func encryptCardBody(fs afero.Fs, rootPath, keyID, body string) (string, error) {
+	keyring, err := LoadKeyringFs(fs, rootPath)
+	if err != nil {
+		return "", err
+	}
+	recipient := findRecipient(keyring, keyID)
+	if recipient == nil {
+		return "", fmt.Errorf("encrypt key %q not found in keyring", keyID)
+	}
+	return crypto.Encrypt(openpgp.EntityList{recipient}, []byte(body))
+}
+
+// decryptCardBody decrypts an armored PGP message body using a private key from rootPath's
+// keyring. It is an error for the keyring to hold no private key, since there is then no way
+// to recover the plaintext at all.
+func decryptCardBody(fs afero.Fs, rootPath, body string) (string, error) {
+	keyring, err := LoadKeyringFs(fs, rootPath)
+	if err != nil {
+		return "", err
+	}
+	signer := firstPrivateKey(keyring)
+	if signer == nil {
+		return "", fmt.Errorf("board has no private key to decrypt with")
+	}
+	plaintext, err := crypto.Decrypt(openpgp.EntityList{signer}, body)
+	if err != nil {
+		return "", fmt.Errorf("decrypting card body: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// firstPrivateKey returns the first entity in keyring that holds a private key, i.e. one this
+// board can sign or decrypt with, or nil if the keyring has none.
+func firstPrivateKey(keyring openpgp.EntityList) *openpgp.Entity {
+	for _, entity := range keyring {
+		if entity.PrivateKey != nil {
+			return entity
+		}
+	}
+	return nil
+}
+
+// findRecipient returns the keyring entity whose short key ID matches keyID, or nil.
+func findRecipient(keyring openpgp.EntityList, keyID string) *openpgp.Entity {
+	for _, entity := range keyring {
+		if entity.PrimaryKey.KeyIdShortString() == keyID {
+			return entity
+		}
+	}
+	return nil
+}
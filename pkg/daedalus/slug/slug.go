@@ -0,0 +1,102 @@
+// Package slug sanitizes list and card names into filesystem-safe, cross-platform path
+// components: NFC-normalized so the same name produces the same bytes on macOS (which stores
+// filenames as NFD) and Linux (which doesn't normalize at all), optionally transliterated to
+// drop accents, whitespace collapsed to a single separator, a disallowed-character set
+// stripped, optionally lowercased, and rejected outright if empty or a reserved name. Modeled
+// on Hugo's MakePath.
+package slug
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Options controls how Make sanitizes a name. The zero value NFC-normalizes, collapses
+// whitespace runs to "-", strips DefaultDisallowed, and trims trailing dots -- it leaves case
+// and accents untouched, which is what a board with no slug: section in board.yaml gets.
+type Options struct {
+	RemoveAccents bool
+	Lower         bool
+}
+
+// DefaultDisallowed is the character set Make always strips, regardless of Options: characters
+// that are reserved, ambiguous, or outright illegal in a path component on at least one of
+// Windows/macOS/Linux.
+const DefaultDisallowed = `,:?*<>|"`
+
+// reservedNames are path components Make refuses to produce: "." and ".." because they'd
+// resolve to something other than a new entry, and Windows' reserved device names because a
+// board synced onto a Windows filesystem can't create them at all.
+var reservedNames = map[string]bool{
+	".": true, "..": true,
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// Make sanitizes name into a single filesystem-safe path component: NFC-normalized, accents
+// stripped if opts.RemoveAccents, whitespace runs collapsed to "-", DefaultDisallowed characters
+// dropped, lowercased if opts.Lower, and trailing dots trimmed (Windows silently drops these,
+// so two names differing only by a trailing dot would otherwise collide). Returns an error if
+// the result is empty or a reserved name.
+func Make(name string, opts Options) (string, error) {
+	name = norm.NFC.String(strings.TrimSpace(name))
+	if opts.RemoveAccents {
+		name = removeAccents(name)
+	}
+	name = collapseWhitespace(name)
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(DefaultDisallowed, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if opts.Lower {
+		name = strings.ToLower(name)
+	}
+	name = strings.TrimRight(name, ".")
+	if name == "" {
+		return "", fmt.Errorf("slug: name is empty after sanitization")
+	}
+	if reservedNames[strings.ToUpper(name)] {
+		return "", fmt.Errorf("slug: %q is a reserved name", name)
+	}
+	return name, nil
+}
+
+// collapseWhitespace replaces every run of whitespace with a single "-", so "My List" becomes
+// "My-List" rather than keeping spaces that some tools (and URLs) mishandle.
+func collapseWhitespace(s string) string {
+	var b strings.Builder
+	lastWasSpace := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if !lastWasSpace {
+				b.WriteRune('-')
+			}
+			lastWasSpace = true
+			continue
+		}
+		lastWasSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// removeAccents transliterates accented characters to their closest unaccented equivalent by
+// decomposing to NFD and dropping combining marks, e.g. "Résumé" -> "Resume" and the Cyrillic
+// "Банковский кассир" -> "Банковскии кассир" (й decomposes to и + a combining breve, which this
+// strips; letters with no precomposed accent are unaffected).
+func removeAccents(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package slug
+
+import "testing"
+
+func TestMake_DefaultOptionsLeavesCaseAndAccents(t *testing.T) {
+	got, err := Make("Résumé Ideas", Options{})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if got != "Résumé-Ideas" {
+		t.Errorf("got %q, want %q", got, "Résumé-Ideas")
+	}
+}
+
+func TestMake_RemoveAccentsTransliterates(t *testing.T) {
+	got, err := Make("Résumé", Options{RemoveAccents: true})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if got != "Resume" {
+		t.Errorf("got %q, want %q", got, "Resume")
+	}
+}
+
+func TestMake_RemoveAccentsOnCyrillic(t *testing.T) {
+	got, err := Make("Банковский кассир", Options{RemoveAccents: true})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if got != "Банковскии-кассир" {
+		t.Errorf("got %q, want %q", got, "Банковскии-кассир")
+	}
+}
+
+func TestMake_LowerLowercases(t *testing.T) {
+	got, err := Make("My List", Options{Lower: true})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if got != "my-list" {
+		t.Errorf("got %q, want %q", got, "my-list")
+	}
+}
+
+func TestMake_StripsDisallowedCharacters(t *testing.T) {
+	got, err := Make(`a,b:c?d*e<f>g|h"i`, Options{})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if got != "abcdefghi" {
+		t.Errorf("got %q, want %q", got, "abcdefghi")
+	}
+}
+
+func TestMake_RejectsEmptyAfterSanitization(t *testing.T) {
+	if _, err := Make(`,:?*`, Options{}); err == nil {
+		t.Fatal("expected an error for a name that sanitizes to empty")
+	}
+}
+
+func TestMake_RejectsReservedNames(t *testing.T) {
+	for _, name := range []string{".", "..", "CON", "con", "LPT1"} {
+		if _, err := Make(name, Options{}); err == nil {
+			t.Errorf("expected %q to be rejected as reserved", name)
+		}
+	}
+}
+
+func TestMake_TrimsTrailingDots(t *testing.T) {
+	got, err := Make("notes...", Options{})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	if got != "notes" {
+		t.Errorf("got %q, want %q", got, "notes")
+	}
+}
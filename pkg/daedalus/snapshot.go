@@ -0,0 +1,274 @@
+package daedalus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotTimeFormat is the UTC timestamp prefix used in snapshot IDs, chosen so lexical
+// sort order matches chronological order.
+const snapshotTimeFormat = "20060102T150405Z"
+
+// unsafeLabelChars matches anything that isn't safe to embed directly in a file name.
+var unsafeLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// SnapshotInfo describes a single point-in-time zip snapshot of a board.
+type SnapshotInfo struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	Label     string    `json:"label"`
+	SizeBytes int64     `json:"sizeBytes"`
+	SHA256    string    `json:"sha256"`
+}
+
+// snapshotsDir returns the <root>/_snapshots directory for a board.
+func snapshotsDir(rootPath string) string {
+	return filepath.Join(rootPath, "_snapshots")
+}
+
+// snapshotLockPath returns the lock file taken during CreateSnapshot/RestoreSnapshot so
+// concurrent CLI invocations don't corrupt each other.
+func snapshotLockPath(rootPath string) string {
+	return filepath.Join(snapshotsDir(rootPath), ".lock")
+}
+
+// acquireSnapshotLock creates _snapshots/.lock exclusively, refusing to proceed if another
+// create/restore is already in progress. The returned release func removes the lock.
+func acquireSnapshotLock(rootPath string) (release func(), err error) {
+	if err := os.MkdirAll(snapshotsDir(rootPath), 0755); err != nil {
+		return nil, fmt.Errorf("creating snapshots dir: %w", err)
+	}
+	lockPath := snapshotLockPath(rootPath)
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, fmt.Errorf("another snapshot operation is in progress (%s exists)", lockPath)
+		}
+		return nil, fmt.Errorf("creating snapshot lock: %w", err)
+	}
+	f.Close()
+	return func() { os.Remove(lockPath) }, nil
+}
+
+// CreateSnapshot writes a zip archive of state (reusing WriteExportZip's file walk) to
+// <root>/_snapshots/<UTC-timestamp>-<label>.zip and returns its metadata.
+func CreateSnapshot(rootPath string, state *BoardState, iconsDir string, label string) (SnapshotInfo, error) {
+	release, err := acquireSnapshotLock(rootPath)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	defer release()
+
+	now := time.Now().UTC()
+	cleanLabel := unsafeLabelChars.ReplaceAllString(strings.TrimSpace(label), "-")
+	base := now.Format(snapshotTimeFormat)
+	if cleanLabel != "" {
+		base += "-" + cleanLabel
+	}
+
+	// Two snapshots within the same second (or with the same label) would otherwise collide;
+	// disambiguate with a numeric suffix rather than silently overwriting the earlier one.
+	id := base
+	for n := 2; fileExists(filepath.Join(snapshotsDir(rootPath), id+".zip")); n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+
+	path := filepath.Join(snapshotsDir(rootPath), id+".zip")
+	if err := WriteExportZip(rootPath, state, iconsDir, path); err != nil {
+		if _, ok := err.(*ExportError); !ok {
+			return SnapshotInfo{}, fmt.Errorf("writing snapshot: %w", err)
+		}
+		// A partial export still produces a usable snapshot; surface the error to the caller
+		// below via SizeBytes/SHA256 computed on whatever was written, same as ExportZip callers.
+	}
+
+	info, statErr := snapshotInfoFromFile(path, id, cleanLabel, now)
+	if statErr != nil {
+		return SnapshotInfo{}, fmt.Errorf("stating snapshot: %w", statErr)
+	}
+	return info, err
+}
+
+// snapshotInfoFromFile stats and hashes a snapshot zip to build its SnapshotInfo.
+func snapshotInfoFromFile(path, id, label string, createdAt time.Time) (SnapshotInfo, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	return SnapshotInfo{
+		ID:        id,
+		CreatedAt: createdAt,
+		Label:     label,
+		SizeBytes: stat.Size(),
+		SHA256:    hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// fileExists reports whether path exists on the real OS filesystem.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// parseSnapshotID splits a snapshot's file-name-derived ID into its UTC timestamp and label.
+func parseSnapshotID(id string) (time.Time, string) {
+	parts := strings.SplitN(id, "-", 2)
+	createdAt, err := time.Parse(snapshotTimeFormat, parts[0])
+	if err != nil {
+		return time.Time{}, id
+	}
+	if len(parts) == 2 {
+		return createdAt, parts[1]
+	}
+	return createdAt, ""
+}
+
+// ListSnapshots scans <root>/_snapshots for zip archives and returns their metadata,
+// sorted newest-first.
+func ListSnapshots(rootPath string) ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(snapshotsDir(rootPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading snapshots dir: %w", err)
+	}
+
+	var snapshots []SnapshotInfo
+	// IDs carry whole seconds only, so snapshots taken within the same second are ordered by
+	// file modification time instead.
+	modTimes := make(map[string]time.Time, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".zip") {
+			continue
+		}
+		id := strings.TrimSuffix(entry.Name(), ".zip")
+		if fi, err := entry.Info(); err == nil {
+			modTimes[id] = fi.ModTime()
+		}
+		createdAt, label := parseSnapshotID(id)
+		info, err := snapshotInfoFromFile(filepath.Join(snapshotsDir(rootPath), entry.Name()), id, label, createdAt)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, info)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if !snapshots[i].CreatedAt.Equal(snapshots[j].CreatedAt) {
+			return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+		}
+		return modTimes[snapshots[i].ID].After(modTimes[snapshots[j].ID])
+	})
+	return snapshots, nil
+}
+
+// RestoreSnapshot extracts the snapshot named id into a temp directory, validates its
+// archive header via ImportZip's schema-version check, then atomically swaps it in for
+// rootPath. The board's previous contents are preserved as a sibling "<root>.pre-restore"
+// directory rather than deleted, so a bad restore can be undone by hand.
+func RestoreSnapshot(rootPath, id string) error {
+	release, err := acquireSnapshotLock(rootPath)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	snapshotPath := filepath.Join(snapshotsDir(rootPath), id+".zip")
+	if _, err := os.Stat(snapshotPath); err != nil {
+		return fmt.Errorf("snapshot %q not found: %w", id, err)
+	}
+
+	tempDir, err := os.MkdirTemp(filepath.Dir(rootPath), ".daedalus-restore-*")
+	if err != nil {
+		return fmt.Errorf("creating restore temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := ImportZip(snapshotPath, tempDir, false); err != nil {
+		return fmt.Errorf("extracting snapshot: %w", err)
+	}
+
+	// Snapshots aren't themselves part of the exported archive; carry the existing
+	// _snapshots directory (including this lock) across the swap so history isn't lost.
+	if err := os.Rename(snapshotsDir(rootPath), snapshotsDir(tempDir)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("carrying over snapshots dir: %w", err)
+	}
+
+	backupDir := rootPath + ".pre-restore"
+	os.RemoveAll(backupDir)
+	if err := os.Rename(rootPath, backupDir); err != nil {
+		return fmt.Errorf("backing up current board: %w", err)
+	}
+	if err := os.Rename(tempDir, rootPath); err != nil {
+		os.Rename(backupDir, rootPath)
+		return fmt.Errorf("swapping in restored board: %w", err)
+	}
+	return nil
+}
+
+// AutoSnapshot creates a pre-destructive snapshot labeled reason (e.g. "pre-delete-card") and
+// prunes down to board.yaml's backup.keep count, for CLI commands that are about to delete or
+// overwrite board content. It is a no-op for boards that haven't set backup.keep, so existing
+// boards see no behavior change until they opt in.
+func AutoSnapshot(rootPath string, reason string) error {
+	config, err := LoadBoardConfig(rootPath)
+	if err != nil {
+		return fmt.Errorf("loading board config: %w", err)
+	}
+	if config.Backup == nil || config.Backup.Keep <= 0 {
+		return nil
+	}
+
+	state, err := ScanBoard(context.Background(), rootPath)
+	if err != nil {
+		return fmt.Errorf("scanning board for auto-snapshot: %w", err)
+	}
+	if _, err := CreateSnapshot(rootPath, state, filepath.Join(rootPath, "_assets", "icons"), reason); err != nil {
+		if _, ok := err.(*ExportError); !ok {
+			return fmt.Errorf("creating auto-snapshot: %w", err)
+		}
+	}
+	return PruneSnapshots(rootPath, config.Backup.Keep)
+}
+
+// PruneSnapshots keeps only the keep most recent snapshots, deleting the rest.
+func PruneSnapshots(rootPath string, keep int) error {
+	snapshots, err := ListSnapshots(rootPath)
+	if err != nil {
+		return err
+	}
+	if keep < 0 {
+		keep = 0
+	}
+	if len(snapshots) <= keep {
+		return nil
+	}
+	for _, s := range snapshots[keep:] {
+		path := filepath.Join(snapshotsDir(rootPath), s.ID+".zip")
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("removing snapshot %s: %w", s.ID, err)
+		}
+	}
+	return nil
+}
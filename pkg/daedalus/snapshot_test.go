@@ -0,0 +1,155 @@
+package daedalus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newSnapshotTestBoard(t *testing.T, rootPath string) *BoardState {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(rootPath, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(rootPath, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card One"}, "body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := SaveBoardConfig(context.Background(), rootPath, &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	state, err := ScanBoard(context.Background(), rootPath)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	return state
+}
+
+// Creating a snapshot should produce a listable, hashed zip under _snapshots/.
+func TestCreateAndListSnapshots(t *testing.T) {
+	rootPath := t.TempDir()
+	state := newSnapshotTestBoard(t, rootPath)
+
+	info, err := CreateSnapshot(rootPath, state, filepath.Join(rootPath, "_assets", "icons"), "before release")
+	if err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+	if info.SizeBytes == 0 || info.SHA256 == "" {
+		t.Fatalf("expected populated snapshot metadata, got %+v", info)
+	}
+	if info.Label != "before-release" {
+		t.Fatalf("expected sanitized label %q, got %q", "before-release", info.Label)
+	}
+
+	snapshots, err := ListSnapshots(rootPath)
+	if err != nil {
+		t.Fatalf("list snapshots: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].ID != info.ID {
+		t.Fatalf("expected 1 listed snapshot matching %q, got %+v", info.ID, snapshots)
+	}
+}
+
+// Restoring a snapshot should bring back the board state captured at snapshot time.
+func TestRestoreSnapshot(t *testing.T) {
+	rootPath := t.TempDir()
+	state := newSnapshotTestBoard(t, rootPath)
+
+	info, err := CreateSnapshot(rootPath, state, filepath.Join(rootPath, "_assets", "icons"), "v1")
+	if err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+
+	// Mutate the board after the snapshot.
+	if err := WriteCardFile(context.Background(), filepath.Join(rootPath, "todo", "1.md"), CardMetadata{ID: 1, Title: "Changed"}, "changed\n"); err != nil {
+		t.Fatalf("mutate card: %v", err)
+	}
+
+	if err := RestoreSnapshot(rootPath, info.ID); err != nil {
+		t.Fatalf("restore snapshot: %v", err)
+	}
+
+	restored, err := ScanBoard(context.Background(), rootPath)
+	if err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	cards := restored.Lists["todo"]
+	if len(cards) != 1 || cards[0].Metadata.Title != "Card One" {
+		t.Fatalf("expected restored card title %q, got %+v", "Card One", cards)
+	}
+
+	// The snapshot directory itself should survive the swap.
+	snapshots, err := ListSnapshots(rootPath)
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot to survive restore, got %d (err %v)", len(snapshots), err)
+	}
+}
+
+// PruneSnapshots should keep only the most recent N snapshots.
+func TestPruneSnapshots(t *testing.T) {
+	rootPath := t.TempDir()
+	state := newSnapshotTestBoard(t, rootPath)
+
+	for i := 0; i < 3; i++ {
+		if _, err := CreateSnapshot(rootPath, state, filepath.Join(rootPath, "_assets", "icons"), ""); err != nil {
+			t.Fatalf("create snapshot %d: %v", i, err)
+		}
+	}
+
+	if err := PruneSnapshots(rootPath, 1); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+
+	snapshots, err := ListSnapshots(rootPath)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot after prune, got %d", len(snapshots))
+	}
+}
+
+// AutoSnapshot should be a no-op for a board that hasn't set backup.keep.
+func TestAutoSnapshot_NoOpWithoutBackupConfig(t *testing.T) {
+	rootPath := t.TempDir()
+	newSnapshotTestBoard(t, rootPath)
+
+	if err := AutoSnapshot(rootPath, "pre-delete-card"); err != nil {
+		t.Fatalf("auto snapshot: %v", err)
+	}
+	snapshots, err := ListSnapshots(rootPath)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(snapshots) != 0 {
+		t.Fatalf("expected no snapshots without backup.keep configured, got %d", len(snapshots))
+	}
+}
+
+// AutoSnapshot should create a labeled snapshot and prune down to backup.keep when configured.
+func TestAutoSnapshot_CreatesAndPrunesWithBackupConfig(t *testing.T) {
+	rootPath := t.TempDir()
+	state := newSnapshotTestBoard(t, rootPath)
+	state.Config.Backup = &BackupConfig{Keep: 1}
+	if err := SaveBoardConfig(context.Background(), rootPath, state.Config); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	if err := AutoSnapshot(rootPath, "pre-delete-card"); err != nil {
+		t.Fatalf("auto snapshot 1: %v", err)
+	}
+	if err := AutoSnapshot(rootPath, "pre-delete-list"); err != nil {
+		t.Fatalf("auto snapshot 2: %v", err)
+	}
+
+	snapshots, err := ListSnapshots(rootPath)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("expected backup.keep=1 to prune down to 1 snapshot, got %d", len(snapshots))
+	}
+	if snapshots[0].Label != "pre-delete-list" {
+		t.Fatalf("expected the most recent snapshot to survive pruning, got label %q", snapshots[0].Label)
+	}
+}
@@ -0,0 +1,166 @@
+package daedalus
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// FileKind classifies a FileDesc so Storage callers don't have to sniff extensions or path
+// depth themselves.
+type FileKind int
+
+const (
+	FileKindOther FileKind = iota
+	FileKindList
+	FileKindCard
+)
+
+// FileDesc names a file or directory relative to a board's root, replacing the raw absolute
+// path strings App's card/list methods pass around today. Name is root-relative (e.g.
+// "todo/1.md") and is only ever produced by Storage.Resolve or Storage.List, never built by
+// hand, so it can't smuggle in a ".." that Resolve would otherwise have rejected.
+type FileDesc struct {
+	Kind FileKind
+	Name string
+}
+
+// FileInfo is the subset of os.FileInfo Storage callers need.
+type FileInfo struct {
+	Name    string
+	Size    int64
+	IsDir   bool
+	ModTime time.Time
+}
+
+// Storage abstracts where a board's list directories and card files actually live. Resolve is
+// the traversal-safe replacement for App.validatePath: every other method takes only a FileDesc
+// that Resolve or List already produced, so a caller can't pass in a path that escapes the
+// board root.
+type Storage interface {
+	// Resolve turns a root-relative or absolute path from a caller (e.g. the frontend) into a
+	// FileDesc, rejecting anything that resolves outside the board root.
+	Resolve(path string) (FileDesc, error)
+	List(prefix string) ([]FileDesc, error)
+	Open(desc FileDesc) (io.ReadCloser, error)
+	Create(desc FileDesc) (io.WriteCloser, error)
+	Remove(desc FileDesc) error
+	Rename(old, new FileDesc) error
+	Stat(desc FileDesc) (FileInfo, error)
+}
+
+// AferoStorage implements Storage over an afero.Fs rooted at a board directory. It builds on
+// the afero.Fs plumbing OpenStorageFs already resolves rather than introducing a second
+// filesystem abstraction, so a board's existing "local"/"s3"/"sftp" storage driver gets typed
+// Storage support for free -- LocalStorage is just AferoStorage over afero.NewOsFs().
+type AferoStorage struct {
+	fs   afero.Fs
+	root string
+}
+
+// NewAferoStorage wraps fs (as returned by OpenStorageFs) rooted at root.
+func NewAferoStorage(fs afero.Fs, root string) *AferoStorage {
+	return &AferoStorage{fs: fs, root: root}
+}
+
+// LocalStorage is Storage backed by the real OS filesystem rooted at root, matching the
+// on-disk behavior App used before Storage existed.
+func LocalStorage(root string) *AferoStorage {
+	return NewAferoStorage(afero.NewOsFs(), root)
+}
+
+// Resolve mirrors App.validatePath's traversal check, then converts the validated absolute
+// path to a FileDesc relative to root.
+func (s *AferoStorage) Resolve(path string) (FileDesc, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return FileDesc{}, fmt.Errorf("invalid path")
+	}
+	absRoot, err := filepath.Abs(s.root)
+	if err != nil {
+		return FileDesc{}, fmt.Errorf("invalid root path")
+	}
+	prefix := absRoot + string(filepath.Separator)
+	// Windows and macOS use case-insensitive filesystems.
+	hasPrefix := false
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		hasPrefix = strings.HasPrefix(strings.ToLower(absPath), strings.ToLower(prefix))
+	} else {
+		hasPrefix = strings.HasPrefix(absPath, prefix)
+	}
+	if !hasPrefix {
+		return FileDesc{}, fmt.Errorf("path outside board directory")
+	}
+	rel, err := filepath.Rel(absRoot, absPath)
+	if err != nil {
+		return FileDesc{}, fmt.Errorf("invalid path")
+	}
+	return FileDesc{Kind: kindOfRelPath(rel), Name: rel}, nil
+}
+
+// kindOfRelPath classifies a root-relative path by shape: a top-level entry with no separator
+// is a list directory, anything ending in .md is a card, everything else is FileKindOther.
+func kindOfRelPath(rel string) FileKind {
+	if strings.HasSuffix(rel, ".md") {
+		return FileKindCard
+	}
+	if !strings.ContainsRune(rel, filepath.Separator) {
+		return FileKindList
+	}
+	return FileKindOther
+}
+
+func (s *AferoStorage) abs(desc FileDesc) string {
+	return filepath.Join(s.root, desc.Name)
+}
+
+// List returns the FileDescs of prefix's direct children (root-relative, e.g. "" for the
+// board root or a list dir name for its cards).
+func (s *AferoStorage) List(prefix string) ([]FileDesc, error) {
+	entries, err := afero.ReadDir(s.fs, filepath.Join(s.root, prefix))
+	if err != nil {
+		return nil, err
+	}
+	descs := make([]FileDesc, 0, len(entries))
+	for _, entry := range entries {
+		rel := filepath.Join(prefix, entry.Name())
+		kind := FileKindOther
+		switch {
+		case entry.IsDir():
+			kind = FileKindList
+		case strings.HasSuffix(entry.Name(), ".md"):
+			kind = FileKindCard
+		}
+		descs = append(descs, FileDesc{Kind: kind, Name: rel})
+	}
+	return descs, nil
+}
+
+func (s *AferoStorage) Open(desc FileDesc) (io.ReadCloser, error) {
+	return s.fs.Open(s.abs(desc))
+}
+
+func (s *AferoStorage) Create(desc FileDesc) (io.WriteCloser, error) {
+	return s.fs.Create(s.abs(desc))
+}
+
+func (s *AferoStorage) Remove(desc FileDesc) error {
+	return s.fs.RemoveAll(s.abs(desc))
+}
+
+func (s *AferoStorage) Rename(old, new FileDesc) error {
+	return s.fs.Rename(s.abs(old), s.abs(new))
+}
+
+func (s *AferoStorage) Stat(desc FileDesc) (FileInfo, error) {
+	info, err := s.fs.Stat(s.abs(desc))
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return FileInfo{Name: info.Name(), Size: info.Size(), IsDir: info.IsDir(), ModTime: info.ModTime()}, nil
+}
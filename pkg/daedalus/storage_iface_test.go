@@ -0,0 +1,121 @@
+package daedalus
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+// Resolve should classify a card and a list dir correctly and reject anything outside root,
+// mirroring the traversal cases App.validatePath used to cover directly.
+func TestAferoStorage_Resolve(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	storage := LocalStorage(root)
+
+	desc, err := storage.Resolve(filepath.Join(root, "todo", "1.md"))
+	if err != nil {
+		t.Fatalf("resolve card: %v", err)
+	}
+	if desc.Kind != FileKindCard || desc.Name != filepath.Join("todo", "1.md") {
+		t.Errorf("unexpected card desc: %+v", desc)
+	}
+
+	desc, err = storage.Resolve(filepath.Join(root, "todo"))
+	if err != nil {
+		t.Fatalf("resolve list: %v", err)
+	}
+	if desc.Kind != FileKindList || desc.Name != "todo" {
+		t.Errorf("unexpected list desc: %+v", desc)
+	}
+
+	if _, err := storage.Resolve(filepath.Join(root, "..", "etc", "passwd")); err == nil {
+		t.Fatalf("expected traversal outside root to be rejected")
+	}
+}
+
+// Create, Stat, Open, Rename and Remove should round-trip through an in-memory afero.Fs, the
+// same interface a real board's OS-backed storage satisfies -- this is the in-memory-for-tests
+// use case the Storage interface exists for.
+func TestAferoStorage_CreateStatOpenRenameRemove(t *testing.T) {
+	storage := NewAferoStorage(afero.NewMemMapFs(), "/board")
+	desc := FileDesc{Kind: FileKindCard, Name: "todo/1.md"}
+
+	w, err := storage.Create(desc)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	info, err := storage.Stat(desc)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Size != 5 || info.IsDir {
+		t.Errorf("unexpected FileInfo: %+v", info)
+	}
+
+	r, err := storage.Open(desc)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || string(data) != "hello" {
+		t.Errorf("unexpected content %q, err %v", data, err)
+	}
+
+	renamed := FileDesc{Kind: FileKindCard, Name: "done/1.md"}
+	if err := storage.Rename(desc, renamed); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if _, err := storage.Stat(desc); err == nil {
+		t.Errorf("expected old path to be gone after rename")
+	}
+
+	if err := storage.Remove(renamed); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := storage.Stat(renamed); err == nil {
+		t.Errorf("expected removed file to be gone")
+	}
+}
+
+// List should report both list directories and card files as direct children of the root.
+func TestAferoStorage_List(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(root, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card"}, "body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+
+	storage := LocalStorage(root)
+	descs, err := storage.List("")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(descs) != 1 || descs[0].Kind != FileKindList || descs[0].Name != "todo" {
+		t.Fatalf("unexpected root listing: %+v", descs)
+	}
+
+	descs, err = storage.List("todo")
+	if err != nil {
+		t.Fatalf("list todo: %v", err)
+	}
+	if len(descs) != 1 || descs[0].Kind != FileKindCard {
+		t.Fatalf("unexpected todo listing: %+v", descs)
+	}
+}
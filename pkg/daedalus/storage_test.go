@@ -0,0 +1,63 @@
+package daedalus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+// A nil storage config, and an explicit "local" driver, should both resolve to the OS
+// filesystem with no prefix -- the default every existing board relies on.
+func TestOpenStorageFs_LocalDriver(t *testing.T) {
+	for _, config := range []*StorageConfig{nil, {Driver: "local"}, {}} {
+		fs, root, err := OpenStorageFs(config)
+		if err != nil {
+			t.Fatalf("config %+v: unexpected error: %v", config, err)
+		}
+		if root != "" {
+			t.Errorf("config %+v: expected empty root, got %q", config, root)
+		}
+		if _, ok := fs.(*afero.OsFs); !ok {
+			t.Errorf("config %+v: expected *afero.OsFs, got %T", config, fs)
+		}
+	}
+}
+
+// An unrecognized driver name should be rejected rather than silently falling back to local.
+func TestOpenStorageFs_UnsupportedDriver(t *testing.T) {
+	_, _, err := OpenStorageFs(&StorageConfig{Driver: "ftp"})
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported driver")
+	}
+}
+
+// The s3 driver requires a bucket even if everything else (region, endpoint) is left default.
+func TestOpenStorageFs_S3RequiresBucket(t *testing.T) {
+	_, _, err := OpenStorageFs(&StorageConfig{Driver: "s3"})
+	if err == nil {
+		t.Fatalf("expected an error when bucket is unset")
+	}
+}
+
+// ScanBoard with no storage config in board.yaml should behave exactly as scanning the OS
+// filesystem directly always has.
+func TestScanBoard_DefaultsToLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := WriteCardFile(context.Background(), filepath.Join(dir, "todo", "1.md"), CardMetadata{ID: 1, Title: "Card"}, "body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+
+	state, err := ScanBoard(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(state.Lists["todo"]) != 1 {
+		t.Fatalf("expected 1 card, got %d", len(state.Lists["todo"]))
+	}
+}
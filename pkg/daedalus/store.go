@@ -0,0 +1,88 @@
+package daedalus
+
+import (
+	"context"
+
+	"github.com/spf13/afero"
+)
+
+// Store is the board-mutation surface CLI and HTTP handlers should go through instead of calling
+// ScanBoard/CreateCardOnDisk/DeleteListOnDisk/os.Remove directly against the OS filesystem. It
+// exists so those callers can be exercised against an in-memory board (NewMemStore) with no real
+// tempdir, and so a future non-local backend only has to be implemented once rather than every
+// handler re-learning OpenStorageFs.
+type Store interface {
+	Scan(ctx context.Context) (*BoardState, error)
+	ReadCard(ctx context.Context, card KanbanCard) (string, error)
+	CreateCard(listDir, title, body, position string, cards []KanbanCard, maxID int) (CardMetadata, string, int, error)
+	DeleteCard(card KanbanCard) error
+	MoveCard(destListDir string, card KanbanCard, destCards []KanbanCard, position string) (CardMetadata, string, error)
+	CreateList(name string, config *BoardConfig) error
+	DeleteList(name string, config *BoardConfig, permanent bool) error
+	// Watch starts a FileWatcher rooted at the store's board path. It only reports real changes
+	// for an OS-backed store (NewFsStore): a FileWatcher watches paths via os.Stat/fsnotify, so a
+	// memory-backed store's Watch never fires.
+	Watch(onChange func(paths []string)) *FileWatcher
+}
+
+// aferoStore is the only Store implementation: it delegates to the package's existing
+// Fs-suffixed board primitives (ScanBoardFs, CreateCardOnDiskFs, ...) rather than reimplementing
+// card/list I/O, so a local disk store and an in-memory store differ only in which afero.Fs they
+// wrap -- the same "swap the Fs, not the logic" pattern AferoStorage and OpenStorageFs already
+// use for remote storage drivers.
+type aferoStore struct {
+	fs       afero.Fs
+	rootPath string
+}
+
+// NewFsStore returns a Store backed by the real OS filesystem rooted at rootPath, matching the
+// on-disk behavior every CLI/HTTP handler had before Store existed.
+func NewFsStore(rootPath string) Store {
+	return &aferoStore{fs: afero.NewOsFs(), rootPath: rootPath}
+}
+
+// NewMemStore returns a Store backed entirely by memory (afero.NewMemMapFs), rooted at rootPath
+// within that in-memory filesystem. Tests can seed it with the Store's Create* methods, or
+// write board.yaml/card files directly via NewMemStoreFs's fs, then exercise a handler with zero
+// real I/O.
+func NewMemStore(rootPath string) Store {
+	return NewMemStoreFs(afero.NewMemMapFs(), rootPath)
+}
+
+// NewMemStoreFs is NewMemStore over a caller-supplied afero.Fs, for tests that want to seed
+// board files before constructing the Store.
+func NewMemStoreFs(fs afero.Fs, rootPath string) Store {
+	return &aferoStore{fs: fs, rootPath: rootPath}
+}
+
+func (s *aferoStore) Scan(ctx context.Context) (*BoardState, error) {
+	return ScanBoardFs(ctx, s.fs, s.rootPath)
+}
+
+func (s *aferoStore) ReadCard(ctx context.Context, card KanbanCard) (string, error) {
+	return ReadCardContentFs(ctx, s.fs, card.FilePath)
+}
+
+func (s *aferoStore) CreateCard(listDir, title, body, position string, cards []KanbanCard, maxID int) (CardMetadata, string, int, error) {
+	return CreateCardOnDiskFs(s.fs, s.rootPath, listDir, title, body, position, cards, maxID)
+}
+
+func (s *aferoStore) DeleteCard(card KanbanCard) error {
+	return s.fs.Remove(card.FilePath)
+}
+
+func (s *aferoStore) MoveCard(destListDir string, card KanbanCard, destCards []KanbanCard, position string) (CardMetadata, string, error) {
+	return MoveCardOnDiskFs(s.fs, s.rootPath, destListDir, card, destCards, position)
+}
+
+func (s *aferoStore) CreateList(name string, config *BoardConfig) error {
+	return CreateListOnDiskFs(s.fs, s.rootPath, name, config)
+}
+
+func (s *aferoStore) DeleteList(name string, config *BoardConfig, permanent bool) error {
+	return DeleteListOnDiskFs(s.fs, s.rootPath, name, config, permanent)
+}
+
+func (s *aferoStore) Watch(onChange func(paths []string)) *FileWatcher {
+	return NewFileWatcher(s.rootPath, onChange)
+}
@@ -0,0 +1,97 @@
+package daedalus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+// A MemStore should support the full create/scan/move/delete-card cycle and create/delete-list
+// cycle with no real filesystem involved.
+func TestMemStore_CardAndListLifecycle(t *testing.T) {
+	root := "/board"
+	store := NewMemStore(root)
+
+	config := &BoardConfig{Lists: []ListEntry{{Dir: "todo"}}}
+	if err := store.CreateList("todo", config); err != nil {
+		t.Fatalf("CreateList: %v", err)
+	}
+	if err := store.CreateList("done", config); err != nil {
+		t.Fatalf("CreateList: %v", err)
+	}
+
+	state, err := store.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if _, ok := state.Lists["todo"]; !ok {
+		t.Fatalf("expected todo list after CreateList, got %v", state.Lists)
+	}
+
+	meta, _, _, err := store.CreateCard("todo", "First card", "body", "bottom", state.Lists["todo"], state.MaxID)
+	if err != nil {
+		t.Fatalf("CreateCard: %v", err)
+	}
+
+	state, err = store.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	cards := state.Lists["todo"]
+	if len(cards) != 1 || cards[0].Metadata.ID != meta.ID {
+		t.Fatalf("expected 1 card with ID %d, got %v", meta.ID, cards)
+	}
+
+	body, err := store.ReadCard(context.Background(), cards[0])
+	if err != nil {
+		t.Fatalf("ReadCard: %v", err)
+	}
+	if body == "" {
+		t.Error("expected non-empty card body")
+	}
+
+	if _, _, err := store.MoveCard("done", cards[0], state.Lists["done"], "bottom"); err != nil {
+		t.Fatalf("MoveCard: %v", err)
+	}
+	state, err = store.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(state.Lists["todo"]) != 0 || len(state.Lists["done"]) != 1 {
+		t.Fatalf("expected card moved from todo to done, got todo=%v done=%v", state.Lists["todo"], state.Lists["done"])
+	}
+
+	if err := store.DeleteCard(state.Lists["done"][0]); err != nil {
+		t.Fatalf("DeleteCard: %v", err)
+	}
+	if err := store.DeleteList("done", state.Config, true); err != nil {
+		t.Fatalf("DeleteList: %v", err)
+	}
+	state, err = store.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if _, ok := state.Lists["done"]; ok {
+		t.Errorf("expected done list removed, got %v", state.Lists)
+	}
+}
+
+// NewMemStoreFs should let a test seed board files directly on the injected afero.Fs before
+// constructing the Store, for fixtures that don't go through CreateList/CreateCard.
+func TestMemStoreFs_SeededBoard(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	if err := afero.WriteFile(fs, root+"/board.yaml", []byte("title: Seeded\nlists:\n  - dir: todo\n"), 0644); err != nil {
+		t.Fatalf("seeding board.yaml: %v", err)
+	}
+	store := NewMemStoreFs(fs, root)
+
+	state, err := store.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if state.Config.Title != "Seeded" {
+		t.Errorf("expected title %q, got %q", "Seeded", state.Config.Title)
+	}
+}
@@ -1,6 +1,10 @@
 package daedalus
 
-import "time"
+import (
+	"time"
+
+	"github.com/spf13/afero"
+)
 
 // PreviewMaxLen is the maximum character length for card body previews.
 const PreviewMaxLen = 150
@@ -14,6 +18,7 @@ type BoardState struct {
 	TotalFileBytes int64
 	ConfigLoadTime time.Duration
 	ScanTime       time.Duration
+	Fs             afero.Fs `json:"-"`
 }
 
 // KanbanCard is the object sent to the frontend
@@ -22,6 +27,9 @@ type KanbanCard struct {
 	ListName    string       `json:"listName"`
 	Metadata    CardMetadata `json:"metadata"`
 	PreviewText string       `json:"previewText"`
+	// Dirty is set by LoadBoard's lazy content-integrity check when a card's body no longer
+	// hashes to its stored Metadata.ContentSHA256, meaning it was edited outside Daedalus.
+	Dirty bool `json:"dirty"`
 }
 
 // CardMetadata is the YAML frontmatter structure in the .md files
@@ -37,6 +45,36 @@ type CardMetadata struct {
 	Icon      string          `yaml:"icon,omitempty" json:"icon"`
 	Counter   *Counter        `yaml:"counter,omitempty" json:"counter,omitempty"`
 	Checklist []CheckListItem `yaml:"checklist,omitempty" json:"checklist,omitempty"`
+	Review    *ReviewState    `yaml:"review,omitempty" json:"review,omitempty"`
+	// ContentSHA256 is ContentHash(body) as of the last Daedalus-confirmed save, so a later
+	// scan can detect a body edited outside Daedalus. Empty for cards saved before this field
+	// existed -- those are never flagged dirty.
+	ContentSHA256 string `yaml:"content_sha256,omitempty" json:"contentSha256,omitempty"`
+	// Version is this card's per-device version vector (see pkg/daedalus/vclock.go), bumped on
+	// the local device's entry by every MoveCard/SaveCard. Empty for cards saved before this
+	// field existed, or on a board where no two devices have ever diverged on it yet.
+	Version []VersionEntry `yaml:"version,omitempty" json:"version,omitempty"`
+	// Attachments lists the files AttachCardFile has attached to this card, each stored once in
+	// the board's content-addressed blob store under .daedalus/blobs (see attachments.go).
+	Attachments []Attachment `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+}
+
+// Attachment is one file attached to a card via AttachCardFile, addressed by the SHA-256 of its
+// content in the board's blob store rather than by a path of its own.
+type Attachment struct {
+	Name     string `yaml:"name" json:"name"`
+	Sha256   string `yaml:"sha256" json:"sha256"`
+	Size     int64  `yaml:"size" json:"size"`
+	MimeType string `yaml:"mime_type,omitempty" json:"mimeType,omitempty"`
+}
+
+// ReviewState tracks spaced-repetition scheduling for a card under the SM-2 algorithm.
+type ReviewState struct {
+	LastReviewed time.Time `yaml:"last_reviewed,omitempty" json:"lastReviewed,omitempty"`
+	NextReview   time.Time `yaml:"next_review,omitempty" json:"nextReview,omitempty"`
+	Streak       int       `yaml:"streak" json:"streak"`
+	Algorithm    string    `yaml:"algorithm,omitempty" json:"algorithm,omitempty"`
+	Ease         float64   `yaml:"ease,omitempty" json:"ease"`
 }
 
 // DateRange is the date range a card will be active
@@ -0,0 +1,365 @@
+package daedalus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const defaultSyncPollInterval = 30 * time.Second
+
+// Sync op kinds recorded in the oplog and replayed against the remote by Syncer.push.
+const (
+	SyncOpWrite  = "write"
+	SyncOpDelete = "delete"
+)
+
+// oplogPath returns the durable append-only change log a board's Syncer drains against its
+// configured remote.
+func oplogPath(rootPath string) string {
+	return filepath.Join(rootPath, ".daedalus", "oplog")
+}
+
+// syncMarkerPath records the timestamp of the last successful pull reconciliation, so Syncer
+// can tell a local card that's untouched since then (safe to overwrite) from one edited since
+// (which should conflict instead of being clobbered).
+func syncMarkerPath(rootPath string) string {
+	return filepath.Join(rootPath, ".daedalus", "sync-marker")
+}
+
+// OpLogEntry is one durable change record enqueued by a card or config mutation and later
+// replayed against the remote by Syncer.push.
+type OpLogEntry struct {
+	Op        string    `json:"op"` // SyncOpWrite or SyncOpDelete
+	Path      string    `json:"path"`
+	Timestamp time.Time `json:"timestamp"`
+	Checksum  string    `json:"checksum"`
+}
+
+// AppendOpLog records a change to path (absolute, or relative to rootPath) in rootPath's oplog
+// on the real OS filesystem. Call this from every mutation that should propagate to a
+// configured sync remote: SaveCard, CreateCard, DeleteCard, and SaveBoardConfig.
+func AppendOpLog(rootPath, op, path string) error {
+	return AppendOpLogFs(afero.NewOsFs(), rootPath, op, path)
+}
+
+// AppendOpLogFs is the afero-backed implementation of AppendOpLog. checksum is computed from
+// the current file contents for SyncOpWrite (left empty for SyncOpDelete, since the file is
+// already gone by the time this is called).
+func AppendOpLogFs(fs afero.Fs, rootPath, op, path string) error {
+	relPath := path
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(rootPath, path); err == nil {
+			relPath = rel
+		}
+	}
+
+	checksum := ""
+	if op != SyncOpDelete {
+		if data, err := afero.ReadFile(fs, filepath.Join(rootPath, relPath)); err == nil {
+			checksum = hashBlob(data)
+		}
+	}
+
+	return appendOpLogEntry(fs, rootPath, OpLogEntry{
+		Op:        op,
+		Path:      filepath.ToSlash(relPath),
+		Timestamp: time.Now(),
+		Checksum:  checksum,
+	})
+}
+
+// appendOpLogEntry appends one JSON-encoded entry as a line to rootPath's oplog, creating the
+// file (and its parent .daedalus dir) if needed.
+func appendOpLogEntry(fs afero.Fs, rootPath string, entry OpLogEntry) error {
+	path := oplogPath(rootPath)
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating oplog dir: %w", err)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding oplog entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	existing, err := afero.ReadFile(fs, path)
+	if err != nil && !isNotExist(fs, path) {
+		return fmt.Errorf("reading oplog: %w", err)
+	}
+	return afero.WriteFile(fs, path, append(existing, line...), 0644)
+}
+
+// readOpLog returns every entry currently pending in rootPath's oplog, oldest first. A missing
+// oplog is not an error -- it just means nothing is pending.
+func readOpLog(fs afero.Fs, rootPath string) ([]OpLogEntry, error) {
+	path := oplogPath(rootPath)
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if isNotExist(fs, path) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading oplog: %w", err)
+	}
+
+	var entries []OpLogEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry OpLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("decoding oplog entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// clearOpLog removes rootPath's oplog after its entries have been successfully pushed.
+func clearOpLog(fs afero.Fs, rootPath string) error {
+	path := oplogPath(rootPath)
+	if exists, _ := afero.Exists(fs, path); !exists {
+		return nil
+	}
+	return fs.Remove(path)
+}
+
+// SyncStatus summarizes a Syncer's state for the frontend's sync indicator.
+type SyncStatus struct {
+	LastSync   time.Time `json:"lastSync"`
+	PendingOps int       `json:"pendingOps"`
+	Conflicts  []string  `json:"conflicts"`
+}
+
+// Syncer continuously replicates a board directory to and from a remote afero.Fs -- an S3
+// bucket, an SFTP host, or another daedalus instance's shared mount (see SyncConfig, and
+// OpenStorageFs's doc comment on treating a remote as just another afero.Fs). It drains the
+// local oplog against the remote on each poll (push), then scans the remote for cards changed
+// elsewhere (pull). A remote card whose local copy hasn't been touched since the last
+// successful pull is safely overwritten; one that has is written alongside as a
+// "<name>.conflict-<unix-ts>.md" sibling instead of being clobbered. Syncer holds no BoardState
+// and updates no MaxID itself; every remote card's ID lands in a local file, so the next local
+// scan's MaxID is at least the remote's.
+type Syncer struct {
+	fs         afero.Fs
+	rootPath   string
+	remoteFs   afero.Fs
+	remoteRoot string
+	interval   time.Duration
+	done       chan struct{}
+
+	mu        sync.Mutex
+	lastSync  time.Time
+	conflicts []string
+}
+
+// NewSyncer opens config.Remote via OpenStorageFs and starts a background Syncer for rootPath
+// on the real OS filesystem. A nil config, or one with a nil Remote, returns (nil, nil): the
+// board simply has no sync daemon.
+func NewSyncer(rootPath string, config *SyncConfig) (*Syncer, error) {
+	if config == nil || config.Remote == nil {
+		return nil, nil
+	}
+
+	remoteFs, remoteRoot, err := OpenStorageFs(config.Remote)
+	if err != nil {
+		return nil, fmt.Errorf("opening sync remote: %w", err)
+	}
+
+	interval := defaultSyncPollInterval
+	if config.PollIntervalSecs > 0 {
+		interval = time.Duration(config.PollIntervalSecs) * time.Second
+	}
+	return NewSyncerFs(afero.NewOsFs(), rootPath, remoteFs, remoteRoot, interval), nil
+}
+
+// NewSyncerFs creates and starts a Syncer between fs (local, rooted at rootPath) and remoteFs
+// (rooted at remoteRoot), polling every interval.
+func NewSyncerFs(fs afero.Fs, rootPath string, remoteFs afero.Fs, remoteRoot string, interval time.Duration) *Syncer {
+	s := &Syncer{
+		fs:         fs,
+		rootPath:   rootPath,
+		remoteFs:   remoteFs,
+		remoteRoot: remoteRoot,
+		interval:   interval,
+		done:       make(chan struct{}),
+	}
+
+	if marker, err := afero.ReadFile(fs, syncMarkerPath(rootPath)); err == nil {
+		if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(marker))); err == nil {
+			s.lastSync = t
+		}
+	}
+
+	go s.run()
+	slog.Info("syncer started", "path", rootPath, "remote", remoteRoot, "interval", interval)
+	return s
+}
+
+// Close stops the syncer.
+func (s *Syncer) Close() {
+	close(s.done)
+	slog.Info("syncer stopped")
+}
+
+// Status reports the syncer's last successful sync time, how many local changes are still
+// waiting to be pushed, and any conflict files produced by the most recent pull.
+func (s *Syncer) Status() SyncStatus {
+	entries, err := readOpLog(s.fs, s.rootPath)
+	pending := 0
+	if err == nil {
+		pending = len(entries)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conflicts := make([]string, len(s.conflicts))
+	copy(conflicts, s.conflicts)
+	return SyncStatus{LastSync: s.lastSync, PendingOps: pending, Conflicts: conflicts}
+}
+
+// run is the main polling loop.
+func (s *Syncer) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.syncOnce()
+		}
+	}
+}
+
+// syncOnce pushes pending local changes, then pulls and reconciles remote changes. Either half
+// failing is logged and left for the next poll rather than aborting the other half.
+func (s *Syncer) syncOnce() {
+	if err := s.push(); err != nil {
+		slog.Warn("syncer: push failed", "error", err)
+	}
+	if err := s.pull(); err != nil {
+		slog.Warn("syncer: pull failed", "error", err)
+	}
+}
+
+// push replays every pending oplog entry against the remote, then clears the log.
+func (s *Syncer) push() error {
+	entries, err := readOpLog(s.fs, s.rootPath)
+	if err != nil {
+		return fmt.Errorf("reading oplog: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	for _, entry := range entries {
+		remotePath := filepath.Join(s.remoteRoot, entry.Path)
+		if entry.Op == SyncOpDelete {
+			if err := s.remoteFs.RemoveAll(remotePath); err != nil {
+				slog.Warn("syncer: failed to push delete", "path", entry.Path, "error", err)
+			}
+			continue
+		}
+
+		data, err := afero.ReadFile(s.fs, filepath.Join(s.rootPath, entry.Path))
+		if err != nil {
+			slog.Warn("syncer: failed to read local file for push", "path", entry.Path, "error", err)
+			continue
+		}
+		if err := s.remoteFs.MkdirAll(filepath.Dir(remotePath), 0755); err != nil {
+			slog.Warn("syncer: failed to create remote dir", "path", entry.Path, "error", err)
+			continue
+		}
+		if err := afero.WriteFile(s.remoteFs, remotePath, data, 0644); err != nil {
+			slog.Warn("syncer: failed to push file", "path", entry.Path, "error", err)
+		}
+	}
+
+	slog.Info("syncer: pushed pending changes", "count", len(entries))
+	return clearOpLog(s.fs, s.rootPath)
+}
+
+// pull scans the remote and local boards, writes over any local card untouched since the last
+// sync, and forks a conflict sibling for one that was touched. It compares the two scans' MaxID
+// only to log when the remote's is higher; no in-memory MaxID is changed.
+func (s *Syncer) pull() error {
+	remote, err := ScanBoardFs(context.Background(), s.remoteFs, s.remoteRoot)
+	if err != nil {
+		return fmt.Errorf("scanning remote: %w", err)
+	}
+	local, err := ScanBoardFs(context.Background(), s.fs, s.rootPath)
+	if err != nil {
+		return fmt.Errorf("scanning local board: %w", err)
+	}
+
+	s.mu.Lock()
+	lastSync := s.lastSync
+	s.mu.Unlock()
+
+	var conflicts []string
+	for listName, cards := range remote.Lists {
+		listDir := filepath.Join(s.rootPath, listName)
+		if err := s.fs.MkdirAll(listDir, 0755); err != nil {
+			slog.Warn("syncer: failed to create local list dir", "list", listName, "error", err)
+			continue
+		}
+
+		for _, card := range cards {
+			localPath := filepath.Join(listDir, fmt.Sprintf("%d.md", card.Metadata.ID))
+
+			data, err := afero.ReadFile(s.remoteFs, card.FilePath)
+			if err != nil {
+				slog.Warn("syncer: failed to read remote card", "path", card.FilePath, "error", err)
+				continue
+			}
+
+			if info, err := s.fs.Stat(localPath); err == nil && !lastSync.IsZero() && info.ModTime().After(lastSync) {
+				conflictPath := fmt.Sprintf("%s.conflict-%d.md", strings.TrimSuffix(localPath, ".md"), time.Now().Unix())
+				if err := afero.WriteFile(s.fs, conflictPath, data, 0644); err != nil {
+					slog.Warn("syncer: failed to write conflict sibling", "path", conflictPath, "error", err)
+					continue
+				}
+				slog.Warn("syncer: local card modified since last sync, wrote conflict sibling",
+					"local", localPath, "conflict", conflictPath)
+				conflicts = append(conflicts, conflictPath)
+				continue
+			}
+
+			if err := afero.WriteFile(s.fs, localPath, data, 0644); err != nil {
+				slog.Warn("syncer: failed to write synced card", "path", localPath, "error", err)
+			}
+		}
+	}
+
+	if remote.MaxID > local.MaxID {
+		slog.Info("syncer: advancing MaxID from remote", "local", local.MaxID, "remote", remote.MaxID)
+	}
+
+	now := time.Now()
+	if err := s.fs.MkdirAll(filepath.Dir(syncMarkerPath(s.rootPath)), 0755); err != nil {
+		return fmt.Errorf("creating sync marker dir: %w", err)
+	}
+	if err := afero.WriteFile(s.fs, syncMarkerPath(s.rootPath), []byte(now.Format(time.RFC3339Nano)), 0644); err != nil {
+		return fmt.Errorf("writing sync marker: %w", err)
+	}
+
+	s.mu.Lock()
+	s.lastSync = now
+	s.conflicts = conflicts
+	s.mu.Unlock()
+	return nil
+}
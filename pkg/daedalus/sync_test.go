@@ -0,0 +1,170 @@
+package daedalus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// AppendOpLogFs should append one JSON line per call and let readOpLog decode them back in order.
+func TestAppendOpLogFs_AppendsAndReadsEntriesInOrder(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	if err := fs.MkdirAll(root+"/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := afero.WriteFile(fs, root+"/todo/1.md", []byte("body"), 0644); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+
+	if err := AppendOpLogFs(fs, root, SyncOpWrite, root+"/todo/1.md"); err != nil {
+		t.Fatalf("append write: %v", err)
+	}
+	if err := AppendOpLogFs(fs, root, SyncOpDelete, "todo/2.md"); err != nil {
+		t.Fatalf("append delete: %v", err)
+	}
+
+	entries, err := readOpLog(fs, root)
+	if err != nil {
+		t.Fatalf("read oplog: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Op != SyncOpWrite || entries[0].Path != "todo/1.md" || entries[0].Checksum == "" {
+		t.Fatalf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].Op != SyncOpDelete || entries[1].Path != "todo/2.md" || entries[1].Checksum != "" {
+		t.Fatalf("unexpected second entry: %+v", entries[1])
+	}
+}
+
+// readOpLog on a board with no oplog yet should return no entries and no error.
+func TestReadOpLog_MissingOplogReturnsEmpty(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	entries, err := readOpLog(fs, "/board")
+	if err != nil {
+		t.Fatalf("read oplog: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries, got %d", len(entries))
+	}
+}
+
+// push should replay every pending oplog entry against the remote and then clear the log.
+func TestSyncer_PushReplaysOplogAndClears(t *testing.T) {
+	localFs := afero.NewMemMapFs()
+	remoteFs := afero.NewMemMapFs()
+	root := "/board"
+
+	if err := localFs.MkdirAll(root+"/todo", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := afero.WriteFile(localFs, root+"/todo/1.md", []byte("card one"), 0644); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	if err := AppendOpLogFs(localFs, root, SyncOpWrite, "todo/1.md"); err != nil {
+		t.Fatalf("append oplog: %v", err)
+	}
+
+	s := &Syncer{fs: localFs, rootPath: root, remoteFs: remoteFs, remoteRoot: "/remote"}
+	if err := s.push(); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+
+	data, err := afero.ReadFile(remoteFs, "/remote/todo/1.md")
+	if err != nil {
+		t.Fatalf("expected card pushed to remote: %v", err)
+	}
+	if string(data) != "card one" {
+		t.Fatalf("expected pushed content to match, got %q", data)
+	}
+
+	entries, err := readOpLog(localFs, root)
+	if err != nil {
+		t.Fatalf("read oplog after push: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected oplog to be cleared after push, got %d entries", len(entries))
+	}
+}
+
+// pull should overwrite a local card that hasn't changed since the last sync, but fork off a
+// conflict sibling for one that has.
+func TestSyncer_PullForksConflictForLocallyModifiedCard(t *testing.T) {
+	localFs := afero.NewMemMapFs()
+	remoteFs := afero.NewMemMapFs()
+	root := "/board"
+	remoteRoot := "/remote"
+
+	if err := localFs.MkdirAll(root+"/todo", 0755); err != nil {
+		t.Fatalf("mkdir local: %v", err)
+	}
+	if err := remoteFs.MkdirAll(remoteRoot+"/todo", 0755); err != nil {
+		t.Fatalf("mkdir remote: %v", err)
+	}
+
+	// Card 1: remote has a newer version, local untouched since last sync -> overwritten.
+	if err := afero.WriteFile(localFs, root+"/todo/1.md", []byte("---\nid: 1\ntitle: Card\nlist_order: 0\n---\nold body"), 0644); err != nil {
+		t.Fatalf("write local card 1: %v", err)
+	}
+	if err := afero.WriteFile(remoteFs, remoteRoot+"/todo/1.md", []byte("---\nid: 1\ntitle: Card\nlist_order: 0\n---\nnew body"), 0644); err != nil {
+		t.Fatalf("write remote card 1: %v", err)
+	}
+
+	// Card 2: local was modified after the last sync -> pull should fork a conflict sibling.
+	if err := afero.WriteFile(localFs, root+"/todo/2.md", []byte("---\nid: 2\ntitle: Card\nlist_order: 1\n---\nlocal edit"), 0644); err != nil {
+		t.Fatalf("write local card 2: %v", err)
+	}
+	if err := afero.WriteFile(remoteFs, remoteRoot+"/todo/2.md", []byte("---\nid: 2\ntitle: Card\nlist_order: 1\n---\nremote edit"), 0644); err != nil {
+		t.Fatalf("write remote card 2: %v", err)
+	}
+
+	s := &Syncer{
+		fs: localFs, rootPath: root,
+		remoteFs: remoteFs, remoteRoot: remoteRoot,
+		lastSync: time.Now().Add(-time.Hour),
+	}
+
+	// Touch card 2 locally after lastSync so it reads as modified-since-last-sync.
+	if err := localFs.Chtimes(root+"/todo/2.md", time.Now(), time.Now()); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	// Backdate card 1 to before lastSync so it reads as untouched since the last sync.
+	if err := localFs.Chtimes(root+"/todo/1.md", time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	if err := s.pull(); err != nil {
+		t.Fatalf("pull: %v", err)
+	}
+
+	card1, err := afero.ReadFile(localFs, root+"/todo/1.md")
+	if err != nil {
+		t.Fatalf("read card 1: %v", err)
+	}
+	if !strings.Contains(string(card1), "new body") {
+		t.Fatalf("expected card 1 to be overwritten with remote content, got %q", card1)
+	}
+
+	status := s.Status()
+	if len(status.Conflicts) != 1 {
+		t.Fatalf("expected exactly one conflict, got %d: %v", len(status.Conflicts), status.Conflicts)
+	}
+	conflictData, err := afero.ReadFile(localFs, status.Conflicts[0])
+	if err != nil {
+		t.Fatalf("read conflict file: %v", err)
+	}
+	if !strings.Contains(string(conflictData), "remote edit") {
+		t.Fatalf("expected conflict sibling to hold the remote content, got %q", conflictData)
+	}
+	original, err := afero.ReadFile(localFs, root+"/todo/2.md")
+	if err != nil {
+		t.Fatalf("read original card 2: %v", err)
+	}
+	if !strings.Contains(string(original), "local edit") {
+		t.Fatalf("expected local edit to survive untouched, got %q", original)
+	}
+}
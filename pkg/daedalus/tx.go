@@ -0,0 +1,231 @@
+package daedalus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	TxOpWrite  = "write"
+	TxOpRename = "rename"
+	TxOpRemove = "remove"
+)
+
+func txJournalPath(rootPath string) string {
+	return filepath.Join(rootPath, ".daedalus", "journal")
+}
+
+// TxOp is one staged step of a Tx, serialized into the journal so a crash mid-commit can be
+// finished on the next LoadBoard instead of leaving the board half-moved.
+type TxOp struct {
+	Kind    string        `json:"kind"` // TxOpWrite, TxOpRename, or TxOpRemove
+	Path    string        `json:"path"`
+	NewPath string        `json:"newPath,omitempty"` // TxOpRename only
+	Meta    *CardMetadata `json:"meta,omitempty"`    // TxOpWrite only
+	Body    string        `json:"body,omitempty"`    // TxOpWrite only
+}
+
+// txOverlayEntry shadows path's real content for the lifetime of a Tx, so a read against a path
+// already staged in this transaction sees what the transaction will write rather than what's
+// still on disk.
+type txOverlayEntry struct {
+	removed bool
+	meta    CardMetadata
+	body    string
+}
+
+// Tx stages a batch of card writes, renames, and removals in memory, shadowing reads against
+// the overlay so multi-step operations (move several cards, delete a list and clear its
+// ListOrder, bulk rename) can be built up and inspected before anything touches the real
+// filesystem. Nothing is applied until Commit.
+//
+// Tx is the journaled, apply-together sibling of Txn (txn.go): see Txn's doc comment for when
+// to reach for which.
+type Tx struct {
+	fs       afero.Fs
+	rootPath string
+	ops      []TxOp
+	overlay  map[string]*txOverlayEntry
+}
+
+// NewTx returns a Tx staging changes against fs, rooted at rootPath (used only to locate the
+// journal file).
+func NewTx(fs afero.Fs, rootPath string) *Tx {
+	return &Tx{fs: fs, rootPath: rootPath, overlay: make(map[string]*txOverlayEntry)}
+}
+
+// WriteFile stages a card write at path. A ReadFile against path before Commit sees meta/body
+// exactly as staged here.
+func (tx *Tx) WriteFile(path string, meta CardMetadata, body string) {
+	tx.ops = append(tx.ops, TxOp{Kind: TxOpWrite, Path: path, Meta: &meta, Body: body})
+	tx.overlay[path] = &txOverlayEntry{meta: meta, body: body}
+}
+
+// Rename stages moving oldPath to newPath. The overlay follows the move, so a ReadFile against
+// newPath before Commit returns whatever oldPath held (staged or on disk), and a ReadFile
+// against oldPath reports not-exist.
+func (tx *Tx) Rename(ctx context.Context, oldPath, newPath string) error {
+	entry, ok := tx.overlay[oldPath]
+	if !ok {
+		meta, body, err := readCardFileFs(tx.fs, oldPath)
+		if err != nil {
+			return fmt.Errorf("staging rename of %s: %w", oldPath, err)
+		}
+		entry = &txOverlayEntry{meta: meta, body: body}
+	}
+	tx.ops = append(tx.ops, TxOp{Kind: TxOpRename, Path: oldPath, NewPath: newPath})
+	tx.overlay[oldPath] = &txOverlayEntry{removed: true}
+	tx.overlay[newPath] = entry
+	return nil
+}
+
+// Remove stages deleting path, recursively if it's a directory (e.g. an entire list being
+// deleted), the same as afero.Fs.RemoveAll.
+func (tx *Tx) Remove(path string) {
+	tx.ops = append(tx.ops, TxOp{Kind: TxOpRemove, Path: path})
+	tx.overlay[path] = &txOverlayEntry{removed: true}
+}
+
+// ReadFile returns path's metadata and body as this Tx would see them mid-transaction: the
+// staged overlay if path has been written, renamed into, or removed by this Tx, otherwise
+// whatever is actually on disk.
+func (tx *Tx) ReadFile(ctx context.Context, path string) (CardMetadata, string, error) {
+	if err := ctx.Err(); err != nil {
+		return CardMetadata{}, "", err
+	}
+	if entry, ok := tx.overlay[path]; ok {
+		if entry.removed {
+			return CardMetadata{}, "", fmt.Errorf("%s does not exist", path)
+		}
+		return entry.meta, entry.body, nil
+	}
+	return readCardFileFs(tx.fs, path)
+}
+
+// readCardFileFs reads path in full and parses it as a card (frontmatter + body), the same way
+// webdav's cardFile.Close and BoardFileSystem.applyCardWrite parse a buffered write.
+func readCardFileFs(fs afero.Fs, path string) (CardMetadata, string, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return CardMetadata{}, "", err
+	}
+	return ParseCardBytes(data)
+}
+
+// Commit materializes every staged op against the real filesystem: it writes a journal
+// recording the ops first, applies them in a deterministic order (every rename, then every
+// write, then every remove -- so a card moved into a new directory and then rewritten lands
+// at its final path before the write, instead of the write being clobbered by a rename that
+// runs after it), and clears the journal once every op has succeeded. A crash between the
+// journal write and the journal clear leaves .daedalus/journal on disk; ReplayTxFs re-applies
+// the same ops on the next LoadBoard to finish the commit, which is safe since every op is
+// idempotent.
+func (tx *Tx) Commit(ctx context.Context) error {
+	if len(tx.ops) == 0 {
+		return nil
+	}
+	if err := writeTxJournal(tx.fs, tx.rootPath, tx.ops); err != nil {
+		return fmt.Errorf("writing tx journal: %w", err)
+	}
+	if err := applyTxOps(ctx, tx.fs, tx.ops); err != nil {
+		return fmt.Errorf("applying tx: %w", err)
+	}
+	if err := clearTxJournal(tx.fs, tx.rootPath); err != nil {
+		return fmt.Errorf("clearing tx journal: %w", err)
+	}
+	return nil
+}
+
+func writeTxJournal(fs afero.Fs, rootPath string, ops []TxOp) error {
+	path := txJournalPath(rootPath)
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(fs, path, data, 0644)
+}
+
+func clearTxJournal(fs afero.Fs, rootPath string) error {
+	path := txJournalPath(rootPath)
+	if err := fs.Remove(path); err != nil && !isNotExist(fs, path) {
+		return err
+	}
+	return nil
+}
+
+func readTxJournal(fs afero.Fs, rootPath string) ([]TxOp, error) {
+	path := txJournalPath(rootPath)
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if isNotExist(fs, path) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ops []TxOp
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("parsing tx journal: %w", err)
+	}
+	return ops, nil
+}
+
+// applyTxOps materializes ops against fs in the deterministic order Commit promises: renames,
+// then writes, then removes.
+func applyTxOps(ctx context.Context, fs afero.Fs, ops []TxOp) error {
+	for _, op := range ops {
+		if op.Kind != TxOpRename {
+			continue
+		}
+		if err := fs.Rename(op.Path, op.NewPath); err != nil && !isNotExist(fs, op.Path) {
+			return err
+		}
+	}
+	for _, op := range ops {
+		if op.Kind != TxOpWrite {
+			continue
+		}
+		if op.Meta == nil {
+			return fmt.Errorf("tx write op for %s has no metadata", op.Path)
+		}
+		if err := WriteCardFileFs(ctx, fs, op.Path, *op.Meta, op.Body); err != nil {
+			return err
+		}
+	}
+	for _, op := range ops {
+		if op.Kind != TxOpRemove {
+			continue
+		}
+		if err := fs.RemoveAll(op.Path); err != nil && !isNotExist(fs, op.Path) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayTxFs finishes a dangling journal left by a Tx.Commit that crashed after writing it but
+// before clearing it, re-applying every staged op (safe, since each is idempotent) and then
+// clearing the journal. Returns whether a journal was found and replayed, so LoadBoard knows
+// whether to rescan.
+func ReplayTxFs(ctx context.Context, fs afero.Fs, rootPath string) (bool, error) {
+	ops, err := readTxJournal(fs, rootPath)
+	if err != nil {
+		return false, err
+	}
+	if len(ops) == 0 {
+		return false, nil
+	}
+	if err := applyTxOps(ctx, fs, ops); err != nil {
+		return false, err
+	}
+	if err := clearTxJournal(fs, rootPath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
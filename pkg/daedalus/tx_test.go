@@ -0,0 +1,116 @@
+package daedalus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+// A Tx with no staged ops should be a no-op Commit that never touches the journal.
+func TestTx_EmptyCommitIsNoOp(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	tx := NewTx(fs, "/board")
+	if err := tx.Commit(context.Background()); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if exists, _ := afero.Exists(fs, txJournalPath("/board")); exists {
+		t.Fatalf("expected no journal file for an empty tx")
+	}
+}
+
+// A write staged in a Tx should be visible to ReadFile before Commit, and materialized to the
+// real filesystem after.
+func TestTx_WriteVisibleInOverlayAndAfterCommit(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	fs.MkdirAll("/board/todo", 0755)
+	tx := NewTx(fs, "/board")
+
+	meta := CardMetadata{ID: 1, Title: "Staged"}
+	tx.WriteFile("/board/todo/1.md", meta, "staged body\n")
+
+	gotMeta, gotBody, err := tx.ReadFile(context.Background(), "/board/todo/1.md")
+	if err != nil {
+		t.Fatalf("read overlay: %v", err)
+	}
+	if gotMeta.Title != "Staged" || gotBody != "staged body\n" {
+		t.Fatalf("expected overlay read to see staged write, got %+v %q", gotMeta, gotBody)
+	}
+
+	if exists, _ := afero.Exists(fs, "/board/todo/1.md"); exists {
+		t.Fatalf("expected no write to land before Commit")
+	}
+	if err := tx.Commit(context.Background()); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if exists, _ := afero.Exists(fs, "/board/todo/1.md"); !exists {
+		t.Fatalf("expected write to land after Commit")
+	}
+	if exists, _ := afero.Exists(fs, txJournalPath("/board")); exists {
+		t.Fatalf("expected journal to be cleared after a successful Commit")
+	}
+}
+
+// ReplayTxFs should finish a dangling journal left by a crash between writing it and clearing
+// it, re-applying the staged op and then clearing the journal.
+func TestReplayTxFs_FinishesDanglingJournal(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	fs.MkdirAll("/board/todo", 0755)
+
+	meta := CardMetadata{ID: 1, Title: "Recovered"}
+	ops := []TxOp{{Kind: TxOpWrite, Path: "/board/todo/1.md", Meta: &meta, Body: "recovered body\n"}}
+	if err := writeTxJournal(fs, "/board", ops); err != nil {
+		t.Fatalf("writing journal: %v", err)
+	}
+
+	replayed, err := ReplayTxFs(context.Background(), fs, "/board")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if !replayed {
+		t.Fatal("expected a dangling journal to be replayed")
+	}
+	body, err := ReadCardContentFs(context.Background(), fs, "/board/todo/1.md")
+	if err != nil {
+		t.Fatalf("read recovered card: %v", err)
+	}
+	if body != "recovered body\n" {
+		t.Fatalf("expected recovered body, got %q", body)
+	}
+	if exists, _ := afero.Exists(fs, txJournalPath("/board")); exists {
+		t.Fatalf("expected journal to be cleared after replay")
+	}
+}
+
+// A rename staged in a Tx should move both the overlay entry and (after Commit) the file.
+func TestTx_RenameMovesOverlayAndFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	fs.MkdirAll("/board/todo", 0755)
+	fs.MkdirAll("/board/doing", 0755)
+	meta := CardMetadata{ID: 2, Title: "Movable"}
+	if err := WriteCardFileFs(context.Background(), fs, "/board/todo/2.md", meta, "body\n"); err != nil {
+		t.Fatalf("seed card: %v", err)
+	}
+
+	tx := NewTx(fs, "/board")
+	if err := tx.Rename(context.Background(), "/board/todo/2.md", "/board/doing/2.md"); err != nil {
+		t.Fatalf("stage rename: %v", err)
+	}
+
+	if _, _, err := tx.ReadFile(context.Background(), "/board/todo/2.md"); err == nil {
+		t.Fatal("expected overlay read of the old path to report not-exist")
+	}
+	if _, _, err := tx.ReadFile(context.Background(), "/board/doing/2.md"); err != nil {
+		t.Fatalf("expected overlay read of the new path to succeed: %v", err)
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if exists, _ := afero.Exists(fs, "/board/todo/2.md"); exists {
+		t.Fatalf("expected old path to be gone after commit")
+	}
+	if exists, _ := afero.Exists(fs, "/board/doing/2.md"); !exists {
+		t.Fatalf("expected new path to exist after commit")
+	}
+}
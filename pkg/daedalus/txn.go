@@ -0,0 +1,110 @@
+package daedalus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/afero"
+)
+
+// Txn stages an in-memory snapshot of everything a multi-file board mutation is about to
+// change -- card file bytes, a BoardState.Lists entry, Config.LabelColors -- so the caller can
+// undo the whole operation in one step if a write fails partway through, rather than leaving
+// board.yaml or some card files migrated and others not. It exists for callers like
+// updateCardsWithLabel that loop over many cards and bail out on the first error; later
+// multi-file mutations (bulk move, list rename, label merge) can reuse the same primitive
+// instead of hand-rolling their own rollback.
+//
+// Only the state first passed to each Snapshot* method is kept, so repeated snapshots of the
+// same card or list within one transaction are no-ops.
+//
+// Txn is deliberately the simpler, in-memory-only sibling of Tx (tx.go): it snapshots before a
+// mutation and restores on Abort, with nothing written until the caller's own loop writes it and
+// no journal surviving a crash mid-loop. Tx instead stages every write up front and Commits them
+// together through a crash-recoverable journal (see ReplayTxFs). Reach for Txn when the mutation
+// is "do N independent writes, roll all of them back if any fails" (updateCardsWithLabel); reach
+// for Tx when it's "stage a batch of writes/renames/removes and apply them atomically" (bulk
+// card moves, list deletion) and a journal is worth the extra bookkeeping.
+type Txn struct {
+	fs       afero.Fs
+	rootPath string
+	config   *BoardConfig
+	lists    map[string][]KanbanCard
+
+	cardBytes      map[string][]byte
+	listSnapshots  map[string][]KanbanCard
+	hadLabelColors bool
+	labelColors    map[string]string
+}
+
+// NewTxn starts a transaction over state's cards, lists, and config.
+func NewTxn(state *BoardState) *Txn {
+	return &Txn{
+		fs:            state.Fs,
+		rootPath:      state.RootPath,
+		config:        state.Config,
+		lists:         state.Lists,
+		cardBytes:     make(map[string][]byte),
+		listSnapshots: make(map[string][]KanbanCard),
+	}
+}
+
+// SnapshotCard records path's current on-disk contents the first time it's passed, so Abort can
+// restore it later.
+func (t *Txn) SnapshotCard(path string) error {
+	if _, ok := t.cardBytes[path]; ok {
+		return nil
+	}
+	data, err := afero.ReadFile(t.fs, path)
+	if err != nil {
+		return fmt.Errorf("snapshotting card %s: %w", path, err)
+	}
+	t.cardBytes[path] = data
+	return nil
+}
+
+// SnapshotList records listKey's current card slice the first time it's passed, so Abort can
+// restore BoardState.Lists[listKey] to it.
+func (t *Txn) SnapshotList(listKey string) {
+	if _, ok := t.listSnapshots[listKey]; ok {
+		return
+	}
+	t.listSnapshots[listKey] = append([]KanbanCard(nil), t.lists[listKey]...)
+}
+
+// SnapshotLabelColors records Config.LabelColors' current contents (including whether it was
+// nil) the first time it's called, so Abort can restore it.
+func (t *Txn) SnapshotLabelColors() {
+	if t.hadLabelColors {
+		return
+	}
+	t.hadLabelColors = true
+	if t.config.LabelColors != nil {
+		t.labelColors = make(map[string]string, len(t.config.LabelColors))
+		for k, v := range t.config.LabelColors {
+			t.labelColors[k] = v
+		}
+	}
+}
+
+// Abort restores every card file, list, and the LabelColors map this transaction recorded, then
+// re-saves board.yaml so the on-disk config matches the restored in-memory one. It's the
+// caller's job to call Abort only once an error has already occurred; Abort's own return value
+// is an error encountered while restoring, not the original failure.
+func (t *Txn) Abort(ctx context.Context) error {
+	for path, data := range t.cardBytes {
+		if err := afero.WriteFile(t.fs, path, data, 0644); err != nil {
+			return fmt.Errorf("restoring card %s during rollback: %w", path, err)
+		}
+	}
+	for listKey, cards := range t.listSnapshots {
+		t.lists[listKey] = cards
+	}
+	if t.hadLabelColors {
+		t.config.LabelColors = t.labelColors
+	}
+	if err := SaveBoardConfigFs(ctx, t.fs, t.rootPath, t.config); err != nil {
+		return fmt.Errorf("restoring board config during rollback: %w", err)
+	}
+	return nil
+}
@@ -1,12 +1,15 @@
 package daedalus
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"github.com/spf13/afero"
 )
 
 // PlatformOpen opens a file or URI with the system default handler.
@@ -23,7 +26,12 @@ func PlatformOpen(target string) error {
 
 // GetFileSize returns the size of a file in bytes, or 0 if the file cannot be stat'd.
 func GetFileSize(path string) int64 {
-	info, err := os.Stat(path)
+	return GetFileSizeFs(afero.NewOsFs(), path)
+}
+
+// GetFileSizeFs returns the size of a file in bytes via fs, or 0 if the file cannot be stat'd.
+func GetFileSizeFs(fs afero.Fs, path string) int64 {
+	info, err := fs.Stat(path)
 	if err != nil {
 		slog.Warn("failed to stat file for size", "path", path, "error", err)
 		return 0
@@ -45,8 +53,20 @@ func IsIconExt(name string) bool {
 	return ext == ".svg" || ext == ".png"
 }
 
+// pathWithinRoot reports whether path, once cleaned, is root itself or lies inside it. It's a
+// last line of defense against zip/tar-slip path traversal (CWE-22) when an untrusted archive
+// entry name or manifest field is joined onto a destination root before a write.
+func pathWithinRoot(root, path string) bool {
+	root = filepath.Clean(root)
+	path = filepath.Clean(path)
+	if path == root {
+		return true
+	}
+	return strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
 // IsListLocked returns true if the given list directory is marked as locked in the config.
 func IsListLocked(config *BoardConfig, dir string) bool {
-	idx := FindListEntry(config.Lists, dir)
+	idx := FindListEntry(context.Background(), config.Lists, dir)
 	return idx >= 0 && config.Lists[idx].Locked
 }
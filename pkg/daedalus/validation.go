@@ -3,24 +3,42 @@ package daedalus
 import (
 	"fmt"
 	"strings"
+
+	"daedalus/pkg/daedalus/slug"
 )
 
-// ValidateListName trims whitespace and validates a list directory name.
-// Rejects empty names, path separators, traversal sequences, hidden dirs, and reserved names.
-// Returns the cleaned name or an error.
+// ValidateListName trims whitespace and validates a list directory name, applying slug
+// sanitization with slug's zero Options (NFC normalization, whitespace collapsed to "-",
+// slug.DefaultDisallowed stripped, trailing dots trimmed). Equivalent to
+// ValidateListNameWithSlug(name, nil).
 func ValidateListName(name string) (string, error) {
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return "", fmt.Errorf("list name cannot be empty")
+	return ValidateListNameWithSlug(name, nil)
+}
+
+// ValidateListNameWithSlug trims whitespace and validates a list directory name against a
+// board's slug: config (see SlugConfig). A nil cfg sanitizes with slug's zero Options --
+// NFC-normalized, whitespace collapsed to "-", DefaultDisallowed stripped, and trailing dots
+// trimmed, but case and accents untouched.
+// Rejects names that sanitize to empty, path separators, traversal sequences, hidden dirs, and
+// reserved names (both slug's Windows-device-name list and daedalus's own "_assets").
+func ValidateListNameWithSlug(name string, cfg *SlugConfig) (string, error) {
+	opts := slug.Options{}
+	if cfg != nil {
+		opts.RemoveAccents = cfg.RemoveAccents
+		opts.Lower = cfg.Lower
+	}
+	cleaned, err := slug.Make(name, opts)
+	if err != nil {
+		return "", fmt.Errorf("invalid list name: %w", err)
 	}
-	if strings.ContainsAny(name, "/\\") || strings.Contains(name, "..") {
+	if strings.ContainsAny(cleaned, "/\\") || strings.Contains(cleaned, "..") {
 		return "", fmt.Errorf("invalid list name")
 	}
-	if strings.HasPrefix(name, ".") {
+	if strings.HasPrefix(cleaned, ".") {
 		return "", fmt.Errorf("list name cannot start with '.'")
 	}
-	if name == "_assets" {
+	if cleaned == "_assets" {
 		return "", fmt.Errorf("list name cannot be '_assets'")
 	}
-	return name, nil
+	return cleaned, nil
 }
@@ -0,0 +1,97 @@
+package daedalus
+
+// VersionEntry is one device's counter within a card's version vector, stored in the card's
+// front matter as version: [{id, value}, ...] (see CardMetadata.Version). Modeled on the
+// per-device counters Syncthing's file set keeps for each file.
+type VersionEntry struct {
+	ID    string `yaml:"id" json:"id"`
+	Value int64  `yaml:"value" json:"value"`
+}
+
+// VectorRelation is the result of comparing two version vectors.
+type VectorRelation int
+
+const (
+	VectorEqual      VectorRelation = iota // identical counters for every device
+	VectorAncestor                         // a happened-before b: every counter in a is <= b, and at least one is less
+	VectorDescendant                       // a happened-after b: the reverse of VectorAncestor
+	VectorConcurrent                       // neither dominates -- a genuine conflict
+)
+
+// versionMap flattens a version vector into a lookup by device ID, so CompareVersions and
+// BumpVersion don't need to linear-scan the slice per device.
+func versionMap(v []VersionEntry) map[string]int64 {
+	m := make(map[string]int64, len(v))
+	for _, e := range v {
+		m[e.ID] = e.Value
+	}
+	return m
+}
+
+// CompareVersions reports how version vector a relates to b. Two devices that have never both
+// incremented past what the other has seen produce VectorAncestor/VectorDescendant; two that
+// have each made edits unseen by the other produce VectorConcurrent, which is the case
+// MoveCard refuses rather than silently picking a winner.
+func CompareVersions(a, b []VersionEntry) VectorRelation {
+	am, bm := versionMap(a), versionMap(b)
+	aLess, bLess := false, false
+	seen := make(map[string]bool, len(am)+len(bm))
+	for id := range am {
+		seen[id] = true
+	}
+	for id := range bm {
+		seen[id] = true
+	}
+	for id := range seen {
+		av, bv := am[id], bm[id]
+		if av < bv {
+			aLess = true
+		}
+		if av > bv {
+			bLess = true
+		}
+	}
+	switch {
+	case !aLess && !bLess:
+		return VectorEqual
+	case aLess && !bLess:
+		return VectorAncestor
+	case !aLess && bLess:
+		return VectorDescendant
+	default:
+		return VectorConcurrent
+	}
+}
+
+// BumpVersion returns a copy of v with deviceID's own counter incremented (starting at 1 if
+// deviceID has no entry yet), leaving every other device's counter untouched. Called on every
+// MoveCard/SaveCard so this device's edits are always visible to CompareVersions.
+func BumpVersion(v []VersionEntry, deviceID string) []VersionEntry {
+	out := make([]VersionEntry, len(v))
+	copy(out, v)
+	for i := range out {
+		if out[i].ID == deviceID {
+			out[i].Value++
+			return out
+		}
+	}
+	return append(out, VersionEntry{ID: deviceID, Value: 1})
+}
+
+// MergeVersions returns a vector that dominates both a and b, by taking the max counter per
+// device. Used by ResolveConflict(KeepBoth) and ResolveConflict(KeepLocal/KeepRemote) so the
+// resolved card's version vector reflects that it has now incorporated both sides, instead of
+// continuing to look concurrent with whichever side was discarded.
+func MergeVersions(a, b []VersionEntry) []VersionEntry {
+	merged := versionMap(a)
+	for id, v := range versionMap(b) {
+		if v > merged[id] {
+			merged[id] = v
+		}
+	}
+	out := make([]VersionEntry, 0, len(merged))
+	for id, v := range merged {
+		out = append(out, VersionEntry{ID: id, Value: v})
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+package daedalus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// WAL op kinds recorded before a card write or delete is attempted.
+const (
+	WALOpWrite  = "write"
+	WALOpDelete = "delete"
+)
+
+// walLogPath returns the durable append-only log a board's card mutations are recorded to
+// before they're attempted, so a crash between the log append and the real write can be
+// recovered from by replaying it against the board on the next LoadBoard.
+func walLogPath(rootPath string) string {
+	return filepath.Join(rootPath, ".daedalus", "wal")
+}
+
+// WALRecord is one pending mutation: everything ApplyWALRecord needs to redo the write or
+// delete that was about to happen when it was appended. For WALOpWrite, Meta and Body are
+// exactly what would be passed to WriteCardFile, so replaying a record is just re-running the
+// same call -- WriteCardFile is already safe to call twice with the same arguments.
+type WALRecord struct {
+	Op        string        `json:"op"` // WALOpWrite or WALOpDelete
+	Path      string        `json:"path"`
+	Meta      *CardMetadata `json:"meta,omitempty"`
+	Body      string        `json:"body,omitempty"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// AppendWAL records an about-to-happen card write or delete in rootPath's WAL on the real OS
+// filesystem. Call this immediately before the corresponding WriteCardFile/Remove, and call
+// FinishWAL once it succeeds.
+func AppendWAL(rootPath, op, path string, meta *CardMetadata, body string) error {
+	return AppendWALFs(afero.NewOsFs(), rootPath, op, path, meta, body)
+}
+
+// AppendWALFs is the afero-backed implementation of AppendWAL.
+func AppendWALFs(fs afero.Fs, rootPath, op, path string, meta *CardMetadata, body string) error {
+	return appendWALRecord(fs, rootPath, WALRecord{
+		Op:        op,
+		Path:      path,
+		Meta:      meta,
+		Body:      body,
+		Timestamp: time.Now(),
+	})
+}
+
+// appendWALRecord appends one JSON-encoded record as a line to rootPath's WAL, creating the
+// file (and its parent .daedalus dir) if needed. Mirrors appendOpLogEntry.
+func appendWALRecord(fs afero.Fs, rootPath string, rec WALRecord) error {
+	path := walLogPath(rootPath)
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating wal dir: %w", err)
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encoding wal record: %w", err)
+	}
+	line = append(line, '\n')
+
+	existing, err := afero.ReadFile(fs, path)
+	if err != nil && !isNotExist(fs, path) {
+		return fmt.Errorf("reading wal: %w", err)
+	}
+	if err := afero.WriteFile(fs, path, append(existing, line...), 0644); err != nil {
+		return err
+	}
+	publishWALEvent(rootPath, rec)
+	return nil
+}
+
+// WALEvent is one record delivered to a Tail subscriber as it's appended to a board's WAL, for
+// a future watcher/UI to observe card mutations live instead of polling the log file or the
+// board directory.
+type WALEvent struct {
+	WALRecord
+	RootPath string `json:"rootPath"`
+}
+
+// walSubs tracks, per board root, the set of channels currently Tailing that board's WAL.
+var (
+	walSubsMu sync.Mutex
+	walSubs   = map[string]map[chan WALEvent]struct{}{}
+)
+
+// TailWAL subscribes ch to every record appended to rootPath's WAL (via AppendWAL/AppendWALFs)
+// from this point on, until ctx is done. A slow subscriber that isn't keeping up has records
+// dropped for it rather than blocking the mutation that triggered them.
+func TailWAL(ctx context.Context, rootPath string, ch chan WALEvent) {
+	walSubsMu.Lock()
+	if walSubs[rootPath] == nil {
+		walSubs[rootPath] = make(map[chan WALEvent]struct{})
+	}
+	walSubs[rootPath][ch] = struct{}{}
+	walSubsMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		walSubsMu.Lock()
+		delete(walSubs[rootPath], ch)
+		if len(walSubs[rootPath]) == 0 {
+			delete(walSubs, rootPath)
+		}
+		walSubsMu.Unlock()
+	}()
+}
+
+// publishWALEvent delivers rec to every channel currently Tailing rootPath.
+func publishWALEvent(rootPath string, rec WALRecord) {
+	walSubsMu.Lock()
+	defer walSubsMu.Unlock()
+	for ch := range walSubs[rootPath] {
+		select {
+		case ch <- WALEvent{WALRecord: rec, RootPath: rootPath}:
+		default:
+		}
+	}
+}
+
+// FinishWAL drops path's pending record from rootPath's WAL on the real OS filesystem once the
+// write or delete it describes has completed.
+func FinishWAL(rootPath, path string) error {
+	return FinishWALFs(afero.NewOsFs(), rootPath, path)
+}
+
+// FinishWALFs is the afero-backed implementation of FinishWAL. Removing the WAL file entirely
+// once it holds no pending records keeps a clean board from accumulating an empty .daedalus/wal
+// file, matching clearOpLog's behavior for the oplog.
+func FinishWALFs(fs afero.Fs, rootPath, path string) error {
+	logPath := walLogPath(rootPath)
+	records, err := readWAL(fs, rootPath)
+	if err != nil {
+		return err
+	}
+
+	var kept []WALRecord
+	for _, rec := range records {
+		if rec.Path != path {
+			kept = append(kept, rec)
+		}
+	}
+	if len(kept) == 0 {
+		if exists, _ := afero.Exists(fs, logPath); !exists {
+			return nil
+		}
+		return fs.Remove(logPath)
+	}
+
+	var buf bytes.Buffer
+	for _, rec := range kept {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("encoding wal record: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return afero.WriteFile(fs, logPath, buf.Bytes(), 0644)
+}
+
+// readWAL parses every well-formed record currently in rootPath's WAL, oldest first. A record
+// that fails to decode (a line torn by a crash mid-append) and every line after it are
+// discarded, since only the log's tail can ever be torn. A missing WAL is not an error -- it
+// just means nothing is pending.
+func readWAL(fs afero.Fs, rootPath string) ([]WALRecord, error) {
+	path := walLogPath(rootPath)
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if isNotExist(fs, path) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading wal: %w", err)
+	}
+
+	var records []WALRecord
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec WALRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			break
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// ReplayWAL returns every pending record in rootPath's WAL on the real OS filesystem, oldest
+// first. Call this right after a successful board scan and apply each record via
+// ApplyWALRecord, then CompactWAL to clear the log -- a non-empty result here means the
+// previous run crashed after appending a record but before FinishWAL ran.
+func ReplayWAL(rootPath string) ([]WALRecord, error) {
+	return readWAL(afero.NewOsFs(), rootPath)
+}
+
+// ApplyWALRecord redoes the write or delete described by rec against fs. A write is applied by
+// calling WriteCardFile with the record's own Meta and Body, so reapplying an already-applied
+// record just writes the same bytes again. A delete that's already been applied is not an
+// error, since the end state (the file being gone) is the same either way.
+func ApplyWALRecord(ctx context.Context, fs afero.Fs, rec WALRecord) error {
+	switch rec.Op {
+	case WALOpDelete:
+		if err := fs.Remove(rec.Path); err != nil && !isNotExist(fs, rec.Path) {
+			return fmt.Errorf("replaying wal delete of %s: %w", rec.Path, err)
+		}
+		return nil
+	case WALOpWrite:
+		if rec.Meta == nil {
+			return fmt.Errorf("replaying wal write of %s: record has no metadata", rec.Path)
+		}
+		if err := WriteCardFileFs(ctx, fs, rec.Path, *rec.Meta, rec.Body); err != nil {
+			return fmt.Errorf("replaying wal write of %s: %w", rec.Path, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("replaying wal record of %s: unknown op %q", rec.Path, rec.Op)
+	}
+}
+
+// CompactWAL removes rootPath's WAL on the real OS filesystem after its pending records have
+// been replayed.
+func CompactWAL(rootPath string) error {
+	return CompactWALFs(afero.NewOsFs(), rootPath)
+}
+
+// CompactWALFs is the afero-backed implementation of CompactWAL.
+func CompactWALFs(fs afero.Fs, rootPath string) error {
+	path := walLogPath(rootPath)
+	if exists, _ := afero.Exists(fs, path); !exists {
+		return nil
+	}
+	return fs.Remove(path)
+}
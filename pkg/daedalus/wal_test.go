@@ -0,0 +1,194 @@
+package daedalus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// A write record appended but never finished should be found by ReplayWAL (simulating a crash
+// between AppendWALFs and FinishWALFs) and ApplyWALRecord should redo it.
+func TestWAL_AppendReplayApplyWrite(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	meta := CardMetadata{ID: 1, Title: "Recovered"}
+
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/1.md", &meta, "# Recovered\n\nBody.\n"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+
+	records, err := readWAL(fs, root)
+	if err != nil {
+		t.Fatalf("readWAL: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 pending record, got %d", len(records))
+	}
+
+	if err := ApplyWALRecord(context.Background(), fs, records[0]); err != nil {
+		t.Fatalf("ApplyWALRecord: %v", err)
+	}
+	if exists, _ := afero.Exists(fs, "/board/open/1.md"); !exists {
+		t.Fatal("expected card file to exist after replaying wal write")
+	}
+
+	if err := CompactWALFs(fs, root); err != nil {
+		t.Fatalf("CompactWALFs: %v", err)
+	}
+	records, err = readWAL(fs, root)
+	if err != nil {
+		t.Fatalf("readWAL after compact: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("expected no pending records after compact, got %d", len(records))
+	}
+}
+
+// FinishWALFs should drop a record once its write has completed, so a clean shutdown leaves
+// nothing for ReplayWAL to redo.
+func TestWAL_FinishDropsCompletedRecord(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	meta := CardMetadata{ID: 1, Title: "Done"}
+
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/1.md", &meta, "body"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+	if err := FinishWALFs(fs, root, "/board/open/1.md"); err != nil {
+		t.Fatalf("FinishWALFs: %v", err)
+	}
+
+	records, err := readWAL(fs, root)
+	if err != nil {
+		t.Fatalf("readWAL: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("expected no pending records, got %d", len(records))
+	}
+}
+
+// A torn trailing line (simulating a crash mid-append) should be discarded rather than failing
+// the whole replay, along with anything after it.
+func TestWAL_TornTailDiscarded(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	meta := CardMetadata{ID: 1, Title: "Good"}
+
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/1.md", &meta, "body"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+
+	existing, err := afero.ReadFile(fs, walLogPath(root))
+	if err != nil {
+		t.Fatalf("reading wal: %v", err)
+	}
+	torn := append(existing, []byte(`{"op":"write","path":"/board/open/2.md"`)...) // no closing brace/newline
+	if err := afero.WriteFile(fs, walLogPath(root), torn, 0644); err != nil {
+		t.Fatalf("writing torn wal: %v", err)
+	}
+
+	records, err := readWAL(fs, root)
+	if err != nil {
+		t.Fatalf("readWAL: %v", err)
+	}
+	if len(records) != 1 || records[0].Path != "/board/open/1.md" {
+		t.Fatalf("expected only the one well-formed record, got %+v", records)
+	}
+}
+
+// Truncating the last few bytes of the log (simulating a crash mid-write of the final record's
+// trailing bytes) should discard only that torn record, leaving earlier well-formed ones intact.
+func TestWAL_TruncatedTailDiscarded(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	meta := CardMetadata{ID: 1, Title: "Good"}
+
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/1.md", &meta, "body"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/2.md", &meta, "body2"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+
+	existing, err := afero.ReadFile(fs, walLogPath(root))
+	if err != nil {
+		t.Fatalf("reading wal: %v", err)
+	}
+	truncated := existing[:len(existing)-5]
+	if err := afero.WriteFile(fs, walLogPath(root), truncated, 0644); err != nil {
+		t.Fatalf("writing truncated wal: %v", err)
+	}
+
+	records, err := readWAL(fs, root)
+	if err != nil {
+		t.Fatalf("readWAL: %v", err)
+	}
+	if len(records) != 1 || records[0].Path != "/board/open/1.md" {
+		t.Fatalf("expected only the first well-formed record to survive, got %+v", records)
+	}
+}
+
+// Tailing a board's WAL should receive every record appended to it after subscribing, and stop
+// once ctx is canceled.
+func TestWAL_TailReceivesAppendedRecords(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := make(chan WALEvent, 4)
+	TailWAL(ctx, root, ch)
+
+	meta := CardMetadata{ID: 1, Title: "Tailed"}
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/1.md", &meta, "body"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+
+	select {
+	case event := <-ch:
+		if event.Path != "/board/open/1.md" || event.RootPath != root {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	default:
+		t.Fatal("expected a WALEvent to be delivered to the tail channel")
+	}
+
+	cancel()
+	time.Sleep(time.Millisecond) // let TailWAL's unsubscribe goroutine run
+
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/2.md", &meta, "body2"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+	select {
+	case event := <-ch:
+		t.Fatalf("expected no event after cancel, got %+v", event)
+	default:
+	}
+}
+
+// FinishWALFs should only drop the record for the given path, leaving other pending records
+// (from concurrent-in-progress mutations) intact.
+func TestWAL_FinishOnlyDropsMatchingPath(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/board"
+	meta := CardMetadata{ID: 1}
+
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/1.md", &meta, "a"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+	if err := AppendWALFs(fs, root, WALOpWrite, "/board/open/2.md", &meta, "b"); err != nil {
+		t.Fatalf("AppendWALFs: %v", err)
+	}
+	if err := FinishWALFs(fs, root, "/board/open/1.md"); err != nil {
+		t.Fatalf("FinishWALFs: %v", err)
+	}
+
+	records, err := readWAL(fs, root)
+	if err != nil {
+		t.Fatalf("readWAL: %v", err)
+	}
+	if len(records) != 1 || records[0].Path != "/board/open/2.md" {
+		t.Fatalf("expected only /board/open/2.md still pending, got %+v", records)
+	}
+}
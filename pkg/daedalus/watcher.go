@@ -7,47 +7,204 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	pollInterval  = 5 * time.Second
+	debounceDelay = 150 * time.Millisecond
 )
 
+// WatcherBackend selects how a FileWatcher detects changes.
+type WatcherBackend int
+
 const (
-	pollInterval = 5 * time.Second
+	// WatcherBackendAuto uses fsnotify if it can watch rootPath, falling back to polling on
+	// filesystems fsnotify can't watch (e.g. some network mounts).
+	WatcherBackendAuto WatcherBackend = iota
+	// WatcherBackendPolling forces the polling backend, regardless of platform support. Tests
+	// use this to drive FileWatcher deterministically instead of waiting on OS event delivery.
+	WatcherBackendPolling
 )
 
-// FileWatcher polls a board directory for external file changes and calls a
-// callback when modifications are detected. Uses file modification times
-// rather than OS-level filesystem events, so it has zero external dependencies.
+// WatcherOptions configures a FileWatcher. The zero value picks the backend automatically.
+type WatcherOptions struct {
+	Backend WatcherBackend
+}
+
+// FileWatcher watches a board directory for external file changes and calls onChange with the
+// paths that changed. It prefers an fsnotify-based backend, which delivers create/write/
+// remove/rename events instantly and needs no re-walk of the board; it falls back to polling
+// file modification times every pollInterval when fsnotify can't watch rootPath, or when
+// WatcherOptions forces it.
 type FileWatcher struct {
 	rootPath string
-	onChange func()
+	onChange func(paths []string)
 	done     chan struct{}
+	closed   sync.Once
+
+	// polling backend state.
 	mu       sync.Mutex
-	snapshot map[string]time.Time // filePath -> modTime
+	snapshot map[string]time.Time
+
+	// fsnotify backend state.
+	watcher       *fsnotify.Watcher
+	debounceMu    sync.Mutex
+	debounceTimer *time.Timer
+	pending       map[string]bool
+}
+
+// NewFileWatcher creates and starts a file watcher for the given board root, picking a backend
+// automatically. onChange fires with the set of paths that changed, debounced by debounceDelay
+// so a burst of saves coalesces into a single call.
+func NewFileWatcher(rootPath string, onChange func(paths []string)) *FileWatcher {
+	return NewFileWatcherWithOptions(rootPath, onChange, WatcherOptions{})
 }
 
-// NewFileWatcher creates and starts a polling file watcher for the given board root.
-// The onChange callback fires when any relevant file is created, modified, or deleted.
-func NewFileWatcher(rootPath string, onChange func()) *FileWatcher {
+// NewFileWatcherWithOptions is NewFileWatcher with an explicit backend choice. See
+// WatcherBackendPolling for why tests want this over the automatic fsnotify-preferring default.
+func NewFileWatcherWithOptions(rootPath string, onChange func(paths []string), opts WatcherOptions) *FileWatcher {
 	fw := &FileWatcher{
 		rootPath: rootPath,
 		onChange: onChange,
 		done:     make(chan struct{}),
-		snapshot: make(map[string]time.Time),
+		pending:  make(map[string]bool),
+	}
+
+	if opts.Backend != WatcherBackendPolling {
+		if w, err := fw.startFsnotify(); err == nil {
+			fw.watcher = w
+			slog.Info("file watcher started", "path", rootPath, "backend", "fsnotify")
+			return fw
+		} else {
+			slog.Warn("fsnotify unavailable, falling back to polling", "path", rootPath, "error", err)
+		}
 	}
 
 	fw.snapshot = fw.scan()
 	go fw.run()
-
-	slog.Info("file watcher started", "path", rootPath, "files", len(fw.snapshot))
+	slog.Info("file watcher started", "path", rootPath, "backend", "polling", "files", len(fw.snapshot))
 	return fw
 }
 
-// Close stops the file watcher.
+// Close stops the file watcher and releases its backend's resources.
 func (fw *FileWatcher) Close() {
-	close(fw.done)
-	slog.Info("file watcher stopped")
+	fw.closed.Do(func() {
+		close(fw.done)
+		if fw.watcher != nil {
+			fw.watcher.Close()
+		}
+		fw.debounceMu.Lock()
+		if fw.debounceTimer != nil {
+			fw.debounceTimer.Stop()
+		}
+		fw.debounceMu.Unlock()
+		slog.Info("file watcher stopped")
+	})
+}
+
+// --- fsnotify backend ---
+
+// startFsnotify creates an fsnotify.Watcher subscribed to the board root, and every existing
+// top-level list directory, and starts its event loop. board.yaml lives directly in the root, so
+// watching the root itself already covers it.
+func (fw *FileWatcher) startFsnotify() (*fsnotify.Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(fw.rootPath); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	if entries, err := os.ReadDir(fw.rootPath); err == nil {
+		for _, entry := range entries {
+			if !isWatchableListDir(entry) {
+				continue
+			}
+			listDir := filepath.Join(fw.rootPath, entry.Name())
+			if err := w.Add(listDir); err != nil {
+				slog.Warn("file watcher: failed to watch list dir", "dir", listDir, "error", err)
+			}
+		}
+	}
+
+	go fw.watchFsnotify(w)
+	return w, nil
 }
 
-// run is the main polling loop.
+// isWatchableListDir reports whether entry is a top-level board directory FileWatcher should
+// treat as a list, mirroring scan's own filtering below.
+func isWatchableListDir(entry os.DirEntry) bool {
+	return entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") && entry.Name() != "assets"
+}
+
+// watchFsnotify is the fsnotify event loop: it auto-adds a watch for a newly created list
+// directory directly under rootPath, and coalesces every event into a debounced onChange call.
+func (fw *FileWatcher) watchFsnotify(w *fsnotify.Watcher) {
+	for {
+		select {
+		case <-fw.done:
+			return
+		case event, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			fw.handleFsnotifyEvent(w, event)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("file watcher: fsnotify error", "error", err)
+		}
+	}
+}
+
+// handleFsnotifyEvent auto-adds a watch for a list directory created directly under rootPath
+// (a removed/renamed-away directory's watch is simply dropped by fsnotify itself), then debounces
+// the event's path into the next onChange call.
+func (fw *FileWatcher) handleFsnotifyEvent(w *fsnotify.Watcher, event fsnotify.Event) {
+	if event.Op&fsnotify.Create != 0 && filepath.Dir(event.Name) == fw.rootPath {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() &&
+			!strings.HasPrefix(info.Name(), ".") && info.Name() != "assets" {
+			if err := w.Add(event.Name); err != nil {
+				slog.Warn("file watcher: failed to watch new list dir", "dir", event.Name, "error", err)
+			}
+		}
+	}
+	fw.debounce(event.Name)
+}
+
+// debounce records path as changed and, if no debounce timer is already pending, starts one;
+// when it fires, every path recorded since is delivered to onChange in a single call.
+func (fw *FileWatcher) debounce(path string) {
+	fw.debounceMu.Lock()
+	defer fw.debounceMu.Unlock()
+
+	fw.pending[path] = true
+	if fw.debounceTimer != nil {
+		return
+	}
+	fw.debounceTimer = time.AfterFunc(debounceDelay, func() {
+		fw.debounceMu.Lock()
+		paths := make([]string, 0, len(fw.pending))
+		for p := range fw.pending {
+			paths = append(paths, p)
+		}
+		fw.pending = make(map[string]bool)
+		fw.debounceTimer = nil
+		fw.debounceMu.Unlock()
+
+		slog.Debug("file watcher detected changes", "paths", len(paths))
+		fw.onChange(paths)
+	})
+}
+
+// --- polling backend ---
+
+// run is the polling backend's main loop.
 func (fw *FileWatcher) run() {
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
@@ -62,7 +219,8 @@ func (fw *FileWatcher) run() {
 	}
 }
 
-// poll takes a new snapshot and compares it against the previous one.
+// poll takes a new snapshot, compares it against the previous one, and fires onChange with
+// whatever paths differ.
 func (fw *FileWatcher) poll() {
 	current := fw.scan()
 
@@ -71,23 +229,27 @@ func (fw *FileWatcher) poll() {
 	fw.snapshot = current
 	fw.mu.Unlock()
 
-	if fw.hasChanged(prev, current) {
-		slog.Debug("file watcher detected changes")
-		fw.onChange()
+	if changed := changedPaths(prev, current); len(changed) > 0 {
+		slog.Debug("file watcher detected changes", "paths", len(changed))
+		fw.onChange(changed)
 	}
 }
 
-// hasChanged returns true if any file was added, removed, or modified.
-func (fw *FileWatcher) hasChanged(prev, current map[string]time.Time) bool {
-	if len(prev) != len(current) {
-		return true
-	}
+// changedPaths returns every path that was added, removed, or had its modtime change between
+// two snapshots.
+func changedPaths(prev, current map[string]time.Time) []string {
+	var changed []string
 	for path, modTime := range current {
 		if prevTime, ok := prev[path]; !ok || !prevTime.Equal(modTime) {
-			return true
+			changed = append(changed, path)
+		}
+	}
+	for path := range prev {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
 		}
 	}
-	return false
+	return changed
 }
 
 // scan walks the board directory and returns a snapshot of all relevant file modification times.
@@ -99,13 +261,17 @@ func (fw *FileWatcher) scan() map[string]time.Time {
 		result[fw.rootPath] = info.ModTime()
 	}
 
-	// Track board.yaml.
+	// Track board.yaml and, for a sharded board, board.order.
 	configPath := filepath.Join(fw.rootPath, "board.yaml")
 	if info, err := os.Stat(configPath); err == nil {
 		result[configPath] = info.ModTime()
 	}
+	orderPath := filepath.Join(fw.rootPath, boardOrderFileName)
+	if info, err := os.Stat(orderPath); err == nil {
+		result[orderPath] = info.ModTime()
+	}
 
-	// Scan each list subdirectory for .md card files.
+	// Scan each list subdirectory for .md card files and, for a sharded board, its .list.yaml.
 	entries, err := os.ReadDir(fw.rootPath)
 	if err != nil {
 		slog.Warn("file watcher: failed to read root dir", "error", err)
@@ -113,7 +279,7 @@ func (fw *FileWatcher) scan() map[string]time.Time {
 	}
 
 	for _, entry := range entries {
-		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || entry.Name() == "assets" {
+		if !isWatchableListDir(entry) {
 			continue
 		}
 		listDir := filepath.Join(fw.rootPath, entry.Name())
@@ -123,6 +289,11 @@ func (fw *FileWatcher) scan() map[string]time.Time {
 			result[listDir] = info.ModTime()
 		}
 
+		listConfigPath := filepath.Join(listDir, listConfigFileName)
+		if info, err := os.Stat(listConfigPath); err == nil {
+			result[listConfigPath] = info.ModTime()
+		}
+
 		files, err := os.ReadDir(listDir)
 		if err != nil {
 			slog.Warn("file watcher: failed to read list dir", "dir", listDir, "error", err)
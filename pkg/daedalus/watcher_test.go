@@ -0,0 +1,161 @@
+package daedalus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// The polling backend's poll() should report exactly the paths whose modtime changed or that
+// were added/removed between two scans, driven synchronously so the test doesn't depend on
+// pollInterval.
+func TestFileWatcher_PollingReportsChangedPaths(t *testing.T) {
+	root := t.TempDir()
+	listDir := filepath.Join(root, "todo")
+	if err := os.MkdirAll(listDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cardPath := filepath.Join(listDir, "1.md")
+	if err := os.WriteFile(cardPath, []byte("---\nid: 1\n---\nbody\n"), 0644); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+
+	var gotPaths []string
+	fw := NewFileWatcherWithOptions(root, func(paths []string) { gotPaths = paths }, WatcherOptions{Backend: WatcherBackendPolling})
+	defer fw.Close()
+
+	// No change yet: poll should fire nothing.
+	fw.poll()
+	if gotPaths != nil {
+		t.Fatalf("expected no callback before any change, got %v", gotPaths)
+	}
+
+	// Touch the card with a new modtime.
+	future := time.Now().Add(time.Second)
+	if err := os.Chtimes(cardPath, future, future); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	fw.poll()
+	if len(gotPaths) != 1 || gotPaths[0] != cardPath {
+		t.Fatalf("expected changed path [%s], got %v", cardPath, gotPaths)
+	}
+
+	// A newly created card in a new list dir should also be reported.
+	gotPaths = nil
+	doneDir := filepath.Join(root, "done")
+	if err := os.MkdirAll(doneDir, 0755); err != nil {
+		t.Fatalf("mkdir done: %v", err)
+	}
+	newCard := filepath.Join(doneDir, "2.md")
+	if err := os.WriteFile(newCard, []byte("---\nid: 2\n---\nbody\n"), 0644); err != nil {
+		t.Fatalf("write new card: %v", err)
+	}
+	fw.poll()
+	found := false
+	for _, p := range gotPaths {
+		if p == newCard {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected %s among changed paths, got %v", newCard, gotPaths)
+	}
+}
+
+// The polling backend should also report changes to a sharded board's board.order and per-list
+// .list.yaml files, not just board.yaml and card files.
+func TestFileWatcher_PollingReportsShardedConfigChanges(t *testing.T) {
+	root := t.TempDir()
+	listDir := filepath.Join(root, "todo")
+	if err := os.MkdirAll(listDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	orderPath := filepath.Join(root, boardOrderFileName)
+	if err := os.WriteFile(orderPath, []byte("todo\n"), 0644); err != nil {
+		t.Fatalf("write board.order: %v", err)
+	}
+	listConfigPath := filepath.Join(listDir, listConfigFileName)
+	if err := os.WriteFile(listConfigPath, []byte("dir: todo\n"), 0644); err != nil {
+		t.Fatalf("write .list.yaml: %v", err)
+	}
+
+	var gotPaths []string
+	fw := NewFileWatcherWithOptions(root, func(paths []string) { gotPaths = paths }, WatcherOptions{Backend: WatcherBackendPolling})
+	defer fw.Close()
+
+	future := time.Now().Add(time.Second)
+	if err := os.Chtimes(orderPath, future, future); err != nil {
+		t.Fatalf("chtimes board.order: %v", err)
+	}
+	if err := os.Chtimes(listConfigPath, future, future); err != nil {
+		t.Fatalf("chtimes .list.yaml: %v", err)
+	}
+	fw.poll()
+
+	wantPaths := map[string]bool{orderPath: true, listConfigPath: true}
+	for _, p := range gotPaths {
+		delete(wantPaths, p)
+	}
+	if len(wantPaths) != 0 {
+		t.Errorf("expected %v among changed paths, got %v", []string{orderPath, listConfigPath}, gotPaths)
+	}
+}
+
+// The fsnotify backend should deliver a debounced callback for a card written after the watcher
+// starts, and should pick up a list directory created after the watcher starts without needing a
+// restart.
+func TestFileWatcher_FsnotifyDetectsWriteAndNewListDir(t *testing.T) {
+	root := t.TempDir()
+	listDir := filepath.Join(root, "todo")
+	if err := os.MkdirAll(listDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	changes := make(chan []string, 8)
+	fw := NewFileWatcher(root, func(paths []string) { changes <- paths })
+	defer fw.Close()
+
+	cardPath := filepath.Join(listDir, "1.md")
+	if err := os.WriteFile(cardPath, []byte("---\nid: 1\n---\nbody\n"), 0644); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+
+	select {
+	case paths := <-changes:
+		if len(paths) == 0 {
+			t.Error("expected at least one changed path for the card write")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for fsnotify to report the card write")
+	}
+
+	// A list directory created after the watcher started should be watched automatically, so a
+	// card written into it is still reported without restarting the watcher.
+	doneDir := filepath.Join(root, "done")
+	if err := os.MkdirAll(doneDir, 0755); err != nil {
+		t.Fatalf("mkdir done: %v", err)
+	}
+	// Give fsnotify a beat to process the MKDIR event and add the new watch before writing into it.
+	time.Sleep(100 * time.Millisecond)
+
+	newCard := filepath.Join(doneDir, "2.md")
+	if err := os.WriteFile(newCard, []byte("---\nid: 2\n---\nbody\n"), 0644); err != nil {
+		t.Fatalf("write new card: %v", err)
+	}
+
+	select {
+	case paths := <-changes:
+		found := false
+		for _, p := range paths {
+			if p == newCard {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %s among changed paths, got %v", newCard, paths)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for fsnotify to report the card written to the new list dir")
+	}
+}
@@ -0,0 +1,149 @@
+package webdav
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/webdav"
+
+	"daedalus/pkg/daedalus"
+)
+
+// boardFS is a golang.org/x/net/webdav.FileSystem rooted at a daedalus board directory. Reads
+// and most structural operations delegate straight to webdav.Dir; writes to a card file and
+// changes to list directories are routed back through the daedalus package so board.yaml and
+// card frontmatter stay consistent with what a native edit followed by ScanBoard would produce.
+type boardFS struct {
+	root string
+	dir  webdav.Dir
+}
+
+func newBoardFS(root string) *boardFS {
+	return &boardFS{root: root, dir: webdav.Dir(root)}
+}
+
+func (fs *boardFS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	if err := fs.dir.Mkdir(ctx, name, perm); err != nil {
+		return err
+	}
+	if isListDir(name) {
+		return fs.reconcileLists(ctx)
+	}
+	return nil
+}
+
+func (fs *boardFS) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if isCardWrite(name, flag) {
+		return &cardFile{ctx: ctx, path: filepath.Join(fs.root, filepath.FromSlash(name))}, nil
+	}
+	return fs.dir.OpenFile(ctx, name, flag, perm)
+}
+
+func (fs *boardFS) RemoveAll(ctx context.Context, name string) error {
+	if err := fs.dir.RemoveAll(ctx, name); err != nil {
+		return err
+	}
+	if isListDir(name) {
+		return fs.reconcileLists(ctx)
+	}
+	return nil
+}
+
+func (fs *boardFS) Rename(ctx context.Context, oldName, newName string) error {
+	if err := fs.dir.Rename(ctx, oldName, newName); err != nil {
+		return err
+	}
+	if isListDir(oldName) || isListDir(newName) {
+		return fs.reconcileLists(ctx)
+	}
+	return nil
+}
+
+func (fs *boardFS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	return fs.dir.Stat(ctx, name)
+}
+
+// reconcileLists reloads board.yaml and merges it against the list directories actually
+// present on disk via daedalus.MergeListEntries, then saves it back. A WebDAV MKCOL,
+// RemoveAll, or Rename changes the directories on disk directly, without going through
+// ScanBoard, so this is the hook point that keeps board.yaml in sync afterward.
+func (fs *boardFS) reconcileLists(ctx context.Context) error {
+	config, err := daedalus.LoadBoardConfig(fs.root)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(fs.root)
+	if err != nil {
+		return err
+	}
+	diskDirs := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") && entry.Name() != "_assets" {
+			diskDirs[entry.Name()] = true
+		}
+	}
+	daedalus.MergeListEntries(config, diskDirs)
+	return daedalus.SaveBoardConfig(ctx, fs.root, config)
+}
+
+// isListDir reports whether name (a WebDAV path rooted at the board) is a top-level list
+// directory rather than the board root, a card file, or the _assets tree.
+func isListDir(name string) bool {
+	clean := strings.Trim(filepath.ToSlash(name), "/")
+	if clean == "" || strings.Contains(clean, "/") {
+		return false
+	}
+	return clean != "_assets" && !strings.HasPrefix(clean, ".")
+}
+
+// isCardWrite reports whether a WebDAV OpenFile call is writing a card markdown file inside a
+// list directory, i.e. one that should be routed through daedalus.WriteCardFile instead of a
+// raw file write.
+func isCardWrite(name string, flag int) bool {
+	if flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return false
+	}
+	clean := strings.Trim(filepath.ToSlash(name), "/")
+	parts := strings.Split(clean, "/")
+	return len(parts) == 2 && strings.HasSuffix(parts[1], ".md")
+}
+
+// cardFile is the webdav.File returned for a card write. It buffers the client's write in
+// memory and, on Close, parses the buffered bytes as a card (frontmatter + body) and routes
+// them through daedalus.WriteCardFile, so unknown frontmatter fields are preserved and Updated
+// bumps exactly as they would for a native card edit.
+type cardFile struct {
+	ctx  context.Context
+	path string
+	buf  []byte
+}
+
+func (f *cardFile) Write(p []byte) (int, error) {
+	f.buf = append(f.buf, p...)
+	return len(p), nil
+}
+
+func (f *cardFile) Close() error {
+	meta, body, err := daedalus.ParseCardBytes(f.buf)
+	if err != nil {
+		return err
+	}
+	if meta.ID == 0 {
+		base := filepath.Base(f.path)
+		meta.ID, _ = strconv.Atoi(strings.TrimSuffix(base, ".md"))
+	}
+	now := time.Now()
+	meta.Updated = &now
+	return daedalus.WriteCardFile(f.ctx, f.path, meta, body)
+}
+
+// Read, Seek, Readdir, and Stat round out webdav.File; a PUT never exercises them, since the
+// handler only opens a file for write, copies the request body into it, and closes it.
+func (f *cardFile) Read(p []byte) (int, error)                   { return 0, os.ErrInvalid }
+func (f *cardFile) Seek(offset int64, whence int) (int64, error) { return 0, os.ErrInvalid }
+func (f *cardFile) Readdir(count int) ([]os.FileInfo, error)     { return nil, os.ErrInvalid }
+func (f *cardFile) Stat() (os.FileInfo, error)                   { return os.Stat(f.path) }
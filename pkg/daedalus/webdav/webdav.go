@@ -0,0 +1,100 @@
+// Package webdav exposes a daedalus board over WebDAV (RFC 4918), so it can be mounted as a
+// network drive in Finder/Explorer/Nautilus and edited with any external editor. Lists are
+// presented as directories and cards as the same .md files daedalus itself reads and writes;
+// card writes are routed back through daedalus.WriteCardFile so frontmatter is preserved and
+// Updated timestamps bump, and new or removed list directories are reconciled into board.yaml
+// via daedalus.MergeListEntries, just as ScanBoard would on its next pass.
+package webdav
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"golang.org/x/net/webdav"
+
+	"daedalus/pkg/daedalus"
+)
+
+// NewHandler returns an http.Handler that serves the board rooted at rootPath over WebDAV,
+// gating every request on HTTP basic auth from cfg.WebDAV. A write into a locked list is
+// rejected with 403 before it reaches the board, via withLockCheck. Locking is handled by
+// webdav.NewMemLS, an in-memory lock system keyed by the relative path being locked.
+func NewHandler(rootPath string, cfg *daedalus.BoardConfig) http.Handler {
+	handler := &webdav.Handler{
+		FileSystem: newBoardFS(rootPath),
+		LockSystem: webdav.NewMemLS(),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				slog.Warn("webdav request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+			}
+		},
+	}
+	return withBasicAuth(cfg, withLockCheck(cfg, handler))
+}
+
+// lockedWriteMethods are the WebDAV request methods that mutate an existing list directory's
+// contents and so must be rejected up front if that list is locked. MKCOL is excluded since it
+// only ever creates a new, as-yet-unlocked list.
+var lockedWriteMethods = map[string]bool{
+	"PUT":       true,
+	"DELETE":    true,
+	"MOVE":      true,
+	"COPY":      true,
+	"PROPPATCH": true,
+}
+
+// withLockCheck returns 403 for a write method targeting a locked list (and, for MOVE/COPY, a
+// locked destination list) before the request reaches next, mirroring the Wails app's
+// withWebDAVLockCheck. golang.org/x/net/webdav.Handler reports every boardFS error -- locked
+// list or not -- as 404, which would otherwise hide the real reason a write was rejected.
+func withLockCheck(cfg *daedalus.BoardConfig, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !lockedWriteMethods[r.Method] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if daedalus.IsListLocked(cfg, listDir(r.URL.Path)) {
+			http.Error(w, "list is locked", http.StatusForbidden)
+			return
+		}
+		if dest := r.Header.Get("Destination"); dest != "" {
+			if destURL, err := url.Parse(dest); err == nil && daedalus.IsListLocked(cfg, listDir(destURL.Path)) {
+				http.Error(w, "destination list is locked", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// listDir extracts the top-level list directory from a WebDAV request path, or "" if name
+// isn't rooted under one (e.g. the board root itself).
+func listDir(name string) string {
+	clean := strings.Trim(path.Clean("/"+name), "/")
+	if clean == "" {
+		return ""
+	}
+	return strings.SplitN(clean, "/", 2)[0]
+}
+
+// withBasicAuth requires HTTP basic auth matching cfg.WebDAV before delegating to next. A nil
+// or incomplete cfg.WebDAV rejects every request, since serving a board over the network
+// without credentials configured is almost certainly a misconfiguration rather than intent.
+func withBasicAuth(cfg *daedalus.BoardConfig, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cfg.WebDAV == nil || cfg.WebDAV.Username == "" || cfg.WebDAV.Password == "" {
+			http.Error(w, "webdav is not configured for this board", http.StatusServiceUnavailable)
+			return
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != cfg.WebDAV.Username || pass != cfg.WebDAV.Password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="daedalus"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,201 @@
+package webdav
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"daedalus/pkg/daedalus"
+)
+
+// setupTestBoard builds a temp board with one list ("todo") containing one card, basic-auth
+// webdav credentials in board.yaml, and returns the board root alongside an httptest.Server
+// fronting NewHandler for it.
+func setupTestBoard(t *testing.T) (root string, srv *httptest.Server) {
+	t.Helper()
+	root = t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(root, "todo"), 0755); err != nil {
+		t.Fatalf("mkdir todo: %v", err)
+	}
+	if err := daedalus.WriteCardFile(context.Background(), filepath.Join(root, "todo", "1.md"), daedalus.CardMetadata{ID: 1, Title: "Card One"}, "original body\n"); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+	config := &daedalus.BoardConfig{
+		Lists:  []daedalus.ListEntry{{Dir: "todo"}},
+		WebDAV: &daedalus.WebDAVConfig{Username: "alice", Password: "secret"},
+	}
+	if err := daedalus.SaveBoardConfig(context.Background(), root, config); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	srv = httptest.NewServer(NewHandler(root, config))
+	t.Cleanup(srv.Close)
+	return root, srv
+}
+
+func doRequest(t *testing.T, srv *httptest.Server, method, path, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.SetBasicAuth("alice", "secret")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// A request without basic auth, or with the wrong credentials, must be rejected before it
+// ever reaches the board filesystem.
+func TestWebDAV_RejectsWithoutAuth(t *testing.T) {
+	_, srv := setupTestBoard(t)
+
+	req, _ := http.NewRequest("PROPFIND", srv.URL+"/", nil)
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("propfind: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+// PROPFIND on the board root should list the existing list directory.
+func TestWebDAV_PropfindListsDirectories(t *testing.T) {
+	_, srv := setupTestBoard(t)
+
+	req, _ := http.NewRequest("PROPFIND", srv.URL+"/", nil)
+	req.SetBasicAuth("alice", "secret")
+	req.Header.Set("Depth", "1")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("propfind: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d", resp.StatusCode)
+	}
+}
+
+// PUT on a card's .md path should route the write through daedalus.WriteCardFile: the body
+// changes, the Updated timestamp bumps, and the frontmatter's id/title survive.
+func TestWebDAV_PutCardWritesThroughDaedalus(t *testing.T) {
+	root, srv := setupTestBoard(t)
+
+	newContent := "---\nid: 1\ntitle: Card One\nlist_order: 0\n---\nedited body\n"
+	resp := doRequest(t, srv, http.MethodPut, "/todo/1.md", newContent)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 201/204, got %d", resp.StatusCode)
+	}
+
+	state, err := daedalus.ScanBoard(context.Background(), root)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	cards := state.Lists["todo"]
+	if len(cards) != 1 {
+		t.Fatalf("expected 1 card, got %d", len(cards))
+	}
+	body, err := daedalus.ReadCardContent(context.Background(), cards[0].FilePath)
+	if err != nil {
+		t.Fatalf("read card content: %v", err)
+	}
+	if strings.TrimSpace(body) != "edited body" {
+		t.Fatalf("expected edited body, got %q", body)
+	}
+	if cards[0].Metadata.Updated == nil {
+		t.Fatalf("expected Updated to be set after a webdav write")
+	}
+}
+
+// MKCOL for a new top-level directory should create it on disk and reconcile it into
+// board.yaml, the same as ScanBoard discovering a manually created list directory.
+func TestWebDAV_MkcolReconcilesNewList(t *testing.T) {
+	root, srv := setupTestBoard(t)
+
+	resp := doRequest(t, srv, "MKCOL", "/inbox", "")
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+
+	config, err := daedalus.LoadBoardConfig(root)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if daedalus.FindListEntry(context.Background(), config.Lists, "inbox") < 0 {
+		t.Fatalf("expected board.yaml to contain a list entry for %q, got %+v", "inbox", config.Lists)
+	}
+}
+
+// DELETE on a list directory should remove it from disk and drop its stale entry from
+// board.yaml via the same MergeListEntries reconciliation ScanBoard performs.
+func TestWebDAV_DeleteRemovesListAndReconciles(t *testing.T) {
+	root, srv := setupTestBoard(t)
+	if err := os.MkdirAll(filepath.Join(root, "archive"), 0755); err != nil {
+		t.Fatalf("mkdir archive: %v", err)
+	}
+	config, err := daedalus.LoadBoardConfig(root)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	config.Lists = append(config.Lists, daedalus.ListEntry{Dir: "archive"})
+	if err := daedalus.SaveBoardConfig(context.Background(), root, config); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	resp := doRequest(t, srv, http.MethodDelete, "/archive", "")
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", resp.StatusCode)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "archive")); !os.IsNotExist(err) {
+		t.Fatalf("expected archive dir to be removed, stat err = %v", err)
+	}
+	config, err = daedalus.LoadBoardConfig(root)
+	if err != nil {
+		t.Fatalf("reload config: %v", err)
+	}
+	if daedalus.FindListEntry(context.Background(), config.Lists, "archive") >= 0 {
+		t.Fatalf("expected archive list entry to be removed from board.yaml")
+	}
+}
+
+// MOVE on a list directory should rename it on disk and reconcile board.yaml to the new name.
+func TestWebDAV_MoveRenamesListAndReconciles(t *testing.T) {
+	root, srv := setupTestBoard(t)
+
+	req, _ := http.NewRequest("MOVE", srv.URL+"/todo", nil)
+	req.SetBasicAuth("alice", "secret")
+	req.Header.Set("Destination", srv.URL+"/doing")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+
+	config, err := daedalus.LoadBoardConfig(root)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if daedalus.FindListEntry(context.Background(), config.Lists, "todo") >= 0 {
+		t.Fatalf("expected old list entry %q to be gone", "todo")
+	}
+	if daedalus.FindListEntry(context.Background(), config.Lists, "doing") < 0 {
+		t.Fatalf("expected new list entry %q to be present", "doing")
+	}
+	if _, err := os.Stat(filepath.Join(root, "doing", "1.md")); err != nil {
+		t.Fatalf("expected card to have moved with the directory: %v", err)
+	}
+}
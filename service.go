@@ -0,0 +1,19 @@
+package main
+
+import "sync"
+
+// Service wraps App with a mutex so its methods -- which mutate a.board.Lists and
+// a.board.Config without any locking of their own -- can be driven concurrently by the HTTP/JSON
+// API added by `daedalus serve`. That's fine as-is for Wails IPC, which dispatches one bound
+// method call at a time, but not for a goroutine-per-request http.Server, so httpserver.go takes
+// Service's lock around every call into the wrapped App instead of calling it directly.
+type Service struct {
+	*App
+	mu sync.RWMutex
+}
+
+// newService wraps app for use by the HTTP API. The Wails bindings continue to use app
+// directly and are unaffected by Service's lock.
+func newService(app *App) *Service {
+	return &Service{App: app}
+}